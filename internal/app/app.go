@@ -4,6 +4,7 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -28,6 +29,7 @@ import (
 	"github.com/zhashkevych/creatly-backend/pkg/database/mongodb"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
 	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
 	"github.com/zhashkevych/creatly-backend/pkg/otp"
 )
 
@@ -70,7 +72,21 @@ func Run(configPath string) {
 	db := mongoClient.Database(cfg.Mongo.Name)
 
 	memCache := cache.NewMemoryCache()
-	hasher := hash.NewSHA1Hasher(cfg.Auth.PasswordSalt)
+
+	hasher, err := newPasswordHasher(cfg, hash.NewSHA1Hasher(cfg.Auth.PasswordSalt))
+	if err != nil {
+		logger.Error(err)
+
+		return
+	}
+
+	// admin passwords were stored without hashing before
+	adminHasher, err := newPasswordHasher(cfg, hash.NewPlaintextHasher())
+	if err != nil {
+		logger.Error(err)
+
+		return
+	}
 
 	emailSender, err := smtp.NewSMTPSender(cfg.SMTP.From, cfg.SMTP.Pass, cfg.SMTP.Host, cfg.SMTP.Port)
 	if err != nil {
@@ -79,7 +95,7 @@ func Run(configPath string) {
 		return
 	}
 
-	tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+	tokenManager, err := newTokenManager(cfg)
 	if err != nil {
 		logger.Error(err)
 
@@ -87,6 +103,7 @@ func Run(configPath string) {
 	}
 
 	otpGenerator := otp.NewGOTPGenerator()
+	oidcProvider := oidc.NewClient(&http.Client{Timeout: 10 * time.Second})
 
 	storageProvider, err := newStorageProvider(cfg)
 	if err != nil {
@@ -110,6 +127,7 @@ func Run(configPath string) {
 		Repos:                  repos,
 		Cache:                  memCache,
 		Hasher:                 hasher,
+		AdminHasher:            adminHasher,
 		TokenManager:           tokenManager,
 		EmailSender:            emailSender,
 		EmailConfig:            cfg.Email,
@@ -118,7 +136,13 @@ func Run(configPath string) {
 		FondyCallbackURL:       cfg.Payment.FondyCallbackURL,
 		CacheTTL:               int64(cfg.CacheTTL.Seconds()),
 		OtpGenerator:           otpGenerator,
+		TOTP:                   otpGenerator,
+		OIDCProvider:           oidcProvider,
 		VerificationCodeLength: cfg.Auth.VerificationCodeLength,
+		VerificationCodeTTL:    cfg.Auth.VerificationCodeTTL,
+		SignInAttempts:         cfg.Auth.SignInAttempts,
+		PasswordResetTokenTTL:  cfg.Auth.PasswordResetTokenTTL,
+		MagicLinkTTL:           cfg.Auth.MagicLinkTTL,
 		StorageProvider:        storageProvider,
 		Environment:            cfg.Environment,
 		Domain:                 cfg.HTTP.Host,
@@ -127,6 +151,8 @@ func Run(configPath string) {
 	handlers := delivery.NewHandler(services, tokenManager)
 
 	services.Files.InitStorageUploaderWorkers(context.Background())
+	services.Modules.InitPublishScheduler(context.Background())
+	services.StudentExports.InitExpiredExportsCleaner(context.Background())
 
 	// HTTP Server
 	srv := server.NewServer(cfg, handlers.Init(cfg))
@@ -172,3 +198,56 @@ func newStorageProvider(cfg *config.Config) (storage.Provider, error) {
 
 	return provider, nil
 }
+
+// newTokenManager creates manager with asymmetric keys from directory and config,
+// falling back to HS256 signing key when there are none.
+func newTokenManager(cfg *config.Config) (*auth.Manager, error) {
+	if cfg.Auth.JWT.KeysDir == "" && len(cfg.Auth.JWT.Keys) == 0 {
+		return auth.NewManager(cfg.Auth.JWT.SigningKey)
+	}
+
+	var keys []auth.Key
+
+	if cfg.Auth.JWT.KeysDir != "" {
+		loaded, err := auth.LoadKeys(cfg.Auth.JWT.KeysDir)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = loaded
+	}
+
+	for _, keyConfig := range cfg.Auth.JWT.Keys {
+		if keyConfig.PrivateKey != "" {
+			key, err := auth.ParsePrivateKey(keyConfig.ID, []byte(keyConfig.PrivateKey))
+			if err != nil {
+				return nil, err
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	for i := range keys {
+		for _, keyConfig := range cfg.Auth.JWT.Keys {
+			if keyConfig.ID == keys[i].ID {
+				keys[i].RetireAt = keyConfig.RetireAt
+			}
+		}
+	}
+
+	return auth.NewKeySetManager(cfg.Auth.JWT.SigningKeyID, keys)
+}
+
+// newPasswordHasher creates hasher for configured algorithm, which is also able
+// to verify legacy passwords, so they could be upgraded on the next sign in.
+func newPasswordHasher(cfg *config.Config, legacy hash.Algorithm) (hash.PasswordHasher, error) {
+	switch cfg.Auth.PasswordHashAlgorithm {
+	case "argon2id":
+		return hash.NewMultiHasher(hash.NewArgon2Hasher(hash.DefaultArgon2Params), legacy), nil
+	case "bcrypt":
+		return hash.NewMultiHasher(hash.NewBcryptHasher(0), legacy), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm: %s", cfg.Auth.PasswordHashAlgorithm)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -17,6 +18,14 @@ const (
 	defaultLimiterBurst           = 2
 	defaultLimiterTTL             = 10 * time.Minute
 	defaultVerificationCodeLength = 8
+	defaultPasswordHashAlgorithm  = "argon2id"
+	defaultPasswordResetTokenTTL  = time.Hour
+	defaultMagicLinkTTL           = 15 * time.Minute
+	defaultVerificationCodeTTL    = 24 * time.Hour
+	defaultSignInFreeAttempts     = 3
+	defaultSignInMaxAttempts      = 10
+	defaultSignInBackoffBase      = time.Second
+	defaultSignInLockoutDuration  = 15 * time.Minute
 
 	EnvLocal = "local"
 	Prod     = "prod"
@@ -47,13 +56,41 @@ type (
 	AuthConfig struct {
 		JWT                    JWTConfig
 		PasswordSalt           string
-		VerificationCodeLength int `mapstructure:"verificationCodeLength"`
+		PasswordHashAlgorithm  string        `mapstructure:"passwordHashAlgorithm"`
+		PasswordResetTokenTTL  time.Duration `mapstructure:"passwordResetTokenTTL"`
+		MagicLinkTTL           time.Duration `mapstructure:"magicLinkTTL"`
+		VerificationCodeLength int           `mapstructure:"verificationCodeLength"`
+		VerificationCodeTTL    time.Duration `mapstructure:"verificationCodeTTL"`
+		SignInAttempts         SignInAttemptsConfig
 	}
 
+	// SignInAttemptsConfig sets up per-account brute-force protection. After FreeAttempts failed sign-ins
+	// each next attempt is delayed by BackoffBase, doubled with every failure, and after MaxAttempts
+	// account is locked for LockoutDuration.
+	SignInAttemptsConfig struct {
+		FreeAttempts    int           `mapstructure:"freeAttempts"`
+		MaxAttempts     int           `mapstructure:"maxAttempts"`
+		BackoffBase     time.Duration `mapstructure:"backoffBase"`
+		LockoutDuration time.Duration `mapstructure:"lockoutDuration"`
+	}
+
+	// JWTConfig sets up access tokens. When KeysDir or Keys are set, tokens are signed with asymmetric key SigningKeyID,
+	// otherwise HS256 with SigningKey is used.
 	JWTConfig struct {
 		AccessTokenTTL  time.Duration `mapstructure:"accessTokenTTL"`
 		RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL"`
 		SigningKey      string
+		SigningKeyID    string         `mapstructure:"jwtSigningKeyId"`
+		KeysDir         string         `mapstructure:"jwtKeysDir"`
+		Keys            []JWTKeyConfig `mapstructure:"jwtKeys"`
+	}
+
+	// JWTKeyConfig describes a key by its ID. PrivateKey (PEM) can be omitted for keys loaded from KeysDir.
+	// Key with RetireAt only verifies tokens till that time.
+	JWTKeyConfig struct {
+		ID         string    `mapstructure:"id"`
+		PrivateKey string    `mapstructure:"privateKey"`
+		RetireAt   time.Time `mapstructure:"retireAt"`
 	}
 
 	FileStorageConfig struct {
@@ -71,11 +108,27 @@ type (
 	EmailTemplates struct {
 		Verification       string `mapstructure:"verification_email"`
 		PurchaseSuccessful string `mapstructure:"purchase_successful"`
+		PasswordReset      string `mapstructure:"password_reset"`
+		AccountLocked      string `mapstructure:"account_locked"`
+		AdminInvitation    string `mapstructure:"admin_invitation"`
+		MagicLink          string `mapstructure:"magic_link"`
+		EmailChange        string `mapstructure:"email_change"`
+		ConfirmationCode   string `mapstructure:"confirmation_code"`
+		DataExport         string `mapstructure:"data_export"`
+		StudentWelcome     string `mapstructure:"student_welcome"`
 	}
 
 	EmailSubjects struct {
 		Verification       string `mapstructure:"verification_email"`
 		PurchaseSuccessful string `mapstructure:"purchase_successful"`
+		PasswordReset      string `mapstructure:"password_reset"`
+		AccountLocked      string `mapstructure:"account_locked"`
+		AdminInvitation    string `mapstructure:"admin_invitation"`
+		MagicLink          string `mapstructure:"magic_link"`
+		EmailChange        string `mapstructure:"email_change"`
+		ConfirmationCode   string `mapstructure:"confirmation_code"`
+		DataExport         string `mapstructure:"data_export"`
+		StudentWelcome     string `mapstructure:"student_welcome"`
 	}
 
 	PaymentConfig struct {
@@ -143,7 +196,10 @@ func unmarshal(cfg *Config) error {
 		return err
 	}
 
-	if err := viper.UnmarshalKey("auth", &cfg.Auth.JWT); err != nil {
+	if err := viper.UnmarshalKey("auth", &cfg.Auth.JWT, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+	))); err != nil {
 		return err
 	}
 
@@ -151,6 +207,26 @@ func unmarshal(cfg *Config) error {
 		return err
 	}
 
+	if err := viper.UnmarshalKey("auth.verificationCodeTTL", &cfg.Auth.VerificationCodeTTL); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("auth.signInAttempts", &cfg.Auth.SignInAttempts); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("auth.passwordHashAlgorithm", &cfg.Auth.PasswordHashAlgorithm); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("auth.passwordResetTokenTTL", &cfg.Auth.PasswordResetTokenTTL); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("auth.magicLinkTTL", &cfg.Auth.MagicLinkTTL); err != nil {
+		return err
+	}
+
 	if err := viper.UnmarshalKey("fileStorage", &cfg.FileStorage); err != nil {
 		return err
 	}
@@ -223,6 +299,14 @@ func populateDefaults() {
 	viper.SetDefault("auth.accessTokenTTL", defaultAccessTokenTTL)
 	viper.SetDefault("auth.refreshTokenTTL", defaultRefreshTokenTTL)
 	viper.SetDefault("auth.verificationCodeLength", defaultVerificationCodeLength)
+	viper.SetDefault("auth.verificationCodeTTL", defaultVerificationCodeTTL)
+	viper.SetDefault("auth.signInAttempts.freeAttempts", defaultSignInFreeAttempts)
+	viper.SetDefault("auth.signInAttempts.maxAttempts", defaultSignInMaxAttempts)
+	viper.SetDefault("auth.signInAttempts.backoffBase", defaultSignInBackoffBase)
+	viper.SetDefault("auth.signInAttempts.lockoutDuration", defaultSignInLockoutDuration)
+	viper.SetDefault("auth.passwordHashAlgorithm", defaultPasswordHashAlgorithm)
+	viper.SetDefault("auth.passwordResetTokenTTL", defaultPasswordResetTokenTTL)
+	viper.SetDefault("auth.magicLinkTTL", defaultMagicLinkTTL)
 	viper.SetDefault("limiter.rps", defaultLimiterRPS)
 	viper.SetDefault("limiter.burst", defaultLimiterBurst)
 	viper.SetDefault("limiter.ttl", defaultLimiterTTL)
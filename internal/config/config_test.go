@@ -102,8 +102,23 @@ func TestInit(t *testing.T) {
 						RefreshTokenTTL: time.Minute * 30,
 						AccessTokenTTL:  time.Minute * 15,
 						SigningKey:      "key",
+						SigningKeyID:    "2026-10",
+						KeysDir:         "./keys",
+						Keys: []JWTKeyConfig{
+							{ID: "2026-04", RetireAt: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+						},
 					},
+					PasswordHashAlgorithm:  "bcrypt",
+					PasswordResetTokenTTL:  time.Hour,
+					MagicLinkTTL:           15 * time.Minute,
+					VerificationCodeTTL:    24 * time.Hour,
 					VerificationCodeLength: 10,
+					SignInAttempts: SignInAttemptsConfig{
+						FreeAttempts:    3,
+						MaxAttempts:     10,
+						BackoffBase:     time.Second,
+						LockoutDuration: 15 * time.Minute,
+					},
 				},
 				Mongo: MongoConfig{
 					Name:     "testDatabase",
@@ -121,10 +136,26 @@ func TestInit(t *testing.T) {
 					Templates: EmailTemplates{
 						Verification:       "./templates/verification_email.html",
 						PurchaseSuccessful: "./templates/purchase_successful.html",
+						PasswordReset:      "./templates/password_reset.html",
+						AccountLocked:      "./templates/account_locked.html",
+						AdminInvitation:    "./templates/admin_invitation.html",
+						MagicLink:          "./templates/magic_link.html",
+						EmailChange:        "./templates/email_change.html",
+						ConfirmationCode:   "./templates/confirmation_code.html",
+						DataExport:         "./templates/data_export.html",
+						StudentWelcome:     "./templates/student_welcome.html",
 					},
 					Subjects: EmailSubjects{
 						Verification:       "Спасибо за регистрацию, %s!",
 						PurchaseSuccessful: "Покупка прошла успешно!",
+						PasswordReset:      "Восстановление пароля, %s",
+						AccountLocked:      "Вход в аккаунт временно заблокирован",
+						AdminInvitation:    "Приглашение в команду школы %s",
+						MagicLink:          "Ссылка для входа",
+						EmailChange:        "Подтверждение нового email",
+						ConfirmationCode:   "Код подтверждения",
+						DataExport:         "Твои данные готовы к скачиванию",
+						StudentWelcome:     "Добро пожаловать в школу, %s!",
 					},
 				},
 				Payment: PaymentConfig{
@@ -3,6 +3,7 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -15,6 +16,9 @@ import (
 	"github.com/zhashkevych/creatly-backend/pkg/limiter"
 )
 
+// jwksMaxAge is how long clients may cache key set, it must be shorter than retired key grace period.
+const jwksMaxAge = 15 * time.Minute
+
 type Handler struct {
 	services     *service.Services
 	tokenManager auth.TokenManager
@@ -52,6 +56,9 @@ func (h *Handler) Init(cfg *config.Config) *gin.Engine {
 		c.String(http.StatusOK, "pong")
 	})
 
+	// public keys to verify access tokens
+	router.GET("/.well-known/jwks.json", h.jwks)
+
 	h.initAPI(router)
 
 	return router
@@ -64,3 +71,8 @@ func (h *Handler) initAPI(router *gin.Engine) {
 		handlerV1.Init(api)
 	}
 }
+
+func (h *Handler) jwks(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksMaxAge.Seconds())))
+	c.JSON(http.StatusOK, h.tokenManager.JWKS())
+}
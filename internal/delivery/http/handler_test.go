@@ -1,6 +1,9 @@
 package http_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -40,3 +43,30 @@ func TestNewHandler_Init(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+func TestNewHandler_JWKS(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tokenManager, err := auth.NewKeySetManager("key", []auth.Key{{ID: "key", PrivateKey: privateKey}})
+	require.NoError(t, err)
+
+	router := handler.NewHandler(&service.Services{}, tokenManager).Init(&config.Config{
+		Limiter: config.LimiterConfig{
+			RPS:   2,
+			Burst: 4,
+			TTL:   10 * time.Minute,
+		},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var jwks auth.JWKSet
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&jwks))
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, "key", jwks.Keys[0].KeyID)
+	require.Equal(t, "OKP", jwks.Keys[0].KeyType)
+}
@@ -9,30 +9,49 @@ import (
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func (h *Handler) initAdminRoutes(api *gin.RouterGroup) { //nolint:funlen
 	admins := api.Group("/admins", h.setSchoolFromRequest)
 	{
 		admins.POST("/sign-in", h.adminSignIn)
+		admins.POST("/sign-in/2fa", h.adminSignInTwoFactor)
 		admins.POST("/auth/refresh", h.adminRefresh)
+		admins.POST("/password-reset", h.adminRequestPasswordReset)
+		admins.POST("/password-reset/confirm", h.adminResetPassword)
+
+		admins.POST("/team/invitations/accept", h.adminAcceptInvitation)
 
 		authenticated := admins.Group("/", h.adminIdentity)
 		{
-			courses := authenticated.Group("/courses")
+			var (
+				member  = h.adminPermission(domain.AdminRoleOwner, domain.AdminRoleEditor, domain.AdminRoleSupport, domain.AdminRoleFinance)
+				owner   = h.adminPermission(domain.AdminRoleOwner)
+				content = h.adminPermission(domain.AdminRoleOwner, domain.AdminRoleEditor)
+				finance = h.adminPermission(domain.AdminRoleOwner, domain.AdminRoleFinance)
+
+				// routes available with API keys
+				coursesAccess    = h.adminAccess(domain.APIKeyResourceCourses, domain.AdminRoleOwner, domain.AdminRoleEditor)
+				offersAccess     = h.adminAccess(domain.APIKeyResourceOffers, domain.AdminRoleOwner, domain.AdminRoleEditor, domain.AdminRoleFinance)
+				promocodesAccess = h.adminAccess(domain.APIKeyResourcePromocodes, domain.AdminRoleOwner, domain.AdminRoleEditor, domain.AdminRoleFinance)
+				ordersAccess     = h.adminAccess(domain.APIKeyResourceOrders, domain.AdminRoleOwner, domain.AdminRoleFinance)
+				studentsAccess   = h.adminAccess(domain.APIKeyResourceStudents, domain.AdminRoleOwner, domain.AdminRoleSupport)
+			)
+
+			courses := authenticated.Group("/courses", coursesAccess)
 			{
 				courses.POST("", h.adminCreateCourse)
 				courses.GET("", h.adminGetAllCourses)
 				courses.GET("/:id", h.adminGetCourseById)
 				courses.PUT("/:id", h.adminUpdateCourse)
 				courses.DELETE("/:id", h.adminDeleteCourse)
+				courses.GET("/:id/progress", h.adminGetCourseProgress)
 				courses.POST("/:id/modules", h.adminCreateModule)
 				courses.POST("/:id/packages", h.adminCreatePackage)
 				courses.GET("/:id/packages", h.adminGetAllPackages)
 			}
 
-			modules := authenticated.Group("/modules")
+			modules := authenticated.Group("/modules", coursesAccess)
 			{
 				modules.PUT("/:id", h.adminUpdateModule)
 				modules.DELETE("/:id", h.adminDeleteModule)
@@ -47,21 +66,26 @@ func (h *Handler) initAdminRoutes(api *gin.RouterGroup) { //nolint:funlen
 				modules.GET("/:id/survey/results/:studentId", h.adminGetSurveyStudentResults)
 			}
 
-			lessons := authenticated.Group("/lessons")
+			lessons := authenticated.Group("/lessons", coursesAccess)
 			{
 				lessons.GET("/:id", h.adminGetLessonById)
 				lessons.PUT("/:id", h.adminUpdateLesson)
 				lessons.DELETE("/:id", h.adminDeleteLesson)
+				lessons.POST("/:id/publish", h.adminPublishLessonDraft)
+				lessons.GET("/:id/versions", h.adminGetLessonVersions)
+				lessons.GET("/:id/versions/:version", h.adminGetLessonVersion)
+				lessons.POST("/:id/versions/:version/restore", h.adminRestoreLessonVersion)
+				lessons.GET("/:id/diff", h.adminDiffLessonVersions)
 			}
 
-			packages := authenticated.Group("/packages")
+			packages := authenticated.Group("/packages", coursesAccess)
 			{
 				packages.GET("/:id", h.adminGetPackageById)
 				packages.PUT("/:id", h.adminUpdatePackage)
 				packages.DELETE("/:id", h.adminDeletePackage)
 			}
 
-			offers := authenticated.Group("/offers")
+			offers := authenticated.Group("/offers", offersAccess)
 			{
 				offers.POST("", h.adminCreateOffer)
 				offers.GET("", h.adminGetAllOffers)
@@ -72,12 +96,14 @@ func (h *Handler) initAdminRoutes(api *gin.RouterGroup) { //nolint:funlen
 
 			school := authenticated.Group("/school")
 			{
-				school.PUT("/settings", h.adminUpdateSchoolSettings)
-				school.PUT("/settings/fondy", h.adminConnectFondy)
-				school.PUT("/settings/sendpulse", h.adminConnectSendPulse)
+				school.PUT("/settings", owner, h.adminUpdateSchoolSettings)
+				school.PUT("/settings/fondy", finance, h.adminConnectFondy)
+				school.PUT("/settings/sendpulse", owner, h.adminConnectSendPulse)
+				school.PUT("/settings/oidc", owner, h.adminSetOIDCProviders)
+				school.PUT("/settings/student-fields", owner, h.adminSetStudentFieldsSchema)
 			}
 
-			promocodes := authenticated.Group("/promocodes")
+			promocodes := authenticated.Group("/promocodes", promocodesAccess)
 			{
 				promocodes.POST("", h.adminCreatePromocode)
 				promocodes.GET("", h.adminGetPromocodes)
@@ -86,33 +112,85 @@ func (h *Handler) initAdminRoutes(api *gin.RouterGroup) { //nolint:funlen
 				promocodes.DELETE("/:id", h.adminDeletePromocode)
 			}
 
-			orders := authenticated.Group("/orders")
+			orders := authenticated.Group("/orders", ordersAccess)
 			{
 				orders.GET("", h.adminGetOrders)
 				orders.PUT("/:id", h.adminUpdateOrderStatus)
 			}
 
-			students := authenticated.Group("/students")
+			students := authenticated.Group("/students", studentsAccess)
 			{
 				students.GET("", h.adminGetStudents)
 				students.POST("", h.adminCreateStudent)
+				students.GET("/export", h.adminExportStudents)
+				students.POST("/tags", h.adminUpdateStudentsTags)
 				students.GET("/:id", h.adminGetStudentById)
 				students.PUT("/:id", h.adminUpdateStudent)
 				students.DELETE("/:id", h.adminDeleteStudent)
+				students.POST("/:id/verification/resend", h.adminResendStudentVerification)
+				students.POST("/:id/export", h.adminExportStudentData)
 				students.PATCH("/:id/offers/:offerId", h.adminManageOfferPermission)
+				students.POST("/:id/tags", h.adminAddStudentTags)
+				students.DELETE("/:id/tags/:tag", h.adminRemoveStudentTag)
+				students.PUT("/:id/fields", h.adminSetStudentFields)
+				students.GET("/:id/activity", h.adminGetStudentActivity)
+			}
+
+			segments := authenticated.Group("/segments", studentsAccess)
+			{
+				segments.GET("", h.adminGetStudentSegments)
+				segments.POST("", h.adminCreateStudentSegment)
+				segments.PUT("/:id", h.adminUpdateStudentSegment)
+				segments.DELETE("/:id", h.adminDeleteStudentSegment)
 			}
 
-			upload := authenticated.Group("/upload")
+			// imports aren't available with API keys
+			studentsImport := authenticated.Group("/students/import", h.adminPermission(domain.AdminRoleOwner, domain.AdminRoleSupport))
+			{
+				studentsImport.POST("", h.adminImportStudents)
+				studentsImport.GET("/:id", h.adminGetStudentImport)
+			}
+
+			upload := authenticated.Group("/upload", content)
 			{
 				upload.POST("/image", h.adminUploadImage)
 				upload.POST("/video", h.adminUploadVideo)
 				upload.POST("/file", h.adminUploadFile)
 			}
 
-			media := authenticated.Group("/media")
+			media := authenticated.Group("/media", content)
 			{
 				media.GET("/videos/:id", h.adminGetVideo)
 			}
+
+			team := authenticated.Group("/team", member)
+			{
+				team.GET("", h.adminGetTeam)
+				team.POST("/invitations", owner, h.adminInviteToTeam)
+				team.PUT("/:id/role", owner, h.adminSetTeamRole)
+				team.DELETE("/:id", owner, h.adminRemoveFromTeam)
+			}
+
+			sessions := authenticated.Group("/sessions", member)
+			{
+				sessions.GET("", h.adminGetSessions)
+				sessions.DELETE("", h.adminRevokeSessions)
+				sessions.DELETE("/:id", h.adminRevokeSession)
+			}
+
+			twoFactor := authenticated.Group("/2fa", member)
+			{
+				twoFactor.POST("/enroll", h.adminEnrollTwoFactor)
+				twoFactor.POST("/confirm", h.adminConfirmTwoFactor)
+				twoFactor.DELETE("", h.adminDisableTwoFactor)
+			}
+
+			apiKeys := authenticated.Group("/api-keys", owner)
+			{
+				apiKeys.POST("", h.adminCreateAPIKey)
+				apiKeys.GET("", h.adminGetAPIKeys)
+				apiKeys.DELETE("/:id", h.adminRevokeAPIKey)
+			}
 		}
 	}
 }
@@ -125,7 +203,8 @@ func (h *Handler) initAdminRoutes(api *gin.RouterGroup) { //nolint:funlen
 // @Produce  json
 // @Param input body signInInput true "sign up info"
 // @Success 200 {object} tokenResponse
-// @Failure 400,404 {object} response
+// @Success 202 {object} twoFactorChallengeResponse
+// @Failure 400,404,429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /admins/sign-in [post]
@@ -144,24 +223,45 @@ func (h *Handler) adminSignIn(c *gin.Context) {
 		return
 	}
 
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
 	res, err := h.services.Admins.SignIn(c.Request.Context(), service.SchoolSignInInput{
-		Email:    inp.Email,
-		Password: inp.Password,
-		SchoolID: school.ID,
+		Email:        inp.Email,
+		Password:     inp.Password,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+		Device:       getDevice(c),
 	})
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
 			newResponse(c, http.StatusUnauthorized, err.Error())
-		} else {
+		case errors.Is(err, domain.ErrTooManyAttempts):
+			newResponse(c, http.StatusTooManyRequests, err.Error())
+		default:
 			newResponse(c, http.StatusInternalServerError, err.Error())
 		}
 
 		return
 	}
 
+	if res.ChallengeToken != "" {
+		c.JSON(http.StatusAccepted, twoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    res.ChallengeToken,
+		})
+
+		return
+	}
+
 	c.JSON(http.StatusOK, tokenResponse{
-		AccessToken:  res.AccessToken,
-		RefreshToken: res.RefreshToken,
+		AccessToken:  res.Tokens.AccessToken,
+		RefreshToken: res.Tokens.RefreshToken,
 	})
 }
 
@@ -191,13 +291,31 @@ func (h *Handler) adminRefresh(c *gin.Context) {
 		return
 	}
 
-	res, err := h.services.Admins.RefreshTokens(c.Request.Context(), school.ID, inp.Token)
+	schoolDomain, err := getDomainFromContext(c)
 	if err != nil {
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
 	}
 
+	res, err := h.services.Admins.RefreshTokens(c.Request.Context(), service.SchoolRefreshTokensInput{
+		RefreshToken: inp.Token,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+		Device:       getDevice(c),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			newResponse(c, http.StatusUnauthorized, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
 	c.JSON(http.StatusOK, tokenResponse{
 		AccessToken:  res.AccessToken,
 		RefreshToken: res.RefreshToken,
@@ -340,12 +458,13 @@ type updateCourseInput struct {
 	Description *string `json:"description"`
 	Color       *string `json:"color"`
 	Published   *bool   `json:"published"`
+	Gating      *string `json:"gating"`
 }
 
 // @Summary Admin Update Course
 // @Security AdminAuth
 // @Tags admins-courses
-// @Description admin update course
+// @Description admin update course. Gating is open or sequential, in sequential course module unlocks after the previous one is completed
 // @ModuleID adminUpdateCourse
 // @Accept  json
 // @Produce  json
@@ -386,7 +505,14 @@ func (h *Handler) adminUpdateCourse(c *gin.Context) {
 		ImageURL:    inp.ImageURL,
 		Color:       inp.Color,
 		Published:   inp.Published,
+		Gating:      inp.Gating,
 	}); err != nil {
+		if errors.Is(err, domain.ErrGatingModeInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -433,6 +559,51 @@ func (h *Handler) adminDeleteCourse(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// @Summary Admin Get Course Progress
+// @Security AdminAuth
+// @Tags admins-courses
+// @Description admin get progress of the course students: how many started, finished at least half of the lessons
+// @Description and completed the course
+// @ModuleID adminGetCourseProgress
+// @Accept  json
+// @Produce  json
+// @Param id path string true "course id"
+// @Success 200 {object} domain.CourseProgressStats
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/courses/{id}/progress [get]
+func (h *Handler) adminGetCourseProgress(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	stats, err := h.services.CourseProgress.GetStats(c.Request.Context(), school.ID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCourseNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
 type createModuleInput struct {
 	Name     string `json:"name" binding:"required,min=5"`
 	Position uint   `json:"position"`
@@ -490,15 +661,20 @@ func (h *Handler) adminCreateModule(c *gin.Context) {
 }
 
 type updateModuleInput struct {
-	Name      string `json:"name"`
-	Position  *uint  `json:"position"`
-	Published *bool  `json:"published"`
+	Name      string     `json:"name"`
+	Position  *uint      `json:"position"`
+	Published *bool      `json:"published"`
+	PublishAt *time.Time `json:"publishAt"`
+	DripDays  *uint      `json:"dripDays"`
+	Gating    *string    `json:"gating"`
 }
 
 // @Summary Admin Update Module
 // @Security AdminAuth
 // @Tags admins-modules
-// @Description admin update course
+// @Description admin update module. Module is unpublished till publishAt, zero time cancels the schedule.
+// @Description dripDays unlocks module for student N days after access to the offer.
+// @Description Gating is open or sequential, empty gating inherits mode of the course
 // @ModuleID adminUpdateModule
 // @Accept  json
 // @Produce  json
@@ -537,7 +713,16 @@ func (h *Handler) adminUpdateModule(c *gin.Context) {
 		Name:      inp.Name,
 		Position:  inp.Position,
 		Published: inp.Published,
+		PublishAt: inp.PublishAt,
+		DripDays:  inp.DripDays,
+		Gating:    inp.Gating,
 	}); err != nil {
+		if errors.Is(err, domain.ErrContentScheduleInvalid) || errors.Is(err, domain.ErrGatingModeInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -726,14 +911,32 @@ func (h *Handler) adminGetLessonById(c *gin.Context) {
 		return
 	}
 
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	lesson.Draft, err = h.services.Lessons.GetDraft(c.Request.Context(), school.ID, id)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
 	c.JSON(http.StatusOK, lesson)
 }
 
 type updateLessonInput struct {
-	Name      string `json:"name"`
-	Content   string `json:"content"`
-	Position  *uint  `json:"position"`
-	Published *bool  `json:"published"`
+	Name      string                `json:"name"`
+	Content   string                `json:"content"`
+	Blocks    *[]domain.LessonBlock `json:"blocks"`
+	Draft     bool                  `json:"draft"`
+	Position  *uint                 `json:"position"`
+	Published *bool                 `json:"published"`
+	PublishAt *time.Time            `json:"publishAt"`
+	DripDays  *uint                 `json:"dripDays"`
 }
 
 // @Summary Admin Update Lesson
@@ -772,14 +975,34 @@ func (h *Handler) adminUpdateLesson(c *gin.Context) {
 		return
 	}
 
+	var blocks []domain.LessonBlock
+	if inp.Blocks != nil {
+		blocks = *inp.Blocks
+	}
+
+	// admin id is absent, when request is authenticated with api key
+	adminId, _ := getIdByContext(c, adminCtx)
+
 	if err := h.services.Lessons.Update(c.Request.Context(), service.UpdateLessonInput{
 		LessonID:  id,
+		AdminID:   adminId,
 		Name:      inp.Name,
 		Content:   inp.Content,
+		Blocks:    blocks,
+		Draft:     inp.Draft,
 		Position:  inp.Position,
 		Published: inp.Published,
+		PublishAt: inp.PublishAt,
+		DripDays:  inp.DripDays,
 		SchoolID:  school.ID.Hex(),
 	}); err != nil {
+		if errors.Is(err, domain.ErrLessonBlockInvalid) || errors.Is(err, domain.ErrLessonBlocksTooMany) ||
+			errors.Is(err, domain.ErrContentScheduleInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -1640,6 +1863,7 @@ type (
 		GoogleAnalyticsCode *string      `json:"googleAnalyticsCode"`
 		LogoURL             *string      `json:"logo"`
 		DisableRegistration *bool        `json:"disableRegistration"`
+		MagicLinkLogin      *bool        `json:"magicLinkLogin"`
 	}
 )
 
@@ -1652,7 +1876,7 @@ type (
 // @Produce  json
 // @Param input body updateSchoolSettingsInput true "update school settings"
 // @Success 200 {string} string "ok"
-// @Failure 400,404 {object} response
+// @Failure 400,404,409 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /admins/school/settings [put]
@@ -1671,6 +1895,22 @@ func (h *Handler) adminUpdateSchoolSettings(c *gin.Context) {
 		return
 	}
 
+	if err := h.services.Schools.UpdateSettings(c.Request.Context(), school.ID, toUpdateSchoolSettingsInput(inp)); err != nil {
+		if errors.Is(err, domain.ErrSchoolDomainTaken) {
+			newResponse(c, http.StatusConflict, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func toUpdateSchoolSettingsInput(inp updateSchoolSettingsInput) domain.UpdateSchoolSettingsInput {
 	updateInput := domain.UpdateSchoolSettingsInput{
 		Name:                inp.Name,
 		Color:               inp.Color,
@@ -1680,6 +1920,7 @@ func (h *Handler) adminUpdateSchoolSettings(c *gin.Context) {
 		GoogleAnalyticsCode: inp.GoogleAnalyticsCode,
 		LogoURL:             inp.LogoURL,
 		DisableRegistration: inp.DisableRegistration,
+		MagicLinkLogin:      inp.MagicLinkLogin,
 	}
 
 	if inp.Pages != nil {
@@ -1700,13 +1941,7 @@ func (h *Handler) adminUpdateSchoolSettings(c *gin.Context) {
 		}
 	}
 
-	if err := h.services.Schools.UpdateSettings(c.Request.Context(), school.ID, updateInput); err != nil {
-		newResponse(c, http.StatusInternalServerError, err.Error())
-
-		return
-	}
-
-	c.Status(http.StatusOK)
+	return updateInput
 }
 
 type connectFondyInput struct {
@@ -1803,6 +2038,68 @@ func (h *Handler) adminConnectSendPulse(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+type oidcProviderInput struct {
+	Name         string `json:"name" binding:"required"`
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"clientId" binding:"required"`
+	ClientSecret string `json:"clientSecret"`
+	AuthURL      string `json:"authUrl"`
+	TokenURL     string `json:"tokenUrl"`
+	UserInfoURL  string `json:"userInfoUrl"`
+}
+
+type setOIDCProvidersInput struct {
+	Providers []oidcProviderInput `json:"providers" binding:"dive"`
+}
+
+// @Summary Admin Set OIDC Providers
+// @Security AdminAuth
+// @Tags admins-school
+// @Description admin set OIDC providers students can sign in with, empty client secret keeps the saved one
+// @ModuleID adminSetOIDCProviders
+// @Accept  json
+// @Produce  json
+// @Param input body setOIDCProvidersInput true "oidc providers"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/school/settings/oidc [put]
+func (h *Handler) adminSetOIDCProviders(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	var inp setOIDCProvidersInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	providers := make([]domain.OIDCProvider, len(inp.Providers))
+	for i, provider := range inp.Providers {
+		providers[i] = domain.OIDCProvider(provider)
+	}
+
+	if err := h.services.Schools.SetOIDCProviders(c.Request.Context(), school.ID, providers); err != nil {
+		if errors.Is(err, domain.ErrOIDCProviderInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 // @Summary Admin Get Orders
 // @Security AdminAuth
 // @Tags admins-orders
@@ -0,0 +1,271 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+// @Summary Admin Publish Lesson Draft
+// @Security AdminAuth
+// @Tags admins-lessons
+// @Description admin publish lesson draft, so students see it
+// @ModuleID adminPublishLessonDraft
+// @Accept  json
+// @Produce  json
+// @Param id path string true "lesson id"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/lessons/{id}/publish [post]
+func (h *Handler) adminPublishLessonDraft(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Lessons.PublishDraft(c.Request.Context(), school.ID, id); err != nil {
+		newLessonVersionErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Admin Get Lesson Versions
+// @Security AdminAuth
+// @Tags admins-lessons
+// @Description admin get lesson content versions without blocks, newest first
+// @ModuleID adminGetLessonVersions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "lesson id"
+// @Param skip query int false "skip"
+// @Param limit query int false "limit"
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/lessons/{id}/versions [get]
+func (h *Handler) adminGetLessonVersions(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var query domain.PaginationQuery
+	if err := c.Bind(&query); err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	versions, count, err := h.services.Lessons.GetVersions(c.Request.Context(), school.ID, id, query)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{
+		Data:  versions,
+		Count: count,
+	})
+}
+
+// @Summary Admin Get Lesson Version
+// @Security AdminAuth
+// @Tags admins-lessons
+// @Description admin get lesson content version with blocks
+// @ModuleID adminGetLessonVersion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "lesson id"
+// @Param version path int true "version"
+// @Success 200 {object} domain.LessonContentVersion
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/lessons/{id}/versions/{version} [get]
+func (h *Handler) adminGetLessonVersion(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid version param")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	version, err := h.services.Lessons.GetVersion(c.Request.Context(), school.ID, id, number)
+	if err != nil {
+		newLessonVersionErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+type restoreLessonVersionInput struct {
+	Publish bool `form:"publish"`
+}
+
+// @Summary Admin Restore Lesson Version
+// @Security AdminAuth
+// @Tags admins-lessons
+// @Description admin restore lesson content version, it's saved as a new version. It's saved as draft unless publish is true
+// @ModuleID adminRestoreLessonVersion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "lesson id"
+// @Param version path int true "version"
+// @Param publish query bool false "publish restored version"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/lessons/{id}/versions/{version}/restore [post]
+func (h *Handler) adminRestoreLessonVersion(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid version param")
+
+		return
+	}
+
+	var inp restoreLessonVersionInput
+	if err := c.BindQuery(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	// admin id is absent, when request is authenticated with api key
+	adminId, _ := getIdByContext(c, adminCtx)
+
+	if err := h.services.Lessons.RestoreVersion(c.Request.Context(), service.RestoreLessonVersionInput{
+		SchoolID: school.ID,
+		LessonID: id,
+		AdminID:  adminId,
+		Version:  number,
+		Publish:  inp.Publish,
+	}); err != nil {
+		newLessonVersionErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type diffLessonVersionsInput struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// @Summary Admin Diff Lesson Versions
+// @Security AdminAuth
+// @Tags admins-lessons
+// @Description admin get blocks added, removed, changed or moved between two lesson versions
+// @ModuleID adminDiffLessonVersions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "lesson id"
+// @Param from query int true "from version"
+// @Param to query int true "to version"
+// @Success 200 {object} domain.LessonContentDiff
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/lessons/{id}/diff [get]
+func (h *Handler) adminDiffLessonVersions(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp diffLessonVersionsInput
+	if err := c.BindQuery(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	diff, err := h.services.Lessons.DiffVersions(c.Request.Context(), school.ID, id, inp.From, inp.To)
+	if err != nil {
+		newLessonVersionErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func newLessonVersionErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLessonVersionNotFound), errors.Is(err, domain.ErrLessonDraftNotFound):
+		newResponse(c, http.StatusNotFound, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
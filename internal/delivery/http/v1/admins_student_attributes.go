@@ -0,0 +1,266 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type studentFieldInput struct {
+	Key  string `json:"key" binding:"required"`
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type" binding:"required"`
+}
+
+type setStudentFieldsSchemaInput struct {
+	Fields []studentFieldInput `json:"fields" binding:"dive"`
+}
+
+// @Summary Admin Set Student Fields
+// @Security AdminAuth
+// @Tags admins-school
+// @Description admin set custom student fields, type is one of text, number, date (YYYY-MM-DD)
+// @ModuleID adminSetStudentFieldsSchema
+// @Accept  json
+// @Produce  json
+// @Param input body setStudentFieldsSchemaInput true "student fields"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/school/settings/student-fields [put]
+func (h *Handler) adminSetStudentFieldsSchema(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	var inp setStudentFieldsSchemaInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	fields := make([]domain.StudentField, len(inp.Fields))
+	for i, field := range inp.Fields {
+		fields[i] = domain.StudentField(field)
+	}
+
+	if err := h.services.Schools.SetStudentFields(c.Request.Context(), school.ID, fields); err != nil {
+		if errors.Is(err, domain.ErrStudentFieldsInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type studentTagsInput struct {
+	Tags []string `json:"tags" binding:"required,min=1"`
+}
+
+// @Summary Admin Add Student Tags
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin add tags to the student
+// @ModuleID adminAddStudentTags
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Param input body studentTagsInput true "tags"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/tags [post]
+func (h *Handler) adminAddStudentTags(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp studentTagsInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	h.updateStudentTags(c, []primitive.ObjectID{id}, inp.Tags, nil)
+}
+
+// @Summary Admin Remove Student Tag
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin remove tag from the student
+// @ModuleID adminRemoveStudentTag
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Param tag path string true "tag"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/tags/{tag} [delete]
+func (h *Handler) adminRemoveStudentTag(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	h.updateStudentTags(c, []primitive.ObjectID{id}, nil, []string{c.Param("tag")})
+}
+
+type bulkStudentTagsInput struct {
+	StudentIDs []string `json:"studentIds" binding:"required,min=1"`
+	Add        []string `json:"add"`
+	Remove     []string `json:"remove"`
+}
+
+// @Summary Admin Update Students Tags
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin add and remove tags of many students at once
+// @ModuleID adminUpdateStudentsTags
+// @Accept  json
+// @Produce  json
+// @Param input body bulkStudentTagsInput true "students and tags"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/tags [post]
+func (h *Handler) adminUpdateStudentsTags(c *gin.Context) {
+	var inp bulkStudentTagsInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	ids := make([]primitive.ObjectID, len(inp.StudentIDs))
+
+	for i, studentId := range inp.StudentIDs {
+		id, err := primitive.ObjectIDFromHex(studentId)
+		if err != nil {
+			newResponse(c, http.StatusBadRequest, "invalid student id")
+
+			return
+		}
+
+		ids[i] = id
+	}
+
+	h.updateStudentTags(c, ids, inp.Add, inp.Remove)
+}
+
+func (h *Handler) updateStudentTags(c *gin.Context, studentIds []primitive.ObjectID, add, remove []string) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.StudentAttributes.UpdateTags(c.Request.Context(), service.UpdateStudentTagsInput{
+		SchoolID:   school.ID,
+		StudentIDs: studentIds,
+		Add:        add,
+		Remove:     remove,
+	}); err != nil {
+		if errors.Is(err, domain.ErrStudentTagInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type setStudentFieldsInput struct {
+	Fields map[string]string `json:"fields" binding:"required"`
+}
+
+// @Summary Admin Set Student Fields Values
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin set values of custom student fields by key, empty value removes the field
+// @ModuleID adminSetStudentFields
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Param input body setStudentFieldsInput true "fields values"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/fields [put]
+func (h *Handler) adminSetStudentFields(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp setStudentFieldsInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.StudentAttributes.SetFields(c.Request.Context(), service.SetStudentFieldsInput{
+		School:    school,
+		StudentID: id,
+		Fields:    inp.Fields,
+	}); err != nil {
+		if errors.Is(err, domain.ErrStudentFieldInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		if errors.Is(err, domain.ErrUserNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+const maxStudentImportSize = 10 << 20 // 10 megabytes
+
+// @Summary Admin Import Students
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin import students from CSV file with columns name, email, password (optional) and offers
+// @Description (optional, ids separated by ";"). Import runs in background, its progress and report are available by id
+// @ModuleID adminImportStudents
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "csv file"
+// @Param dryRun formData bool false "only validate rows"
+// @Param emails formData string false "emails sent to created students: welcome | verification"
+// @Success 202 {object} domain.StudentImport
+// @Failure 400,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/import [post]
+func (h *Handler) adminImportStudents(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStudentImportSize)
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	defer file.Close()
+
+	var dryRun bool
+
+	if value := c.Request.FormValue("dryRun"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			newResponse(c, http.StatusBadRequest, "dryRun must be boolean")
+
+			return
+		}
+	}
+
+	emails := c.Request.FormValue("emails")
+	if !domain.IsValidStudentImportEmails(emails) {
+		newResponse(c, http.StatusBadRequest, "emails must be welcome or verification")
+
+		return
+	}
+
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	studentImport, err := h.services.StudentImports.Start(c.Request.Context(), service.StartStudentImportInput{
+		SchoolID:     school.ID,
+		AdminID:      adminId,
+		SchoolDomain: schoolDomain,
+		File:         file,
+		DryRun:       dryRun,
+		Emails:       emails,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrStudentImportInvalid) || errors.Is(err, domain.ErrStudentImportTooLarge) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, studentImport)
+}
+
+// @Summary Admin Get Students Import
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin get students import progress and per-row report
+// @ModuleID adminGetStudentImport
+// @Accept  json
+// @Produce  json
+// @Param id path string true "import id"
+// @Success 200 {object} domain.StudentImport
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/import/{id} [get]
+func (h *Handler) adminGetStudentImport(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	studentImport, err := h.services.StudentImports.GetById(c.Request.Context(), school.ID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrStudentImportNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, studentImport)
+}
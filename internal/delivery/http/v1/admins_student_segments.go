@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type studentSegmentInput struct {
+	Name    string                     `json:"name" binding:"required,min=1,max=64"`
+	Search  string                     `json:"search"`
+	Filters domain.StudentFiltersQuery `json:"filters"`
+}
+
+// @Summary Admin Create Students Segment
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin save students filters as a segment, it's applied to students list and export with segment query parameter
+// @ModuleID adminCreateStudentSegment
+// @Accept  json
+// @Produce  json
+// @Param input body studentSegmentInput true "segment"
+// @Success 201 {object} domain.StudentSegment
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/segments [post]
+func (h *Handler) adminCreateStudentSegment(c *gin.Context) {
+	var inp studentSegmentInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	segment, err := h.services.StudentSegments.Create(c.Request.Context(), service.CreateStudentSegmentInput{
+		SchoolID: school.ID,
+		Name:     inp.Name,
+		Search:   inp.Search,
+		Filters:  inp.Filters,
+	})
+	if err != nil {
+		newStudentSegmentErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, segment)
+}
+
+// @Summary Admin Get Students Segments
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin get saved students segments
+// @ModuleID adminGetStudentSegments
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/segments [get]
+func (h *Handler) adminGetStudentSegments(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	segments, err := h.services.StudentSegments.GetBySchool(c.Request.Context(), school.ID)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: segments})
+}
+
+// @Summary Admin Update Students Segment
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin update saved students segment
+// @ModuleID adminUpdateStudentSegment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "segment id"
+// @Param input body studentSegmentInput true "segment"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/segments/{id} [put]
+func (h *Handler) adminUpdateStudentSegment(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp studentSegmentInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.StudentSegments.Update(c.Request.Context(), service.UpdateStudentSegmentInput{
+		ID:       id,
+		SchoolID: school.ID,
+		Name:     inp.Name,
+		Search:   inp.Search,
+		Filters:  inp.Filters,
+	}); err != nil {
+		newStudentSegmentErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Admin Delete Students Segment
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin delete saved students segment
+// @ModuleID adminDeleteStudentSegment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "segment id"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/segments/{id} [delete]
+func (h *Handler) adminDeleteStudentSegment(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.StudentSegments.Delete(c.Request.Context(), school.ID, id); err != nil {
+		newStudentSegmentErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// newStudentSegmentErrorResponse is used for segment endpoints and students list filtered by segment.
+func newStudentSegmentErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrStudentFilterInvalid):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrStudentSegmentNotFound):
+		newResponse(c, http.StatusNotFound, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -1,11 +1,16 @@
 package v1
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/spreadsheet"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -17,6 +22,8 @@ type studentResponse struct {
 	LastVisitAt  time.Time          `json:"lastVisitAt"`
 	Verified     bool               `json:"verified"`
 	Blocked      bool               `json:"blocked"`
+	Tags         []string           `json:"tags"`
+	Fields       map[string]string  `json:"fields"`
 }
 
 func toStudentsResponse(students []domain.Student) []studentResponse {
@@ -29,6 +36,8 @@ func toStudentsResponse(students []domain.Student) []studentResponse {
 		out[i].LastVisitAt = student.LastVisitAt
 		out[i].Verified = student.Verification.Verified
 		out[i].Blocked = student.Blocked
+		out[i].Tags = student.Tags
+		out[i].Fields = student.Fields
 	}
 
 	return out
@@ -49,6 +58,9 @@ func toStudentsResponse(students []domain.Student) []studentResponse {
 // @Param registerDateTo query string false "registerDateTo"
 // @Param lastVisitDateFrom query string false "lastVisitDateFrom"
 // @Param lastVisitDateTo query string false "registerDateTo"
+// @Param tags query []string false "students with all of the tags"
+// @Param fields query []string false "custom field filters in key:value form"
+// @Param segment query string false "saved segment id"
 // @Success 200 {object} dataResponse
 // @Failure 400,404 {object} response
 // @Failure 500 {object} response
@@ -71,7 +83,7 @@ func (h *Handler) adminGetStudents(c *gin.Context) {
 
 	students, count, err := h.services.Students.GetBySchool(c.Request.Context(), school.ID, query)
 	if err != nil {
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		newStudentSegmentErrorResponse(c, err)
 
 		return
 	}
@@ -82,6 +94,78 @@ func (h *Handler) adminGetStudents(c *gin.Context) {
 	})
 }
 
+type exportStudentsQuery struct {
+	domain.GetStudentsQuery
+	Format string `form:"format"`
+}
+
+// @Summary Admin Export Students
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin export all students matching filters with granted offers and courses progress, without pagination
+// @ModuleID adminExportStudents
+// @Accept  json
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string true "csv | xlsx"
+// @Param search query string false "search"
+// @Param verified query bool false "verified"
+// @Param registerDateFrom query string false "registerDateFrom"
+// @Param registerDateTo query string false "registerDateTo"
+// @Param lastVisitDateFrom query string false "lastVisitDateFrom"
+// @Param lastVisitDateTo query string false "lastVisitDateTo"
+// @Param tags query []string false "students with all of the tags"
+// @Param fields query []string false "custom field filters in key:value form"
+// @Param segment query string false "saved segment id"
+// @Success 200 {file} file
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/export [get]
+func (h *Handler) adminExportStudents(c *gin.Context) {
+	var query exportStudentsQuery
+	if err := c.Bind(&query); err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if query.Format != spreadsheet.FormatCSV && query.Format != spreadsheet.FormatXLSX {
+		newResponse(c, http.StatusBadRequest, "format must be csv or xlsx")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Header("Content-Type", spreadsheet.ContentType(query.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"students.%s\"", query.Format))
+
+	if err := h.services.StudentListExports.Export(c.Request.Context(), service.ExportStudentsInput{
+		School: school,
+		Query:  query.GetStudentsQuery,
+		Format: query.Format,
+		Writer: c.Writer,
+	}); err != nil {
+		// response is already streamed partially, so it can only be cut off
+		if c.Writer.Written() {
+			logger.Errorf("failed to export students of school %s: %s", school.ID.Hex(), err.Error())
+			c.Abort()
+
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		newStudentSegmentErrorResponse(c, err)
+	}
+}
+
 // @Summary Admin Get Student By ID
 // @Security AdminAuth
 // @Tags admins-students
@@ -208,25 +292,85 @@ func (h *Handler) adminManageOfferPermission(c *gin.Context) {
 		return
 	}
 
+	// admin id is absent, when request is authenticated with api key
+	adminId, _ := getIdByContext(c, adminCtx)
+
 	if inp.Available {
-		if err := h.services.Students.GiveAccessToOffer(c.Request.Context(), studentId, offer); err != nil {
-			newResponse(c, http.StatusInternalServerError, err.Error())
+		err = h.services.Students.GiveAccessToOffer(c.Request.Context(), studentId, offer, adminId)
+	} else {
+		err = h.services.Students.RemoveAccessToOffer(c.Request.Context(), studentId, offer, adminId)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
 
 			return
 		}
 
-		c.Status(http.StatusOK)
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Admin Get Student Activity
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin get student activity log, newest first: sign-ins, lessons opened and finished, surveys, orders and access changes
+// @ModuleID adminGetStudentActivity
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Param skip query int false "skip"
+// @Param limit query int false "limit"
+// @Param types query []string false "sign_in | lesson_opened | lesson_finished | survey_submitted | course_completed | order_created | order_paid | access_granted | access_revoked"
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/activity [get]
+func (h *Handler) adminGetStudentActivity(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var query domain.GetStudentActivityQuery
+	if err := c.Bind(&query); err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
 
 		return
 	}
 
-	if err := h.services.Students.RemoveAccessToOffer(c.Request.Context(), studentId, offer); err != nil {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
 	}
 
-	c.Status(http.StatusOK)
+	activities, count, err := h.services.StudentActivities.GetByStudent(c.Request.Context(), school.ID, id, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrStudentActivityTypeInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{
+		Data:  activities,
+		Count: count,
+	})
 }
 
 // @Summary Admin Update Student
@@ -314,3 +458,78 @@ func (h *Handler) adminDeleteStudent(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// @Summary Admin Export Student Data
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin request archive with all student's data, download link is emailed to the student when it's ready
+// @ModuleID adminExportStudentData
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Success 202 {string} string "accepted"
+// @Failure 400,404,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/export [post]
+func (h *Handler) adminExportStudentData(c *gin.Context) {
+	studentId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	h.exportStudentData(c, school.ID, studentId)
+}
+
+// @Summary Admin Resend Student Verification
+// @Security AdminAuth
+// @Tags admins-students
+// @Description admin send a new verification code to student
+// @ModuleID adminResendStudentVerification
+// @Accept  json
+// @Produce  json
+// @Param id path string true "student id"
+// @Success 200 {string} string "ok"
+// @Failure 400,404,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/students/{id}/verification/resend [post]
+func (h *Handler) adminResendStudentVerification(c *gin.Context) {
+	studentId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.ResendVerificationById(c.Request.Context(), school.ID, studentId, schoolDomain); err != nil {
+		newVerificationErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,275 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type teamMemberResponse struct {
+	ID               primitive.ObjectID `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Role             string             `json:"role"`
+	Pending          bool               `json:"pending"`
+	TwoFactorEnabled bool               `json:"twoFactorEnabled"`
+}
+
+type inviteToTeamInput struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+	Name  string `json:"name" binding:"required,min=2,max=64"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type acceptInvitationInput struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required,min=2,max=64"`
+	Password string `json:"password" binding:"required,min=8,max=64"`
+}
+
+type setTeamRoleInput struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// @Summary Admin Get Team
+// @Security AdminAuth
+// @Tags admins-team
+// @Description admin get all school admins
+// @ModuleID adminGetTeam
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/team [get]
+func (h *Handler) adminGetTeam(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	admins, err := h.services.Admins.GetTeam(c.Request.Context(), school.ID)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	team := make([]teamMemberResponse, len(admins))
+	for i, admin := range admins {
+		team[i] = teamMemberResponse{
+			ID:               admin.ID,
+			Name:             admin.Name,
+			Email:            admin.Email,
+			Role:             admin.GetRole(),
+			Pending:          admin.Pending,
+			TwoFactorEnabled: admin.TwoFactor.Enabled,
+		}
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: team, Count: int64(len(team))})
+}
+
+// @Summary Admin Invite To Team
+// @Security AdminAuth
+// @Tags admins-team
+// @Description admin invite new admin by email, available only for school owners
+// @ModuleID adminInviteToTeam
+// @Accept  json
+// @Produce  json
+// @Param input body inviteToTeamInput true "invitation info"
+// @Success 201 {string} string "ok"
+// @Failure 400,403,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/team/invitations [post]
+func (h *Handler) adminInviteToTeam(c *gin.Context) {
+	var inp inviteToTeamInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Admins.InviteAdmin(c.Request.Context(), service.InviteAdminInput{
+		Email:        inp.Email,
+		Name:         inp.Name,
+		Role:         inp.Role,
+		SchoolID:     school.ID,
+		SchoolName:   school.Name,
+		SchoolDomain: schoolDomain,
+	}); err != nil {
+		newTeamErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// @Summary Admin Accept Invitation
+// @Tags admins-team
+// @Description admin accept invitation from email and set password
+// @ModuleID adminAcceptInvitation
+// @Accept  json
+// @Produce  json
+// @Param input body acceptInvitationInput true "invitation token and account info"
+// @Success 200 {string} string "ok"
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/team/invitations/accept [post]
+func (h *Handler) adminAcceptInvitation(c *gin.Context) {
+	var inp acceptInvitationInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Admins.AcceptInvitation(c.Request.Context(), service.AcceptAdminInvitationInput{
+		Token:    inp.Token,
+		Name:     inp.Name,
+		Password: inp.Password,
+		SchoolID: school.ID,
+	}); err != nil {
+		newTeamErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Admin Set Team Role
+// @Security AdminAuth
+// @Tags admins-team
+// @Description admin change role of another admin, available only for school owners
+// @ModuleID adminSetTeamRole
+// @Accept  json
+// @Produce  json
+// @Param id path string true "admin id"
+// @Param input body setTeamRoleInput true "new role"
+// @Success 200 {string} string "ok"
+// @Failure 400,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/team/{id}/role [put]
+func (h *Handler) adminSetTeamRole(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp setTeamRoleInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Admins.SetRole(c.Request.Context(), school.ID, id, inp.Role); err != nil {
+		newTeamErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Admin Remove From Team
+// @Security AdminAuth
+// @Tags admins-team
+// @Description admin remove another admin or invitation, available only for school owners
+// @ModuleID adminRemoveFromTeam
+// @Accept  json
+// @Produce  json
+// @Param id path string true "admin id"
+// @Success 200 {string} string "ok"
+// @Failure 400,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/team/{id} [delete]
+func (h *Handler) adminRemoveFromTeam(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Admins.RemoveFromTeam(c.Request.Context(), school.ID, adminId, id); err != nil {
+		newTeamErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func newTeamErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		newResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrAdminAlreadyExists):
+		newResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrOneTimeTokenInvalid):
+		newResponse(c, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domain.ErrAdminRoleInvalid), errors.Is(err, domain.ErrLastSchoolOwner),
+		errors.Is(err, domain.ErrCannotRemoveSelf):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type createAPIKeyInput struct {
+	Name   string   `json:"name" binding:"required,min=2,max=64"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+type createAPIKeyResponse struct {
+	APIKey domain.APIKey `json:"apiKey"`
+	Key    string        `json:"key"`
+}
+
+// @Summary Admin Create API Key
+// @Security AdminAuth
+// @Tags admins-api-keys
+// @Description admin create API key for server-to-server integrations, key is returned only once.
+// @Description Scopes are "<resource>:read" and "<resource>:write", resources: courses, offers, promocodes, students, orders
+// @ModuleID adminCreateAPIKey
+// @Accept  json
+// @Produce  json
+// @Param input body createAPIKeyInput true "api key info"
+// @Success 201 {object} createAPIKeyResponse
+// @Failure 400,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/api-keys [post]
+func (h *Handler) adminCreateAPIKey(c *gin.Context) {
+	var inp createAPIKeyInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	res, err := h.services.APIKeys.Create(c.Request.Context(), service.CreateAPIKeyInput{
+		SchoolID: school.ID,
+		AdminID:  adminId,
+		Name:     inp.Name,
+		Scopes:   inp.Scopes,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyScopeInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, createAPIKeyResponse{APIKey: res.APIKey, Key: res.Key})
+}
+
+// @Summary Admin Get API Keys
+// @Security AdminAuth
+// @Tags admins-api-keys
+// @Description admin get all school API keys
+// @ModuleID adminGetAPIKeys
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 400,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/api-keys [get]
+func (h *Handler) adminGetAPIKeys(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	keys, err := h.services.APIKeys.GetBySchool(c.Request.Context(), school.ID)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: keys, Count: int64(len(keys))})
+}
+
+// @Summary Admin Revoke API Key
+// @Security AdminAuth
+// @Tags admins-api-keys
+// @Description admin revoke API key, requests with it are rejected immediately
+// @ModuleID adminRevokeAPIKey
+// @Accept  json
+// @Produce  json
+// @Param id path string true "api key id"
+// @Success 200 {string} string "ok"
+// @Failure 400,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/api-keys/{id} [delete]
+func (h *Handler) adminRevokeAPIKey(c *gin.Context) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.APIKeys.Revoke(c.Request.Context(), school.ID, id); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
@@ -55,16 +56,18 @@ type getCourseByIdResponse struct {
 }
 
 type module struct {
-	ID       primitive.ObjectID `json:"id" bson:"_id"`
-	Name     string             `json:"name" bson:"name"`
-	Position uint               `json:"position" bson:"position"`
-	Lessons  []lesson           `json:"lessons" bson:"lessons"`
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Position    uint               `json:"position" bson:"position"`
+	AvailableAt *time.Time         `json:"availableAt,omitempty" bson:"-"`
+	Lessons     []lesson           `json:"lessons" bson:"lessons"`
 }
 
 type lesson struct {
-	ID       primitive.ObjectID `json:"id" bson:"_id"`
-	Name     string             `json:"name" bson:"name"`
-	Position uint               `json:"position" bson:"position"`
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Position    uint               `json:"position" bson:"position"`
+	AvailableAt *time.Time         `json:"availableAt,omitempty" bson:"-"`
 }
 
 func newGetCourseByIdResponse(course domain.Course, courseModules []domain.Module) getCourseByIdResponse {
@@ -74,6 +77,7 @@ func newGetCourseByIdResponse(course domain.Course, courseModules []domain.Modul
 		modules[i].ID = courseModules[i].ID
 		modules[i].Name = courseModules[i].Name
 		modules[i].Position = courseModules[i].Position
+		modules[i].AvailableAt = courseModules[i].AvailableAt
 		modules[i].Lessons = toLessons(courseModules[i].Lessons)
 	}
 
@@ -87,11 +91,12 @@ func toLessons(lessons []domain.Lesson) []lesson {
 	out := make([]lesson, 0)
 
 	for _, l := range lessons {
-		if l.Published {
+		if l.IsPublishedOrScheduled() {
 			out = append(out, lesson{
-				ID:       l.ID,
-				Name:     l.Name,
-				Position: l.Position,
+				ID:          l.ID,
+				Name:        l.Name,
+				Position:    l.Position,
+				AvailableAt: l.AvailableAt,
 			})
 		}
 	}
@@ -101,7 +106,7 @@ func toLessons(lessons []domain.Lesson) []lesson {
 
 // @Summary Get Course By ModuleID
 // @Tags courses
-// @Description  get course by id
+// @Description  get course by id, scheduled modules and lessons have availableAt
 // @ModuleID getCourseById
 // @Accept  json
 // @Produce  json
@@ -133,7 +138,7 @@ func (h *Handler) getCourseById(c *gin.Context) {
 		return
 	}
 
-	modules, err := h.services.Modules.GetPublishedByCourseId(c.Request.Context(), course.ID)
+	modules, err := h.services.Modules.GetScheduledByCourseId(c.Request.Context(), course.ID)
 	if err != nil {
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
@@ -49,3 +49,10 @@ func parseIdFromPath(c *gin.Context, param string) (primitive.ObjectID, error) {
 
 	return id, nil
 }
+
+func getDevice(c *gin.Context) service.Device {
+	return service.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
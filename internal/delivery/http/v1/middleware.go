@@ -7,18 +7,26 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
 	"github.com/zhashkevych/creatly-backend/pkg/logger"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const (
 	authorizationHeader = "Authorization"
+	apiKeyScheme        = "ApiKey"
 
 	studentCtx = "studentId"
 	adminCtx   = "adminId"
 	userCtx    = "userId"
 	schoolCtx  = "school"
 	domainCtx  = "domain"
+	apiKeyCtx  = "apiKey"
+)
+
+var (
+	errInvalidTokenRole   = errors.New("token was issued for another role")
+	errInvalidTokenSchool = errors.New("token was issued for another school")
 )
 
 func (h *Handler) setSchoolFromRequest(c *gin.Context) {
@@ -65,50 +73,217 @@ func getSchoolFromContext(c *gin.Context) (domain.School, error) {
 }
 
 func (h *Handler) studentIdentity(c *gin.Context) {
-	id, err := h.parseAuthHeader(c)
+	claims, err := h.parseAuthHeader(c)
 	if err != nil {
 		newResponse(c, http.StatusUnauthorized, err.Error())
+
+		return
 	}
 
-	c.Set(studentCtx, id)
+	if err := checkSchoolClaims(c, claims, domain.RoleStudent); err != nil {
+		newResponse(c, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	c.Set(studentCtx, claims.UserID)
 }
 
+// adminIdentity authenticates admin by access token or school's API key ("Authorization: ApiKey <key>").
+// Requests with API key have no admin in context, access for them is checked by adminAccess.
 func (h *Handler) adminIdentity(c *gin.Context) {
-	id, err := h.parseAuthHeader(c)
+	if key, ok := parseAPIKeyHeader(c); ok {
+		h.apiKeyIdentity(c, key)
+
+		return
+	}
+
+	claims, err := h.parseAuthHeader(c)
 	if err != nil {
 		newResponse(c, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	if err := checkSchoolClaims(c, claims, domain.RoleAdmin); err != nil {
+		newResponse(c, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	c.Set(adminCtx, claims.UserID)
+}
+
+func (h *Handler) apiKeyIdentity(c *gin.Context, key string) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	apiKey, err := h.services.APIKeys.Authenticate(c.Request.Context(), school.ID, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyInvalid) {
+			newResponse(c, http.StatusUnauthorized, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Set(apiKeyCtx, apiKey)
+}
+
+func parseAPIKeyHeader(c *gin.Context) (string, bool) {
+	headerParts := strings.Split(c.GetHeader(authorizationHeader), " ")
+	if len(headerParts) != 2 || headerParts[0] != apiKeyScheme || headerParts[1] == "" {
+		return "", false
+	}
+
+	return headerParts[1], true
+}
+
+func getAPIKeyFromContext(c *gin.Context) (domain.APIKey, bool) {
+	value, ex := c.Get(apiKeyCtx)
+	if !ex {
+		return domain.APIKey{}, false
 	}
 
-	c.Set(adminCtx, id)
+	apiKey, ok := value.(domain.APIKey)
+
+	return apiKey, ok
+}
+
+// adminAccess lets the request through for admins with one of the given roles
+// and for API keys with the resource scope: "read" for GET requests and "write" for the rest.
+func (h *Handler) adminAccess(resource string, roles ...string) gin.HandlerFunc {
+	permission := h.adminPermission(roles...)
+
+	return func(c *gin.Context) {
+		apiKey, ok := getAPIKeyFromContext(c)
+		if !ok {
+			permission(c)
+
+			return
+		}
+
+		if !apiKey.HasScope(domain.APIKeyScope(resource, c.Request.Method != http.MethodGet)) {
+			newResponse(c, http.StatusForbidden, domain.ErrPermissionDenied.Error())
+		}
+	}
+}
+
+// adminPermission lets the request through only for admins with one of the given roles.
+// Role is loaded on every request, so role changes and removals take effect immediately.
+// Requests with API key are always rejected.
+func (h *Handler) adminPermission(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := getAPIKeyFromContext(c); ok {
+			newResponse(c, http.StatusForbidden, domain.ErrPermissionDenied.Error())
+
+			return
+		}
+
+		adminId, err := getIdByContext(c, adminCtx)
+		if err != nil {
+			newResponse(c, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		school, err := getSchoolFromContext(c)
+		if err != nil {
+			newResponse(c, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		admin, err := h.services.Admins.GetById(c.Request.Context(), school.ID, adminId)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				newResponse(c, http.StatusUnauthorized, err.Error())
+
+				return
+			}
+
+			newResponse(c, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		for _, role := range roles {
+			if admin.GetRole() == role {
+				return
+			}
+		}
+
+		newResponse(c, http.StatusForbidden, domain.ErrPermissionDenied.Error())
+	}
 }
 
 func (h *Handler) userIdentity(c *gin.Context) {
-	id, err := h.parseAuthHeader(c)
+	claims, err := h.parseAuthHeader(c)
 	if err != nil {
 		newResponse(c, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	if claims.Role != domain.RoleUser {
+		newResponse(c, http.StatusUnauthorized, errInvalidTokenRole.Error())
+
+		return
 	}
 
-	c.Set(userCtx, id)
+	c.Set(userCtx, claims.UserID)
 }
 
-func (h *Handler) parseAuthHeader(c *gin.Context) (string, error) {
+func (h *Handler) parseAuthHeader(c *gin.Context) (auth.Claims, error) {
 	header := c.GetHeader(authorizationHeader)
 	if header == "" {
-		return "", errors.New("empty auth header")
+		return auth.Claims{}, errors.New("empty auth header")
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		return "", errors.New("invalid auth header")
+		return auth.Claims{}, errors.New("invalid auth header")
 	}
 
 	if len(headerParts[1]) == 0 {
-		return "", errors.New("token is empty")
+		return auth.Claims{}, errors.New("token is empty")
 	}
 
 	return h.tokenManager.Parse(headerParts[1])
 }
 
+// checkSchoolClaims makes sure the token was issued with the expected role
+// for the school (and domain) resolved from the current request.
+func checkSchoolClaims(c *gin.Context, claims auth.Claims, role string) error {
+	if claims.Role != role {
+		return errInvalidTokenRole
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if claims.SchoolID != school.ID.Hex() || claims.Audience != schoolDomain {
+		return errInvalidTokenSchool
+	}
+
+	return nil
+}
+
 func getStudentId(c *gin.Context) (primitive.ObjectID, error) {
 	return getIdByContext(c, studentCtx)
 }
@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHandler_adminIdentity(t *testing.T) {
+	tokenManager, err := auth.NewManager("signing_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	school := domain.School{ID: primitive.NewObjectID()}
+	schoolDomain := "school.creatly.me"
+	adminId := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		claims     auth.Claims
+		statusCode int
+	}{
+		{
+			name: "ok",
+			claims: auth.Claims{
+				UserID: adminId.Hex(), Role: domain.RoleAdmin, SchoolID: school.ID.Hex(), Audience: schoolDomain,
+			},
+			statusCode: 200,
+		},
+		{
+			name: "student token",
+			claims: auth.Claims{
+				UserID: adminId.Hex(), Role: domain.RoleStudent, SchoolID: school.ID.Hex(), Audience: schoolDomain,
+			},
+			statusCode: 401,
+		},
+		{
+			name: "another school",
+			claims: auth.Claims{
+				UserID: adminId.Hex(), Role: domain.RoleAdmin, SchoolID: primitive.NewObjectID().Hex(), Audience: schoolDomain,
+			},
+			statusCode: 401,
+		},
+		{
+			name: "another domain",
+			claims: auth.Claims{
+				UserID: adminId.Hex(), Role: domain.RoleAdmin, SchoolID: school.ID.Hex(), Audience: "other.creatly.me",
+			},
+			statusCode: 401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Handler{tokenManager: tokenManager}
+
+			token, err := tokenManager.NewJWT(tt.claims, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/admin", func(c *gin.Context) {
+				c.Set(schoolCtx, school)
+				c.Set(domainCtx, schoolDomain)
+			}, handler.adminIdentity, func(c *gin.Context) {
+				id, _ := getIdByContext(c, adminCtx)
+				assert.Equal(t, id, adminId)
+			})
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin", nil)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, tt.statusCode)
+		})
+	}
+}
+
+func TestHandler_adminPermission(t *testing.T) {
+	school := domain.School{ID: primitive.NewObjectID()}
+	adminId := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		admin      domain.Admin
+		err        error
+		statusCode int
+	}{
+		{
+			name:       "ok",
+			admin:      domain.Admin{ID: adminId, Role: domain.AdminRoleFinance},
+			statusCode: 200,
+		},
+		{
+			name:       "admin without role is owner",
+			admin:      domain.Admin{ID: adminId},
+			statusCode: 200,
+		},
+		{
+			name:       "role not allowed",
+			admin:      domain.Admin{ID: adminId, Role: domain.AdminRoleEditor},
+			statusCode: 403,
+		},
+		{
+			name:       "admin removed",
+			err:        domain.ErrUserNotFound,
+			statusCode: 401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s := mock_service.NewMockAdmins(c)
+			s.EXPECT().GetById(context.Background(), school.ID, adminId).Return(tt.admin, tt.err)
+
+			handler := Handler{services: &service.Services{Admins: s}}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/orders", func(c *gin.Context) {
+				c.Set(schoolCtx, school)
+				c.Set(adminCtx, adminId.Hex())
+			}, handler.adminPermission(domain.AdminRoleOwner, domain.AdminRoleFinance), func(c *gin.Context) {
+				c.Status(200)
+			})
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/orders", nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, tt.statusCode)
+		})
+	}
+}
+
+func TestHandler_adminAccessWithAPIKey(t *testing.T) {
+	school := domain.School{ID: primitive.NewObjectID()}
+	apiKey := domain.APIKey{ID: primitive.NewObjectID(), SchoolID: school.ID, Scopes: []string{"orders:read"}}
+
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		err        error
+		statusCode int
+	}{
+		{
+			name:       "ok",
+			method:     "GET",
+			header:     "ApiKey key",
+			statusCode: 200,
+		},
+		{
+			name:       "no write scope",
+			method:     "PUT",
+			header:     "ApiKey key",
+			statusCode: 403,
+		},
+		{
+			name:       "invalid key",
+			method:     "GET",
+			header:     "ApiKey key",
+			err:        domain.ErrAPIKeyInvalid,
+			statusCode: 401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s := mock_service.NewMockAPIKeys(c)
+			s.EXPECT().Authenticate(context.Background(), school.ID, "key").Return(apiKey, tt.err)
+
+			handler := Handler{services: &service.Services{APIKeys: s}}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Handle(tt.method, "/orders", func(c *gin.Context) {
+				c.Set(schoolCtx, school)
+			}, handler.adminIdentity, handler.adminAccess(domain.APIKeyResourceOrders, domain.AdminRoleOwner), func(c *gin.Context) {
+				c.Status(200)
+			})
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			req.Header.Set("Authorization", tt.header)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, tt.statusCode)
+		})
+	}
+}
+
+func TestHandler_adminPermissionRejectsAPIKey(t *testing.T) {
+	handler := Handler{services: &service.Services{}}
+
+	r := gin.New()
+	r.GET("/team", func(c *gin.Context) {
+		c.Set(apiKeyCtx, domain.APIKey{Scopes: []string{"students:read"}})
+	}, handler.adminPermission(domain.AdminRoleOwner), func(c *gin.Context) {
+		c.Status(200)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/team", nil))
+
+	assert.Equal(t, w.Code, 403)
+}
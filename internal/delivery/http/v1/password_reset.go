@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type passwordResetRequestInput struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+}
+
+type passwordResetInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=64"`
+}
+
+// @Summary Student Request Password Reset
+// @Tags students-auth
+// @Description send password reset link to the student email
+// @ModuleID studentRequestPasswordReset
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetRequestInput true "student email"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/password-reset [post]
+func (h *Handler) studentRequestPasswordReset(c *gin.Context) {
+	h.requestSchoolPasswordReset(c, h.services.Students)
+}
+
+// @Summary Student Reset Password
+// @Tags students-auth
+// @Description set new student password using token from the email, all student sessions are revoked
+// @ModuleID studentResetPassword
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetInput true "reset token and new password"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/password-reset/confirm [post]
+func (h *Handler) studentResetPassword(c *gin.Context) {
+	h.resetSchoolPassword(c, h.services.Students)
+}
+
+// @Summary Admin Request Password Reset
+// @Tags admins-auth
+// @Description send password reset link to the admin email
+// @ModuleID adminRequestPasswordReset
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetRequestInput true "admin email"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/password-reset [post]
+func (h *Handler) adminRequestPasswordReset(c *gin.Context) {
+	h.requestSchoolPasswordReset(c, h.services.Admins)
+}
+
+// @Summary Admin Reset Password
+// @Tags admins-auth
+// @Description set new admin password using token from the email, all admin sessions are revoked
+// @ModuleID adminResetPassword
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetInput true "reset token and new password"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/password-reset/confirm [post]
+func (h *Handler) adminResetPassword(c *gin.Context) {
+	h.resetSchoolPassword(c, h.services.Admins)
+}
+
+// @Summary User Request Password Reset
+// @Tags users-auth
+// @Description send password reset link to the user email
+// @ModuleID userRequestPasswordReset
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetRequestInput true "user email"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/password-reset [post]
+func (h *Handler) userRequestPasswordReset(c *gin.Context) {
+	var inp passwordResetRequestInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	if err := h.services.Users.RequestPasswordReset(c.Request.Context(), service.RequestPasswordResetInput{
+		Email: inp.Email,
+	}); err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary User Reset Password
+// @Tags users-auth
+// @Description set new user password using token from the email, all user sessions are revoked
+// @ModuleID userResetPassword
+// @Accept  json
+// @Produce  json
+// @Param input body passwordResetInput true "reset token and new password"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/password-reset/confirm [post]
+func (h *Handler) userResetPassword(c *gin.Context) {
+	var inp passwordResetInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	if err := h.services.Users.ResetPassword(c.Request.Context(), service.ResetPasswordInput{
+		Token:    inp.Token,
+		Password: inp.Password,
+	}); err != nil {
+		if errors.Is(err, domain.ErrOneTimeTokenInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type passwordResetter interface {
+	RequestPasswordReset(ctx context.Context, input service.RequestPasswordResetInput) error
+	ResetPassword(ctx context.Context, input service.ResetPasswordInput) error
+}
+
+func (h *Handler) requestSchoolPasswordReset(c *gin.Context, resetter passwordResetter) {
+	var inp passwordResetRequestInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := resetter.RequestPasswordReset(c.Request.Context(), service.RequestPasswordResetInput{
+		Email:        inp.Email,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+	}); err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) resetSchoolPassword(c *gin.Context, resetter passwordResetter) {
+	var inp passwordResetInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := resetter.ResetPassword(c.Request.Context(), service.ResetPasswordInput{
+		Token:    inp.Token,
+		Password: inp.Password,
+		SchoolID: school.ID,
+	}); err != nil {
+		if errors.Is(err, domain.ErrOneTimeTokenInvalid) {
+			newResponse(c, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+)
+
+// @Summary Student Get Sessions
+// @Security StudentsAuth
+// @Tags students-auth
+// @Description student get active sessions
+// @ModuleID studentGetSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/sessions [get]
+func (h *Handler) studentGetSessions(c *gin.Context) {
+	h.getSessions(c, studentCtx)
+}
+
+// @Summary Student Revoke Session
+// @Security StudentsAuth
+// @Tags students-auth
+// @Description student revoke session by id
+// @ModuleID studentRevokeSession
+// @Accept  json
+// @Produce  json
+// @Param id path string true "session id"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/sessions/{id} [delete]
+func (h *Handler) studentRevokeSession(c *gin.Context) {
+	h.revokeSession(c, studentCtx)
+}
+
+// @Summary Student Revoke All Sessions
+// @Security StudentsAuth
+// @Tags students-auth
+// @Description student revoke all sessions
+// @ModuleID studentRevokeSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/sessions [delete]
+func (h *Handler) studentRevokeSessions(c *gin.Context) {
+	h.revokeSessions(c, studentCtx)
+}
+
+// @Summary Admin Get Sessions
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin get active sessions
+// @ModuleID adminGetSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/sessions [get]
+func (h *Handler) adminGetSessions(c *gin.Context) {
+	h.getSessions(c, adminCtx)
+}
+
+// @Summary Admin Revoke Session
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin revoke session by id
+// @ModuleID adminRevokeSession
+// @Accept  json
+// @Produce  json
+// @Param id path string true "session id"
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/sessions/{id} [delete]
+func (h *Handler) adminRevokeSession(c *gin.Context) {
+	h.revokeSession(c, adminCtx)
+}
+
+// @Summary Admin Revoke All Sessions
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin revoke all sessions
+// @ModuleID adminRevokeSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string "ok"
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/sessions [delete]
+func (h *Handler) adminRevokeSessions(c *gin.Context) {
+	h.revokeSessions(c, adminCtx)
+}
+
+func (h *Handler) getSessions(c *gin.Context, ownerCtx string) {
+	ownerId, err := getIdByContext(c, ownerCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	sessions, err := h.services.Sessions.GetByOwner(c.Request.Context(), ownerId)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	response := make([]domain.Session, len(sessions))
+	if sessions != nil {
+		response = sessions
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: response, Count: int64(len(response))})
+}
+
+func (h *Handler) revokeSession(c *gin.Context, ownerCtx string) {
+	id, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	ownerId, err := getIdByContext(c, ownerCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Sessions.Revoke(c.Request.Context(), ownerId, id); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) revokeSessions(c *gin.Context, ownerCtx string) {
+	ownerId, err := getIdByContext(c, ownerCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Sessions.RevokeAll(c.Request.Context(), ownerId); err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
@@ -15,11 +16,20 @@ func (h *Handler) initStudentsRoutes(api *gin.RouterGroup) {
 	{
 		students.POST("/sign-up", h.studentSignUp)
 		students.POST("/sign-in", h.studentSignIn)
+		students.GET("/oidc/:provider", h.studentGetOIDCAuthURL)
+		students.POST("/oidc/:provider/callback", h.studentOIDCCallback)
+		students.POST("/magic-link", h.studentRequestMagicLink)
+		students.POST("/magic-link/confirm", h.studentSignInMagicLink)
 		students.POST("/auth/refresh", h.studentRefresh)
 		students.POST("/verify/:code", h.studentVerify)
+		students.POST("/verification/resend", h.studentResendVerification)
+		students.POST("/password-reset", h.studentRequestPasswordReset)
+		students.POST("/password-reset/confirm", h.studentResetPassword)
+		students.POST("/account/email/confirm", h.studentConfirmEmailChange)
 
 		authenticated := students.Group("/", h.studentIdentity)
 		{
+			authenticated.GET("/courses/:id/progress", h.studentGetCourseProgress)
 			authenticated.GET("/modules/:id/content", h.studentGetModuleContent)
 			authenticated.GET("/modules/:id/offers", h.studentGetModuleOffers)
 			authenticated.POST("/modules/:id/survey", h.studentSubmitSurvey)
@@ -27,6 +37,15 @@ func (h *Handler) initStudentsRoutes(api *gin.RouterGroup) {
 			authenticated.POST("/orders", h.studentCreateOrder)
 			authenticated.GET("/orders/:id/payment", h.studentGeneratePaymentLink)
 			authenticated.GET("/account", h.studentGetAccount)
+			authenticated.PUT("/account", h.studentUpdateAccount)
+			authenticated.DELETE("/account", h.studentDeleteAccount)
+			authenticated.POST("/account/email", h.studentChangeEmail)
+			authenticated.POST("/account/confirmation-code", h.studentRequestConfirmationCode)
+			authenticated.PUT("/account/password", h.studentChangePassword)
+			authenticated.POST("/account/export", h.studentExportData)
+			authenticated.GET("/sessions", h.studentGetSessions)
+			authenticated.DELETE("/sessions", h.studentRevokeSessions)
+			authenticated.DELETE("/sessions/:id", h.studentRevokeSession)
 		}
 	}
 }
@@ -46,7 +65,7 @@ type studentSignUpInput struct {
 // @Produce  json
 // @Param input body studentSignUpInput true "sign up info"
 // @Success 201 {string} string "ok"
-// @Failure 400,404 {object} response
+// @Failure 400,403,404 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /students/sign-up [post]
@@ -73,12 +92,13 @@ func (h *Handler) studentSignUp(c *gin.Context) {
 	}
 
 	if err := h.services.Students.SignUp(c.Request.Context(), service.StudentSignUpInput{
-		Name:         inp.Name,
-		Email:        inp.Email,
-		Password:     inp.Password,
-		SchoolID:     school.ID,
-		SchoolDomain: schoolDomain,
-		Verified:     inp.Verified,
+		Name:                inp.Name,
+		Email:               inp.Email,
+		Password:            inp.Password,
+		SchoolID:            school.ID,
+		SchoolDomain:        schoolDomain,
+		Verified:            inp.Verified,
+		DisableRegistration: school.Settings.DisableRegistration,
 	}); err != nil {
 		if errors.Is(err, domain.ErrUserAlreadyExists) {
 			newResponse(c, http.StatusBadRequest, err.Error())
@@ -86,6 +106,12 @@ func (h *Handler) studentSignUp(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrRegistrationDisabled) {
+			newResponse(c, http.StatusForbidden, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -112,7 +138,7 @@ type tokenResponse struct {
 // @Produce  json
 // @Param input body signInInput true "sign up info"
 // @Success 200 {object} tokenResponse
-// @Failure 400,404 {object} response
+// @Failure 400,404,429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /students/sign-in [post]
@@ -131,10 +157,19 @@ func (h *Handler) studentSignIn(c *gin.Context) {
 		return
 	}
 
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
 	res, err := h.services.Students.SignIn(c.Request.Context(), service.SchoolSignInInput{
-		SchoolID: school.ID,
-		Email:    inp.Email,
-		Password: inp.Password,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+		Email:        inp.Email,
+		Password:     inp.Password,
+		Device:       getDevice(c),
 	})
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -143,6 +178,12 @@ func (h *Handler) studentSignIn(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrTooManyAttempts) {
+			newResponse(c, http.StatusTooManyRequests, err.Error())
+
+			return
+		}
+
 		if errors.Is(err, domain.ErrStudentBlocked) {
 			newResponse(c, http.StatusForbidden, err.Error())
 
@@ -190,8 +231,26 @@ func (h *Handler) studentRefresh(c *gin.Context) {
 		return
 	}
 
-	res, err := h.services.Students.RefreshTokens(c.Request.Context(), school.ID, inp.Token)
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Students.RefreshTokens(c.Request.Context(), service.SchoolRefreshTokensInput{
+		RefreshToken: inp.Token,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+		Device:       getDevice(c),
+	})
 	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			newResponse(c, http.StatusUnauthorized, err.Error())
+
+			return
+		}
+
 		if errors.Is(err, domain.ErrStudentBlocked) {
 			newResponse(c, http.StatusForbidden, err.Error())
 
@@ -217,7 +276,7 @@ func (h *Handler) studentRefresh(c *gin.Context) {
 // @Produce  json
 // @Param code path string true "verification code"
 // @Success 200 {object} tokenResponse
-// @Failure 400,404 {object} response
+// @Failure 400,404,410 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /students/verify/{code} [post]
@@ -230,24 +289,85 @@ func (h *Handler) studentVerify(c *gin.Context) {
 	}
 
 	if err := h.services.Students.Verify(c.Request.Context(), code); err != nil {
-		if errors.Is(err, domain.ErrVerificationCodeInvalid) {
-			newResponse(c, http.StatusBadRequest, err.Error())
+		newVerificationErrorResponse(c, err)
 
-			return
-		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response{"success"})
+}
 
+type resendVerificationInput struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+}
+
+// @Summary Student Resend Verification
+// @Tags students-auth
+// @Description student request a new verification code, response is the same whether account exists or the code was sent recently
+// @ModuleID studentResendVerification
+// @Accept  json
+// @Produce  json
+// @Param input body resendVerificationInput true "student email"
+// @Success 200 {object} response
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/verification/resend [post]
+func (h *Handler) studentResendVerification(c *gin.Context) {
+	var inp resendVerificationInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
 	}
 
+	if err := h.services.Students.ResendVerification(c.Request.Context(), service.ResendVerificationInput{
+		Email:        inp.Email,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+	}); err != nil {
+		newVerificationErrorResponse(c, err)
+
+		return
+	}
+
 	c.JSON(http.StatusOK, response{"success"})
 }
 
+func newVerificationErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrVerificationCodeInvalid), errors.Is(err, domain.ErrAlreadyVerified):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrVerificationCodeExpired):
+		newResponse(c, http.StatusGone, err.Error())
+	case errors.Is(err, domain.ErrVerificationSentRecently):
+		newResponse(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, domain.ErrUserNotFound):
+		newResponse(c, http.StatusNotFound, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
 // @Summary Student Get Content By Module ID
 // @Security StudentsAuth
 // @Tags students-courses
-// @Description student get content by module id
+// @Description student get content by module id, content of scheduled or drip lessons is hidden till availableAt,
+// @Description content of lessons locked by gating mode is hidden till prerequisite is completed
 // @ModuleID studentGetModuleContent
 // @Accept  json
 // @Produce  json
@@ -295,6 +415,57 @@ func (h *Handler) studentGetModuleContent(c *gin.Context) {
 	c.JSON(http.StatusOK, content)
 }
 
+// @Summary Student Get Course Progress
+// @Security StudentsAuth
+// @Tags students-courses
+// @Description student get progress of the course by published lessons of each module
+// @ModuleID studentGetCourseProgress
+// @Accept  json
+// @Produce  json
+// @Param id path string true "course id"
+// @Success 200 {object} domain.CourseProgress
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/courses/{id}/progress [get]
+func (h *Handler) studentGetCourseProgress(c *gin.Context) {
+	courseId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid id param")
+
+		return
+	}
+
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	progress, err := h.services.CourseProgress.GetByCourse(c.Request.Context(), school.ID, studentId, courseId)
+	if err != nil {
+		if errors.Is(err, domain.ErrCourseNotFound) {
+			newResponse(c, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
 type submitSurveyInput struct {
 	Answers []surveyAnswer `json:"answers"`
 }
@@ -368,6 +539,14 @@ func (h *Handler) studentSubmitSurvey(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// lessonLockedResponse is returned for scheduled or drip lessons, that are not available yet,
+// and for lessons, which prerequisite is not completed.
+type lessonLockedResponse struct {
+	Message      string               `json:"message"`
+	AvailableAt  *time.Time           `json:"availableAt,omitempty"`
+	Prerequisite *domain.Prerequisite `json:"prerequisite,omitempty"`
+}
+
 // @Summary Student Set Lesson As Finished By LessonID
 // @Security StudentsAuth
 // @Tags students-courses
@@ -377,7 +556,8 @@ func (h *Handler) studentSubmitSurvey(c *gin.Context) {
 // @Produce  json
 // @Param id path string true "lesson id"
 // @Success 200 {string} string "ok"
-// @Failure 400,403 {object} response
+// @Failure 400 {object} response
+// @Failure 403 {object} lessonLockedResponse
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /students/lessons/{id}/finished [post]
@@ -403,6 +583,20 @@ func (h *Handler) studentSetLessonFinished(c *gin.Context) {
 			return
 		}
 
+		var locked domain.ContentLockedError
+		if errors.As(err, &locked) {
+			c.AbortWithStatusJSON(http.StatusForbidden, lessonLockedResponse{Message: err.Error(), AvailableAt: &locked.AvailableAt})
+
+			return
+		}
+
+		var missing domain.PrerequisiteError
+		if errors.As(err, &missing) {
+			c.AbortWithStatusJSON(http.StatusForbidden, lessonLockedResponse{Message: err.Error(), Prerequisite: &missing.Prerequisite})
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -609,14 +803,15 @@ func (h *Handler) studentGeneratePaymentLink(c *gin.Context) {
 }
 
 type studentAccountResponse struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name     string                  `json:"name"`
+	Email    string                  `json:"email"`
+	Progress []domain.CourseProgress `json:"progress"`
 }
 
 // @Summary Student Get Account Info
 // @Security StudentsAuth
 // @Tags students-account
-// @Description student get account info
+// @Description student get account info with progress of the available courses
 // @ModuleID studentGetAccount
 // @Accept  json
 // @Produce  json
@@ -647,9 +842,17 @@ func (h *Handler) studentGetAccount(c *gin.Context) {
 		return
 	}
 
+	progress, err := h.services.CourseProgress.GetByStudent(c.Request.Context(), student)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
 	c.JSON(http.StatusOK, studentAccountResponse{
-		Name:  student.Name,
-		Email: student.Email,
+		Name:     student.Name,
+		Email:    student.Email,
+		Progress: progress,
 	})
 }
 
@@ -0,0 +1,401 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type updateStudentAccountInput struct {
+	Name string `json:"name" binding:"required,min=2,max=64"`
+}
+
+type changeStudentEmailInput struct {
+	Email    string `json:"email" binding:"required,email,max=64"`
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type confirmStudentEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type changeStudentPasswordInput struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword" binding:"required,min=8,max=64"`
+	Code            string `json:"code"`
+}
+
+type deleteStudentAccountInput struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// @Summary Student Update Account
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student update account info
+// @ModuleID studentUpdateAccount
+// @Accept  json
+// @Produce  json
+// @Param input body updateStudentAccountInput true "account info"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account [put]
+func (h *Handler) studentUpdateAccount(c *gin.Context) {
+	var inp updateStudentAccountInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.UpdateAccount(c.Request.Context(), service.UpdateStudentAccountInput{
+		StudentID: studentId,
+		SchoolID:  school.ID,
+		Name:      inp.Name,
+	}); err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student Change Email
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student request email change, confirmation link is sent to the new email.
+// @Description Current password is required if account has one, otherwise emailed confirmation code is required
+// @ModuleID studentChangeEmail
+// @Accept  json
+// @Produce  json
+// @Param input body changeStudentEmailInput true "new email"
+// @Success 200 {string} string "ok"
+// @Failure 400,403,409,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account/email [post]
+func (h *Handler) studentChangeEmail(c *gin.Context) {
+	var inp changeStudentEmailInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.RequestEmailChange(c.Request.Context(), service.StudentChangeEmailInput{
+		StudentID:    studentId,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+		Email:        inp.Email,
+		Password:     inp.Password,
+		Code:         inp.Code,
+	}); err != nil {
+		newStudentAccountErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student Request Confirmation Code
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student without password request one-time code to the current email,
+// @Description the code confirms email change, password change or account deletion
+// @ModuleID studentRequestConfirmationCode
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account/confirmation-code [post]
+func (h *Handler) studentRequestConfirmationCode(c *gin.Context) {
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.RequestConfirmationCode(c.Request.Context(), service.StudentRequestConfirmationCodeInput{
+		StudentID: studentId,
+		SchoolID:  school.ID,
+	}); err != nil {
+		newStudentAccountErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student Confirm Email Change
+// @Tags students-account
+// @Description student confirm new email with token from the confirmation link
+// @ModuleID studentConfirmEmailChange
+// @Accept  json
+// @Produce  json
+// @Param input body confirmStudentEmailInput true "confirmation token"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account/email/confirm [post]
+func (h *Handler) studentConfirmEmailChange(c *gin.Context) {
+	var inp confirmStudentEmailInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.ConfirmEmailChange(c.Request.Context(), service.StudentConfirmEmailChangeInput{
+		Token:    inp.Token,
+		SchoolID: school.ID,
+	}); err != nil {
+		newStudentAccountErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student Change Password
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student change password, all sessions are revoked and new tokens are issued for the current device.
+// @Description Current password is required if account has one, otherwise emailed confirmation code is required
+// @ModuleID studentChangePassword
+// @Accept  json
+// @Produce  json
+// @Param input body changeStudentPasswordInput true "passwords"
+// @Success 200 {object} tokenResponse
+// @Failure 400,403,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account/password [put]
+func (h *Handler) studentChangePassword(c *gin.Context) {
+	var inp changeStudentPasswordInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Students.ChangePassword(c.Request.Context(), service.StudentChangePasswordInput{
+		StudentID:       studentId,
+		SchoolID:        school.ID,
+		SchoolDomain:    schoolDomain,
+		CurrentPassword: inp.CurrentPassword,
+		NewPassword:     inp.NewPassword,
+		Code:            inp.Code,
+		Device:          getDevice(c),
+	})
+	if err != nil {
+		newStudentAccountErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
+
+// @Summary Student Delete Account
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student delete account, personal data is erased from orders and survey results.
+// @Description Current password is required if account has one, otherwise emailed confirmation code is required
+// @ModuleID studentDeleteAccount
+// @Accept  json
+// @Produce  json
+// @Param input body deleteStudentAccountInput true "current password"
+// @Success 200 {string} string "ok"
+// @Failure 400,403,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account [delete]
+func (h *Handler) studentDeleteAccount(c *gin.Context) {
+	var inp deleteStudentAccountInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Students.DeleteAccount(c.Request.Context(), service.StudentDeleteAccountInput{
+		StudentID: studentId,
+		SchoolID:  school.ID,
+		Password:  inp.Password,
+		Code:      inp.Code,
+	}); err != nil {
+		newStudentAccountErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student Export Data
+// @Security StudentsAuth
+// @Tags students-account
+// @Description student request archive with all personal data, download link is emailed when it's ready.
+// @Description Archive can be requested once an hour, the link expires in a day
+// @ModuleID studentExportData
+// @Accept  json
+// @Produce  json
+// @Success 202 {string} string "accepted"
+// @Failure 400,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/account/export [post]
+func (h *Handler) studentExportData(c *gin.Context) {
+	studentId, err := getStudentId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	h.exportStudentData(c, school.ID, studentId)
+}
+
+func (h *Handler) exportStudentData(c *gin.Context, schoolId, studentId primitive.ObjectID) {
+	if err := h.services.StudentExports.Export(c.Request.Context(), schoolId, studentId); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			newResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrStudentExportTooFrequent):
+			newResponse(c, http.StatusTooManyRequests, err.Error())
+		default:
+			newResponse(c, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func newStudentAccountErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPasswordInvalid), errors.Is(err, domain.ErrConfirmationCodeInvalid):
+		newResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrConfirmationCodeNotNeeded):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		newResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrOneTimeTokenInvalid):
+		newResponse(c, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		newResponse(c, http.StatusTooManyRequests, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
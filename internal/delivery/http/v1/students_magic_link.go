@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type magicLinkRequestInput struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+}
+
+type magicLinkSignInInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// @Summary Student Request Magic Link
+// @Tags students-auth
+// @Description send single-use login link to the student email, available when school enabled magic link login
+// @ModuleID studentRequestMagicLink
+// @Accept  json
+// @Produce  json
+// @Param input body magicLinkRequestInput true "student email"
+// @Success 200 {string} string "ok"
+// @Failure 400,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/magic-link [post]
+func (h *Handler) studentRequestMagicLink(c *gin.Context) {
+	var inp magicLinkRequestInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if !school.Settings.MagicLinkLogin {
+		newResponse(c, http.StatusForbidden, domain.ErrMagicLinkDisabled.Error())
+
+		return
+	}
+
+	if err := h.services.Students.RequestMagicLink(c.Request.Context(), service.StudentRequestMagicLinkInput{
+		Email:               inp.Email,
+		SchoolID:            school.ID,
+		SchoolDomain:        schoolDomain,
+		DisableRegistration: school.Settings.DisableRegistration,
+	}); err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Student SignIn With Magic Link
+// @Tags students-auth
+// @Description student sign in with token from the login link
+// @ModuleID studentSignInMagicLink
+// @Accept  json
+// @Produce  json
+// @Param input body magicLinkSignInInput true "login link token"
+// @Success 200 {object} tokenResponse
+// @Failure 400,401,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/magic-link/confirm [post]
+func (h *Handler) studentSignInMagicLink(c *gin.Context) {
+	var inp magicLinkSignInInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if !school.Settings.MagicLinkLogin {
+		newResponse(c, http.StatusForbidden, domain.ErrMagicLinkDisabled.Error())
+
+		return
+	}
+
+	res, err := h.services.Students.SignInMagicLink(c.Request.Context(), service.StudentMagicLinkSignInInput{
+		Token:               inp.Token,
+		SchoolID:            school.ID,
+		SchoolDomain:        schoolDomain,
+		DisableRegistration: school.Settings.DisableRegistration,
+		Device:              getDevice(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOneTimeTokenInvalid), errors.Is(err, domain.ErrUserNotFound):
+			newResponse(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, domain.ErrStudentBlocked):
+			newResponse(c, http.StatusForbidden, err.Error())
+		default:
+			newResponse(c, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
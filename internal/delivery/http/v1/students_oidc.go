@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type oidcAuthURLResponse struct {
+	URL   string `json:"url"`
+	Nonce string `json:"nonce"`
+}
+
+type oidcCallbackInput struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+	Nonce string `json:"nonce" binding:"required"`
+}
+
+// @Summary Student OIDC Auth URL
+// @Tags students-auth
+// @Description student get sign in URL of the school's OIDC provider.
+// @Description Client keeps nonce till the callback, e.g. in the session storage, and sends it back with code and state
+// @ModuleID studentGetOIDCAuthURL
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "provider name"
+// @Success 200 {object} oidcAuthURLResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/oidc/{provider} [get]
+func (h *Handler) studentGetOIDCAuthURL(c *gin.Context) {
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	provider, err := school.Settings.GetOIDCProvider(c.Param("provider"))
+	if err != nil {
+		newResponse(c, http.StatusNotFound, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Students.GetOIDCAuthURL(c.Request.Context(), service.StudentOIDCAuthURLInput{
+		Provider:     provider,
+		SchoolID:     school.ID,
+		SchoolDomain: schoolDomain,
+	})
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, oidcAuthURLResponse{URL: res.URL, Nonce: res.Nonce})
+}
+
+// @Summary Student OIDC Callback
+// @Tags students-auth
+// @Description student sign in with code returned by the school's OIDC provider
+// @ModuleID studentOIDCCallback
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "provider name"
+// @Param input body oidcCallbackInput true "code and state from provider's redirect, nonce from auth url response"
+// @Success 200 {object} tokenResponse
+// @Failure 400,401,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /students/oidc/{provider}/callback [post]
+func (h *Handler) studentOIDCCallback(c *gin.Context) {
+	var inp oidcCallbackInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	provider, err := school.Settings.GetOIDCProvider(c.Param("provider"))
+	if err != nil {
+		newResponse(c, http.StatusNotFound, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Students.SignInOIDC(c.Request.Context(), service.StudentOIDCSignInInput{
+		Provider:            provider,
+		Code:                inp.Code,
+		State:               inp.State,
+		Nonce:               inp.Nonce,
+		SchoolID:            school.ID,
+		SchoolDomain:        schoolDomain,
+		DisableRegistration: school.Settings.DisableRegistration,
+		Device:              getDevice(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOneTimeTokenInvalid):
+			newResponse(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, domain.ErrOIDCEmailNotVerified):
+			newResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrStudentBlocked), errors.Is(err, domain.ErrRegistrationDisabled):
+			newResponse(c, http.StatusForbidden, err.Error())
+		default:
+			newResponse(c, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
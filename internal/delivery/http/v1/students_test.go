@@ -255,8 +255,10 @@ func TestHandler_studentGetModuleContent(t *testing.T) {
 						Name:      "test lesson",
 						Position:  0,
 						Published: true,
-						Content:   "content",
-						SchoolID:  schoolId,
+						Blocks: []domain.LessonBlock{
+							{Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: "content"}},
+						},
+						SchoolID: schoolId,
 					},
 				},
 			},
@@ -264,7 +266,7 @@ func TestHandler_studentGetModuleContent(t *testing.T) {
 				r.EXPECT().GetModuleContent(context.Background(), schoolId, studentId, moduleId).Return(content, nil)
 			},
 			statusCode:   200,
-			responseBody: fmt.Sprintf(`{"lessons":[{"id":"000000000000000000000000","name":"test lesson","position":0,"published":true,"content":"content","schoolId":"%s"}],"survey":{"title":"","questions":null,"required":false}}`, schoolId.Hex()),
+			responseBody: fmt.Sprintf(`{"lessons":[{"id":"000000000000000000000000","name":"test lesson","position":0,"published":true,"blocks":[{"id":"000000000000000000000000","type":"text","position":0,"text":{"html":"content"}}],"schoolId":"%s"}],"survey":{"title":"","questions":null,"required":false}}`, schoolId.Hex()),
 		},
 		{
 			name:      "invalid module id",
@@ -555,3 +557,94 @@ func TestHandler_studentSignUp(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_studentResendVerification(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockStudents, input service.ResendVerificationInput)
+
+	schoolId := primitive.NewObjectID()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		serviceInput service.ResendVerificationInput
+		mockBehavior mockBehavior
+		statusCode   int
+		responseBody string
+	}{
+		{
+			name:        "ok",
+			requestBody: `{"email":"test@test.com"}`,
+			serviceInput: service.ResendVerificationInput{
+				Email:        "test@test.com",
+				SchoolID:     schoolId,
+				SchoolDomain: "localhost",
+			},
+			mockBehavior: func(r *mock_service.MockStudents, input service.ResendVerificationInput) {
+				r.EXPECT().ResendVerification(context.Background(), input).Return(nil)
+			},
+			statusCode:   200,
+			responseBody: `{"message":"success"}`,
+		},
+		{
+			name:         "invalid email",
+			requestBody:  `{"email":"test"}`,
+			mockBehavior: func(r *mock_service.MockStudents, input service.ResendVerificationInput) {},
+			statusCode:   400,
+			responseBody: `{"message":"invalid input body"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s := mock_service.NewMockStudents(c)
+
+			tt.mockBehavior(s, tt.serviceInput)
+
+			services := &service.Services{Students: s}
+			handler := Handler{services: services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/verification/resend", func(c *gin.Context) {
+				c.Set(schoolCtx, domain.School{ID: schoolId})
+				c.Set(domainCtx, "localhost")
+			}, handler.studentResendVerification)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/verification/resend", bytes.NewBufferString(tt.requestBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, tt.statusCode)
+			assert.Equal(t, w.Body.String(), tt.responseBody)
+		})
+	}
+}
+
+func TestHandler_studentVerifyExpiredCode(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s := mock_service.NewMockStudents(c)
+	s.EXPECT().Verify(context.Background(), "CODE1234").Return(domain.ErrVerificationCodeExpired)
+
+	handler := Handler{services: &service.Services{Students: s}}
+
+	r := gin.New()
+	r.POST("/verify/:code", handler.studentVerify)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/verify/CODE1234", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, 410)
+	assert.Equal(t, w.Body.String(), fmt.Sprintf(`{"message":"%s"}`, domain.ErrVerificationCodeExpired.Error()))
+}
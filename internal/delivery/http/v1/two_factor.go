@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+)
+
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+}
+
+type twoFactorSignInInput struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type twoFactorCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type twoFactorEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type twoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// @Summary Admin SignIn Two-Factor
+// @Tags admins-auth
+// @Description admin finish sign in with TOTP or recovery code
+// @ModuleID adminSignInTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body twoFactorSignInInput true "challenge token and code"
+// @Success 200 {object} tokenResponse
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/sign-in/2fa [post]
+func (h *Handler) adminSignInTwoFactor(c *gin.Context) {
+	var inp twoFactorSignInInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schoolDomain, err := getDomainFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Admins.SignInTwoFactor(c.Request.Context(), service.AdminTwoFactorSignInInput{
+		ChallengeToken: inp.ChallengeToken,
+		Code:           inp.Code,
+		SchoolID:       school.ID,
+		SchoolDomain:   schoolDomain,
+		Device:         getDevice(c),
+	})
+	if err != nil {
+		newTwoFactorErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
+
+// @Summary Admin Enroll Two-Factor
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin generate TOTP secret, two-factor is enabled only after confirmation
+// @ModuleID adminEnrollTwoFactor
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} twoFactorEnrollmentResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/2fa/enroll [post]
+func (h *Handler) adminEnrollTwoFactor(c *gin.Context) {
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := getSchoolFromContext(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	enrollment, err := h.services.Admins.EnrollTwoFactor(c.Request.Context(), adminId, school.Name)
+	if err != nil {
+		newTwoFactorErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactorEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+// @Summary Admin Confirm Two-Factor
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin enable two-factor with code from authenticator app, returns recovery codes
+// @ModuleID adminConfirmTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body twoFactorCodeInput true "TOTP code"
+// @Success 200 {object} twoFactorRecoveryCodesResponse
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/2fa/confirm [post]
+func (h *Handler) adminConfirmTwoFactor(c *gin.Context) {
+	var inp twoFactorCodeInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	recoveryCodes, err := h.services.Admins.ConfirmTwoFactor(c.Request.Context(), adminId, inp.Code)
+	if err != nil {
+		newTwoFactorErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactorRecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// @Summary Admin Disable Two-Factor
+// @Security AdminAuth
+// @Tags admins-auth
+// @Description admin disable two-factor with TOTP or recovery code
+// @ModuleID adminDisableTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body twoFactorCodeInput true "TOTP or recovery code"
+// @Success 200 {string} string "ok"
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admins/2fa [delete]
+func (h *Handler) adminDisableTwoFactor(c *gin.Context) {
+	var inp twoFactorCodeInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	adminId, err := getIdByContext(c, adminCtx)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Admins.DisableTwoFactor(c.Request.Context(), adminId, inp.Code); err != nil {
+		newTwoFactorErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func newTwoFactorErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOneTimeTokenInvalid), errors.Is(err, domain.ErrTwoFactorCodeInvalid):
+		newResponse(c, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domain.ErrTwoFactorAlreadyEnabled), errors.Is(err, domain.ErrTwoFactorNotEnabled),
+		errors.Is(err, domain.ErrTwoFactorNotEnrolled):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -15,10 +15,13 @@ func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 		users.POST("/sign-up", h.userSignUp)
 		users.POST("/sign-in", h.userSignIn)
 		users.POST("/auth/refresh", h.userRefresh)
+		users.POST("/password-reset", h.userRequestPasswordReset)
+		users.POST("/password-reset/confirm", h.userResetPassword)
 
 		authenticated := users.Group("/", h.userIdentity)
 		{
 			authenticated.POST("/verify/:code", h.userVerify)
+			authenticated.POST("/verification/resend", h.userResendVerification)
 
 			schools := authenticated.Group("/schools/")
 			{
@@ -26,6 +29,7 @@ func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 				schools.GET("", h.userGetSchools)
 				schools.GET("/:id", h.userGetSchoolById)
 				schools.PUT("/:id", h.userUpdateSchool)
+				schools.POST("/:id/impersonate", h.userImpersonateAdmin)
 			}
 		}
 	}
@@ -86,7 +90,7 @@ func (h *Handler) userSignUp(c *gin.Context) {
 // @Produce  json
 // @Param input body signInInput true "sign up info"
 // @Success 200 {object} tokenResponse
-// @Failure 400,404 {object} response
+// @Failure 400,404,429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /users/sign-in [post]
@@ -101,6 +105,7 @@ func (h *Handler) userSignIn(c *gin.Context) {
 	res, err := h.services.Users.SignIn(c.Request.Context(), service.UserSignInInput{
 		Email:    inp.Email,
 		Password: inp.Password,
+		Device:   getDevice(c),
 	})
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -109,6 +114,12 @@ func (h *Handler) userSignIn(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrTooManyAttempts) {
+			newResponse(c, http.StatusTooManyRequests, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -139,8 +150,17 @@ func (h *Handler) userRefresh(c *gin.Context) {
 		return
 	}
 
-	res, err := h.services.Users.RefreshTokens(c.Request.Context(), inp.Token)
+	res, err := h.services.Users.RefreshTokens(c.Request.Context(), service.UserRefreshTokensInput{
+		RefreshToken: inp.Token,
+		Device:       getDevice(c),
+	})
 	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			newResponse(c, http.StatusUnauthorized, err.Error())
+
+			return
+		}
+
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
@@ -161,7 +181,7 @@ func (h *Handler) userRefresh(c *gin.Context) {
 // @Produce  json
 // @Param code path string true "verification code"
 // @Success 200 {object} tokenResponse
-// @Failure 400,404 {object} response
+// @Failure 400,404,410 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /users/verify/{code} [post]
@@ -181,17 +201,40 @@ func (h *Handler) userVerify(c *gin.Context) {
 	}
 
 	if err := h.services.Users.Verify(c.Request.Context(), id, code); err != nil {
-		if errors.Is(err, domain.ErrVerificationCodeInvalid) {
-			newResponse(c, http.StatusBadRequest, err.Error())
+		newVerificationErrorResponse(c, err)
 
-			return
-		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response{"success"})
+}
 
+// @Summary User Resend Verification
+// @Security UsersAuth
+// @Tags users-auth
+// @Description user request a new verification code
+// @ModuleID userResendVerification
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} response
+// @Failure 400,429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/verification/resend [post]
+func (h *Handler) userResendVerification(c *gin.Context) {
+	id, err := getUserId(c)
+	if err != nil {
 		newResponse(c, http.StatusInternalServerError, err.Error())
 
 		return
 	}
 
+	if err := h.services.Users.ResendVerification(c.Request.Context(), id); err != nil {
+		newVerificationErrorResponse(c, err)
+
+		return
+	}
+
 	c.JSON(http.StatusOK, response{"success"})
 }
 
@@ -237,11 +280,173 @@ func (h *Handler) userCreateSchool(c *gin.Context) {
 	c.JSON(http.StatusCreated, school)
 }
 
+// @Summary User Get Schools
+// @Security UsersAuth
+// @Tags users-schools
+// @Description user get owned schools
+// @ModuleID userGetSchools
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dataResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/schools [get]
 func (h *Handler) userGetSchools(c *gin.Context) {
+	id, err := getUserId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	schools, err := h.services.Users.GetSchools(c.Request.Context(), id)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: schools, Count: int64(len(schools))})
 }
 
+// @Summary User Get School By ID
+// @Security UsersAuth
+// @Tags users-schools
+// @Description user get owned school by id
+// @ModuleID userGetSchoolById
+// @Accept  json
+// @Produce  json
+// @Param id path string true "school id"
+// @Success 200 {object} domain.School
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/schools/{id} [get]
 func (h *Handler) userGetSchoolById(c *gin.Context) {
+	schoolId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	id, err := getUserId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	school, err := h.services.Users.GetSchoolById(c.Request.Context(), id, schoolId)
+	if err != nil {
+		newUserSchoolErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, school)
 }
 
+// @Summary User Update School
+// @Security UsersAuth
+// @Tags users-schools
+// @Description user update owned school settings
+// @ModuleID userUpdateSchool
+// @Accept  json
+// @Produce  json
+// @Param id path string true "school id"
+// @Param input body updateSchoolSettingsInput true "update school settings"
+// @Success 200 {string} string "ok"
+// @Failure 400,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/schools/{id} [put]
 func (h *Handler) userUpdateSchool(c *gin.Context) {
+	schoolId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var inp updateSchoolSettingsInput
+	if err := c.BindJSON(&inp); err != nil {
+		newResponse(c, http.StatusBadRequest, "invalid input body")
+
+		return
+	}
+
+	id, err := getUserId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	if err := h.services.Users.UpdateSchool(c.Request.Context(), id, schoolId, toUpdateSchoolSettingsInput(inp)); err != nil {
+		newUserSchoolErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary User Impersonate School Admin
+// @Security UsersAuth
+// @Tags users-schools
+// @Description user get admin tokens for owned school
+// @ModuleID userImpersonateAdmin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "school id"
+// @Success 200 {object} tokenResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/schools/{id}/impersonate [post]
+func (h *Handler) userImpersonateAdmin(c *gin.Context) {
+	schoolId, err := parseIdFromPath(c, "id")
+	if err != nil {
+		newResponse(c, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	id, err := getUserId(c)
+	if err != nil {
+		newResponse(c, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	res, err := h.services.Users.ImpersonateAdmin(c.Request.Context(), service.UserImpersonateAdminInput{
+		UserID:   id,
+		SchoolID: schoolId,
+		Device:   getDevice(c),
+	})
+	if err != nil {
+		newUserSchoolErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
+
+func newUserSchoolErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSchoolNotFound):
+		newResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrSchoolHasNoDomains):
+		newResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrSchoolDomainTaken):
+		newResponse(c, http.StatusConflict, err.Error())
+	default:
+		newResponse(c, http.StatusInternalServerError, err.Error())
+	}
 }
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHandler_userImpersonateAdmin(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockUsers, userId, schoolId primitive.ObjectID)
+
+	userId := primitive.NewObjectID()
+	schoolId := primitive.NewObjectID()
+
+	tests := []struct {
+		name         string
+		schoolId     string
+		mockBehavior mockBehavior
+		statusCode   int
+		responseBody string
+	}{
+		{
+			name:     "ok",
+			schoolId: schoolId.Hex(),
+			mockBehavior: func(r *mock_service.MockUsers, userId, schoolId primitive.ObjectID) {
+				r.EXPECT().ImpersonateAdmin(context.Background(), service.UserImpersonateAdminInput{
+					UserID:   userId,
+					SchoolID: schoolId,
+					Device:   service.Device{IP: "192.0.2.1"},
+				}).Return(service.Tokens{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			statusCode:   200,
+			responseBody: `{"accessToken":"access","refreshToken":"refresh"}`,
+		},
+		{
+			name:         "invalid school id",
+			schoolId:     "123",
+			mockBehavior: func(r *mock_service.MockUsers, userId, schoolId primitive.ObjectID) {},
+			statusCode:   400,
+			responseBody: `{"message":"invalid id param"}`,
+		},
+		{
+			name:     "school not owned",
+			schoolId: schoolId.Hex(),
+			mockBehavior: func(r *mock_service.MockUsers, userId, schoolId primitive.ObjectID) {
+				r.EXPECT().ImpersonateAdmin(context.Background(), gomock.Any()).Return(service.Tokens{}, domain.ErrSchoolNotFound)
+			},
+			statusCode:   404,
+			responseBody: fmt.Sprintf(`{"message":"%s"}`, domain.ErrSchoolNotFound),
+		},
+		{
+			name:     "school has no domains",
+			schoolId: schoolId.Hex(),
+			mockBehavior: func(r *mock_service.MockUsers, userId, schoolId primitive.ObjectID) {
+				r.EXPECT().ImpersonateAdmin(context.Background(), gomock.Any()).Return(service.Tokens{}, domain.ErrSchoolHasNoDomains)
+			},
+			statusCode:   400,
+			responseBody: fmt.Sprintf(`{"message":"%s"}`, domain.ErrSchoolHasNoDomains),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s := mock_service.NewMockUsers(c)
+			tt.mockBehavior(s, userId, schoolId)
+
+			services := &service.Services{Users: s}
+			handler := Handler{services: services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/schools/:id/impersonate", func(c *gin.Context) {
+				c.Set(userCtx, userId.Hex())
+			}, handler.userImpersonateAdmin)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/schools/%s/impersonate", tt.schoolId), nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, tt.statusCode)
+			assert.Equal(t, w.Body.String(), tt.responseBody)
+		})
+	}
+}
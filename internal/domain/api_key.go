@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resources available with API keys. Scope is "<resource>:read" or "<resource>:write",
+// write scope doesn't include read.
+const (
+	APIKeyResourceCourses    = "courses"
+	APIKeyResourceOffers     = "offers"
+	APIKeyResourcePromocodes = "promocodes"
+	APIKeyResourceStudents   = "students"
+	APIKeyResourceOrders     = "orders"
+)
+
+var apiKeyResources = []string{
+	APIKeyResourceCourses, APIKeyResourceOffers, APIKeyResourcePromocodes, APIKeyResourceStudents, APIKeyResourceOrders,
+}
+
+// APIKey is a long-lived credential for server-to-server integrations with admin API.
+// Only SHA256 hash of the key is stored, Prefix is kept to help admins tell keys apart.
+type APIKey struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SchoolID   primitive.ObjectID `json:"-" bson:"schoolId"`
+	Name       string             `json:"name" bson:"name"`
+	Prefix     string             `json:"prefix" bson:"prefix"`
+	Hash       string             `json:"-" bson:"hash"`
+	Scopes     []string           `json:"scopes" bson:"scopes"`
+	CreatedBy  primitive.ObjectID `json:"createdBy" bson:"createdBy"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	LastUsedAt time.Time          `json:"lastUsedAt" bson:"lastUsedAt,omitempty"`
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func APIKeyScope(resource string, write bool) string {
+	if write {
+		return resource + ":write"
+	}
+
+	return resource + ":read"
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	for _, resource := range apiKeyResources {
+		if scope == APIKeyScope(resource, false) || scope == APIKeyScope(resource, true) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -16,33 +16,55 @@ type Course struct {
 	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt,omitempty"`
 	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt,omitempty"`
 	Published   bool               `json:"published" bson:"published,omitempty"`
+	Gating      string             `json:"gating,omitempty" bson:"gating,omitempty"`
 }
 
+// Module is published manually or at PublishAt by the scheduler. DripDays unlocks module for student
+// N days after access to the offer. AvailableAt is set for scheduled module in the course listing,
+// module content has it for drip as well.
 type Module struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name      string             `json:"name" bson:"name"`
-	Position  uint               `json:"position" bson:"position"`
-	Published bool               `json:"published"`
-	CourseID  primitive.ObjectID `json:"courseId" bson:"courseId"`
-	PackageID primitive.ObjectID `json:"packageId,omitempty" bson:"packageId,omitempty"`
-	SchoolID  primitive.ObjectID `json:"schoolId" bson:"schoolId"`
-	Lessons   []Lesson           `json:"lessons,omitempty" bson:"lessons,omitempty"`
-	Survey    Survey             `json:"survey,omitempty" bson:"survey,omitempty"`
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"`
+	Position    uint               `json:"position" bson:"position"`
+	Published   bool               `json:"published"`
+	PublishAt   *time.Time         `json:"publishAt,omitempty" bson:"publishAt,omitempty"`
+	DripDays    uint               `json:"dripDays,omitempty" bson:"dripDays,omitempty"`
+	AvailableAt *time.Time         `json:"availableAt,omitempty" bson:"-"`
+	Gating      string             `json:"gating,omitempty" bson:"gating,omitempty"`
+	CourseID    primitive.ObjectID `json:"courseId" bson:"courseId"`
+	PackageID   primitive.ObjectID `json:"packageId,omitempty" bson:"packageId,omitempty"`
+	SchoolID    primitive.ObjectID `json:"schoolId" bson:"schoolId"`
+	Lessons     []Lesson           `json:"lessons,omitempty" bson:"lessons,omitempty"`
+	Survey      Survey             `json:"survey,omitempty" bson:"survey,omitempty"`
 }
 
+// Lesson is scheduled the same way as Module and is never available before its module.
+// Prerequisite is set for students, when lesson is locked by gating mode.
 type Lesson struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name      string             `json:"name" bson:"name"`
-	Position  uint               `json:"position" bson:"position"`
-	Published bool               `json:"published" bson:"published,omitempty"`
-	Content   string             `json:"content,omitempty" bson:"content,omitempty"`
-	SchoolID  primitive.ObjectID `json:"schoolId" bson:"schoolId"`
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name         string             `json:"name" bson:"name"`
+	Position     uint               `json:"position" bson:"position"`
+	Published    bool               `json:"published" bson:"published,omitempty"`
+	PublishAt    *time.Time         `json:"publishAt,omitempty" bson:"publishAt,omitempty"`
+	DripDays     uint               `json:"dripDays,omitempty" bson:"dripDays,omitempty"`
+	AvailableAt  *time.Time         `json:"availableAt,omitempty" bson:"-"`
+	Prerequisite *Prerequisite      `json:"prerequisite,omitempty" bson:"-"`
+	Blocks       []LessonBlock      `json:"blocks,omitempty" bson:"-"`
+	Version      int                `json:"version,omitempty" bson:"-"`
+	Draft        *LessonDraft       `json:"draft,omitempty" bson:"-"`
+	SchoolID     primitive.ObjectID `json:"schoolId" bson:"schoolId"`
 }
 
 type LessonContent struct {
 	LessonID primitive.ObjectID `json:"lessonId" bson:"lessonId"`
 	SchoolID primitive.ObjectID `json:"schoolId" bson:"schoolId"`
-	Content  string             `json:"content" bson:"content"`
+	Blocks   []LessonBlock      `json:"blocks" bson:"blocks,omitempty"`
+	// Version is published version, LastVersion is the latest saved one, including drafts.
+	Version     int          `json:"version" bson:"version,omitempty"`
+	LastVersion int          `json:"lastVersion" bson:"lastVersion,omitempty"`
+	Draft       *LessonDraft `json:"draft,omitempty" bson:"draft,omitempty"`
+	// Content is legacy raw content, it's converted to a single text block on read and removed on update.
+	Content string `json:"-" bson:"content,omitempty"`
 }
 
 type Package struct {
@@ -54,6 +76,7 @@ type Package struct {
 }
 
 type ModuleContent struct {
-	Lessons []Lesson `json:"lessons" bson:"lessons"`
-	Survey  Survey   `json:"survey" bson:"survey"`
+	Lessons     []Lesson   `json:"lessons" bson:"lessons"`
+	Survey      Survey     `json:"survey" bson:"survey"`
+	AvailableAt *time.Time `json:"availableAt,omitempty" bson:"-"`
 }
@@ -3,16 +3,64 @@ package domain
 import "errors"
 
 var (
-	ErrUserNotFound            = errors.New("user doesn't exists")
-	ErrVerificationCodeInvalid = errors.New("verification code is invalid")
-	ErrOfferNotFound           = errors.New("offer doesn't exists")
-	ErrPromoNotFound           = errors.New("promocode doesn't exists")
-	ErrCourseNotFound          = errors.New("course not found")
-	ErrUserAlreadyExists       = errors.New("user with such email already exists")
-	ErrModuleIsNotAvailable    = errors.New("module's content is not available")
-	ErrPromocodeExpired        = errors.New("promocode has expired")
-	ErrTransactionInvalid      = errors.New("transaction is invalid")
-	ErrUnknownCallbackType     = errors.New("unknown callback type")
-	ErrSendPulseIsNotConnected = errors.New("sendpulse is not connected")
-	ErrStudentBlocked          = errors.New("student is blocked by the admin")
+	ErrUserNotFound               = errors.New("user doesn't exists")
+	ErrVerificationCodeInvalid    = errors.New("verification code is invalid")
+	ErrVerificationCodeExpired    = errors.New("verification code has expired")
+	ErrVerificationSentRecently   = errors.New("verification code was sent recently, try again later")
+	ErrAlreadyVerified            = errors.New("account is already verified")
+	ErrOfferNotFound              = errors.New("offer doesn't exists")
+	ErrPromoNotFound              = errors.New("promocode doesn't exists")
+	ErrCourseNotFound             = errors.New("course not found")
+	ErrUserAlreadyExists          = errors.New("user with such email already exists")
+	ErrRegistrationDisabled       = errors.New("registration is disabled by the school")
+	ErrModuleIsNotAvailable       = errors.New("module's content is not available")
+	ErrPromocodeExpired           = errors.New("promocode has expired")
+	ErrTransactionInvalid         = errors.New("transaction is invalid")
+	ErrUnknownCallbackType        = errors.New("unknown callback type")
+	ErrSendPulseIsNotConnected    = errors.New("sendpulse is not connected")
+	ErrStudentBlocked             = errors.New("student is blocked by the admin")
+	ErrSessionNotFound            = errors.New("session doesn't exists or has expired")
+	ErrRefreshTokenReused         = errors.New("refresh token has already been used, session is revoked")
+	ErrOneTimeTokenInvalid        = errors.New("token is invalid or has expired")
+	ErrTwoFactorAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled       = errors.New("two-factor authentication enrolment is not started")
+	ErrTwoFactorCodeInvalid       = errors.New("two-factor authentication code is invalid")
+	ErrTooManyAttempts            = errors.New("too many failed attempts, try again later")
+	ErrStudentExportTooFrequent   = errors.New("data export was requested recently, try again later")
+	ErrSchoolNotFound             = errors.New("school doesn't exists")
+	ErrSchoolHasNoDomains         = errors.New("school doesn't have any domains")
+	ErrSchoolDomainTaken          = errors.New("domain is already used by another school")
+	ErrAdminAlreadyExists         = errors.New("admin with such email already exists")
+	ErrAdminRoleInvalid           = errors.New("admin role is invalid")
+	ErrLastSchoolOwner            = errors.New("school must have at least one owner")
+	ErrCannotRemoveSelf           = errors.New("admin can't remove himself from the team")
+	ErrPermissionDenied           = errors.New("admin role doesn't allow this action")
+	ErrOIDCProviderNotFound       = errors.New("sign-in provider is not configured for the school")
+	ErrOIDCProviderInvalid        = errors.New("sign-in provider must have unique name, client id and issuer or endpoints")
+	ErrOIDCEmailNotVerified       = errors.New("email is not verified by the sign-in provider")
+	ErrMagicLinkDisabled          = errors.New("login by email link is disabled for the school")
+	ErrAPIKeyInvalid              = errors.New("api key is invalid")
+	ErrAPIKeyNotFound             = errors.New("api key doesn't exists")
+	ErrAPIKeyScopeInvalid         = errors.New("api key scope is invalid")
+	ErrPasswordInvalid            = errors.New("current password is invalid")
+	ErrConfirmationCodeInvalid    = errors.New("confirmation code is invalid or has expired")
+	ErrConfirmationCodeNotNeeded  = errors.New("account has a password, confirm changes with it")
+	ErrStudentImportNotFound      = errors.New("students import doesn't exists")
+	ErrStudentImportInvalid       = errors.New("csv file must have header with name and email columns and at least one row")
+	ErrStudentImportTooLarge      = errors.New("csv file has too many rows")
+	ErrStudentTagInvalid          = errors.New("tag must be up to 32 letters, digits, dots, dashes or underscores")
+	ErrStudentFieldInvalid        = errors.New("student field is not defined by the school or its value is invalid")
+	ErrStudentFieldsInvalid       = errors.New("student fields must have unique keys, names and text, number or date type")
+	ErrStudentFilterInvalid       = errors.New("students filter is invalid")
+	ErrStudentSegmentNotFound     = errors.New("students segment doesn't exists")
+	ErrStudentActivityTypeInvalid = errors.New("student activity type is invalid")
+	ErrLessonBlockInvalid         = errors.New("lesson block is invalid")
+	ErrLessonBlocksTooMany        = errors.New("lesson has too many blocks")
+	ErrLessonVersionNotFound      = errors.New("lesson version doesn't exists")
+	ErrLessonDraftNotFound        = errors.New("lesson doesn't have a draft")
+	ErrContentIsLocked            = errors.New("content is not available yet")
+	ErrPrerequisiteMissing        = errors.New("lesson prerequisite is not completed")
+	ErrGatingModeInvalid          = errors.New("gating mode must be open or sequential")
+	ErrContentScheduleInvalid     = errors.New("publish time must be in the future and content can't be published at the same time")
 )
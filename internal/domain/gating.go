@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Gating modes of course and module. In sequential course module unlocks after all lessons of the previous
+// module are finished and its required survey is submitted, in sequential module lesson unlocks after the previous one
+// is finished. Module without gating mode inherits mode of the course.
+const (
+	GatingOpen       = "open"
+	GatingSequential = "sequential"
+)
+
+// Types of prerequisites.
+const (
+	PrerequisiteLesson = "lesson"
+	PrerequisiteSurvey = "survey"
+)
+
+func IsValidGatingMode(mode string) bool {
+	return mode == GatingOpen || mode == GatingSequential
+}
+
+// Prerequisite is a missing step, student has to complete before lesson unlocks.
+// LessonID is set for lesson prerequisite.
+type Prerequisite struct {
+	Type     string              `json:"type"`
+	ModuleID primitive.ObjectID  `json:"moduleId"`
+	LessonID *primitive.ObjectID `json:"lessonId,omitempty"`
+	Name     string              `json:"name"`
+}
+
+func (p Prerequisite) String() string {
+	if p.Type == PrerequisiteSurvey {
+		return fmt.Sprintf("submit survey of module %q first", p.Name)
+	}
+
+	return fmt.Sprintf("finish lesson %q first", p.Name)
+}
+
+// PrerequisiteError is returned for lesson, which prerequisite is not completed.
+type PrerequisiteError struct {
+	Prerequisite Prerequisite
+}
+
+func (e PrerequisiteError) Error() string {
+	return fmt.Sprintf("%s, %s", ErrPrerequisiteMissing, e.Prerequisite)
+}
+
+func (e PrerequisiteError) Unwrap() error {
+	return ErrPrerequisiteMissing
+}
+
+// GatingMode returns effective gating mode of the module.
+func (m Module) GatingMode(course Course) string {
+	if m.Gating != "" {
+		return m.Gating
+	}
+
+	if course.Gating != "" {
+		return course.Gating
+	}
+
+	return GatingOpen
+}
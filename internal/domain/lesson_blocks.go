@@ -0,0 +1,271 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Types of lesson content blocks.
+const (
+	LessonBlockText       = "text"
+	LessonBlockVideo      = "video"
+	LessonBlockImage      = "image"
+	LessonBlockAttachment = "attachment"
+	LessonBlockCode       = "code"
+	LessonBlockEmbed      = "embed"
+	LessonBlockQuiz       = "quiz"
+
+	LessonMaxBlocks = 100
+
+	lessonBlockTextMaxLength     = 100000
+	lessonBlockCodeMaxLength     = 50000
+	lessonBlockCaptionMaxLength  = 512
+	lessonQuizMaxQuestions       = 50
+	lessonQuizMaxOptions         = 10
+	lessonQuizQuestionMaxLength  = 1024
+	lessonQuizOptionMaxLength    = 512
+	lessonBlockEmbedURLMaxLength = 2048
+)
+
+var codeLanguageRegexp = regexp.MustCompile(`^[a-z0-9+#.\-]{0,32}$`)
+
+// LessonBlock is a single piece of lesson content. Only the payload matching Type is set,
+// video, image and attachment blocks share File payload.
+type LessonBlock struct {
+	ID       primitive.ObjectID `json:"id" bson:"id"`
+	Type     string             `json:"type" bson:"type"`
+	Position uint               `json:"position" bson:"position"`
+	Text     *TextBlock         `json:"text,omitempty" bson:"text,omitempty"`
+	File     *FileBlock         `json:"file,omitempty" bson:"file,omitempty"`
+	Code     *CodeBlock         `json:"code,omitempty" bson:"code,omitempty"`
+	Embed    *EmbedBlock        `json:"embed,omitempty" bson:"embed,omitempty"`
+	Quiz     *QuizBlock         `json:"quiz,omitempty" bson:"quiz,omitempty"`
+}
+
+type TextBlock struct {
+	HTML string `json:"html" bson:"html"`
+}
+
+// FileBlock references uploaded domain.File, file details are copied on save.
+type FileBlock struct {
+	FileID      primitive.ObjectID `json:"fileId" bson:"fileId"`
+	Caption     string             `json:"caption,omitempty" bson:"caption,omitempty"`
+	Name        string             `json:"name" bson:"name"`
+	ContentType string             `json:"contentType" bson:"contentType"`
+	Size        int64              `json:"size" bson:"size"`
+	URL         string             `json:"url" bson:"url"`
+}
+
+type CodeBlock struct {
+	Language string `json:"language,omitempty" bson:"language,omitempty"`
+	Code     string `json:"code" bson:"code"`
+}
+
+type EmbedBlock struct {
+	URL string `json:"url" bson:"url"`
+}
+
+type QuizBlock struct {
+	Questions []QuizQuestion `json:"questions" bson:"questions"`
+}
+
+type QuizQuestion struct {
+	ID       primitive.ObjectID `json:"id" bson:"id"`
+	Text     string             `json:"text" bson:"text"`
+	Multiple bool               `json:"multiple" bson:"multiple"`
+	Options  []QuizOption       `json:"options" bson:"options"`
+}
+
+type QuizOption struct {
+	ID      primitive.ObjectID `json:"id" bson:"id"`
+	Text    string             `json:"text" bson:"text"`
+	Correct bool               `json:"correct,omitempty" bson:"correct"`
+}
+
+// FileType returns type of the file expected by block, empty string means any type.
+func (b LessonBlock) FileType() FileType {
+	switch b.Type {
+	case LessonBlockVideo:
+		return Video
+	case LessonBlockImage:
+		return Image
+	default:
+		return ""
+	}
+}
+
+func (b LessonBlock) IsFileBlock() bool {
+	return b.Type == LessonBlockVideo || b.Type == LessonBlockImage || b.Type == LessonBlockAttachment
+}
+
+// Validate checks that block has only the payload of its type and the payload is valid.
+// Referenced files are checked by the service.
+func (b LessonBlock) Validate() error {
+	payloads := 0
+
+	for _, set := range []bool{b.Text != nil, b.File != nil, b.Code != nil, b.Embed != nil, b.Quiz != nil} {
+		if set {
+			payloads++
+		}
+	}
+
+	if payloads != 1 {
+		return lessonBlockError(b, "must have exactly one payload")
+	}
+
+	switch b.Type {
+	case LessonBlockText:
+		return b.validateText()
+	case LessonBlockVideo, LessonBlockImage, LessonBlockAttachment:
+		return b.validateFile()
+	case LessonBlockCode:
+		return b.validateCode()
+	case LessonBlockEmbed:
+		return b.validateEmbed()
+	case LessonBlockQuiz:
+		return b.validateQuiz()
+	default:
+		return lessonBlockError(b, "unknown type")
+	}
+}
+
+func (b LessonBlock) validateText() error {
+	if b.Text == nil || strings.TrimSpace(b.Text.HTML) == "" {
+		return lessonBlockError(b, "text is empty")
+	}
+
+	if len(b.Text.HTML) > lessonBlockTextMaxLength {
+		return lessonBlockError(b, "text is too long")
+	}
+
+	return nil
+}
+
+func (b LessonBlock) validateFile() error {
+	if b.File == nil || b.File.FileID.IsZero() {
+		return lessonBlockError(b, "file id is empty")
+	}
+
+	if len(b.File.Caption) > lessonBlockCaptionMaxLength {
+		return lessonBlockError(b, "caption is too long")
+	}
+
+	return nil
+}
+
+func (b LessonBlock) validateCode() error {
+	if b.Code == nil || b.Code.Code == "" {
+		return lessonBlockError(b, "code is empty")
+	}
+
+	if len(b.Code.Code) > lessonBlockCodeMaxLength {
+		return lessonBlockError(b, "code is too long")
+	}
+
+	if !codeLanguageRegexp.MatchString(b.Code.Language) {
+		return lessonBlockError(b, "code language is invalid")
+	}
+
+	return nil
+}
+
+func (b LessonBlock) validateEmbed() error {
+	if b.Embed == nil || len(b.Embed.URL) > lessonBlockEmbedURLMaxLength {
+		return lessonBlockError(b, "embed url is invalid")
+	}
+
+	u, err := url.Parse(b.Embed.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return lessonBlockError(b, "embed url must be absolute https url")
+	}
+
+	return nil
+}
+
+func (b LessonBlock) validateQuiz() error {
+	if b.Quiz == nil || len(b.Quiz.Questions) == 0 || len(b.Quiz.Questions) > lessonQuizMaxQuestions {
+		return lessonBlockError(b, fmt.Sprintf("quiz must have from 1 to %d questions", lessonQuizMaxQuestions))
+	}
+
+	for _, question := range b.Quiz.Questions {
+		if strings.TrimSpace(question.Text) == "" || len(question.Text) > lessonQuizQuestionMaxLength {
+			return lessonBlockError(b, "quiz question text is invalid")
+		}
+
+		if len(question.Options) < 2 || len(question.Options) > lessonQuizMaxOptions {
+			return lessonBlockError(b, fmt.Sprintf("quiz question must have from 2 to %d options", lessonQuizMaxOptions))
+		}
+
+		correct := 0
+
+		for _, option := range question.Options {
+			if strings.TrimSpace(option.Text) == "" || len(option.Text) > lessonQuizOptionMaxLength {
+				return lessonBlockError(b, "quiz option text is invalid")
+			}
+
+			if option.Correct {
+				correct++
+			}
+		}
+
+		if correct == 0 || (!question.Multiple && correct > 1) {
+			return lessonBlockError(b, "quiz question must have one correct option or several if multiple")
+		}
+	}
+
+	return nil
+}
+
+func lessonBlockError(b LessonBlock, reason string) error {
+	return fmt.Errorf("%w: block %d (%s) %s", ErrLessonBlockInvalid, b.Position, b.Type, reason)
+}
+
+// WithoutAnswers returns copy of blocks with correct quiz options hidden, it's used for students.
+func WithoutAnswers(blocks []LessonBlock) []LessonBlock {
+	if blocks == nil {
+		return nil
+	}
+
+	res := make([]LessonBlock, len(blocks))
+
+	for i, block := range blocks {
+		res[i] = block
+
+		if block.Quiz == nil {
+			continue
+		}
+
+		quiz := QuizBlock{Questions: make([]QuizQuestion, len(block.Quiz.Questions))}
+
+		for j, question := range block.Quiz.Questions {
+			quiz.Questions[j] = question
+			quiz.Questions[j].Options = make([]QuizOption, len(question.Options))
+
+			for k, option := range question.Options {
+				option.Correct = false
+				quiz.Questions[j].Options[k] = option
+			}
+		}
+
+		res[i].Quiz = &quiz
+	}
+
+	return res
+}
+
+// GetBlocks returns content blocks, legacy string content is returned as a single text block.
+func (c LessonContent) GetBlocks() []LessonBlock {
+	if len(c.Blocks) != 0 || c.Content == "" {
+		return c.Blocks
+	}
+
+	return []LessonBlock{{
+		ID:   c.LessonID,
+		Type: LessonBlockText,
+		Text: &TextBlock{HTML: c.Content},
+	}}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Types of block changes between two lesson content versions.
+const (
+	LessonBlockAdded   = "added"
+	LessonBlockRemoved = "removed"
+	LessonBlockChanged = "changed"
+	LessonBlockMoved   = "moved"
+)
+
+// LessonContentVersion is a snapshot of lesson blocks stored on every save.
+// AdminID is empty, when content was saved with api key.
+type LessonContentVersion struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LessonID     primitive.ObjectID `json:"lessonId" bson:"lessonId"`
+	SchoolID     primitive.ObjectID `json:"schoolId" bson:"schoolId"`
+	Version      int                `json:"version" bson:"version"`
+	AdminID      primitive.ObjectID `json:"adminId,omitempty" bson:"adminId,omitempty"`
+	RestoredFrom int                `json:"restoredFrom,omitempty" bson:"restoredFrom,omitempty"`
+	Blocks       []LessonBlock      `json:"blocks,omitempty" bson:"blocks"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// LessonDraft is unpublished lesson content, it's never shown to students.
+type LessonDraft struct {
+	Version   int                `json:"version" bson:"version"`
+	AdminID   primitive.ObjectID `json:"adminId,omitempty" bson:"adminId,omitempty"`
+	Blocks    []LessonBlock      `json:"blocks" bson:"blocks"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+type LessonBlockChange struct {
+	BlockID primitive.ObjectID `json:"blockId"`
+	Type    string             `json:"type"`
+	Change  string             `json:"change"`
+	From    *LessonBlock       `json:"from,omitempty"`
+	To      *LessonBlock       `json:"to,omitempty"`
+}
+
+type LessonContentDiff struct {
+	From    int                 `json:"from"`
+	To      int                 `json:"to"`
+	Changes []LessonBlockChange `json:"changes"`
+}
+
+// DiffLessonBlocks matches blocks by id and returns blocks that were added, removed, changed or moved.
+func DiffLessonBlocks(from, to []LessonBlock) []LessonBlockChange {
+	changes := make([]LessonBlockChange, 0)
+	fromBlocks := make(map[primitive.ObjectID]LessonBlock, len(from))
+
+	for _, block := range from {
+		fromBlocks[block.ID] = block
+	}
+
+	toIds := make(map[primitive.ObjectID]bool, len(to))
+
+	for i := range to {
+		block := to[i]
+		toIds[block.ID] = true
+
+		old, ok := fromBlocks[block.ID]
+		if !ok {
+			changes = append(changes, LessonBlockChange{BlockID: block.ID, Type: block.Type, Change: LessonBlockAdded, To: &block})
+
+			continue
+		}
+
+		position := old.Position
+		old.Position = block.Position
+
+		switch {
+		case !reflect.DeepEqual(old, block):
+			old.Position = position
+			changes = append(changes, LessonBlockChange{BlockID: block.ID, Type: block.Type, Change: LessonBlockChanged, From: &old, To: &block})
+		case position != block.Position:
+			old.Position = position
+			changes = append(changes, LessonBlockChange{BlockID: block.ID, Type: block.Type, Change: LessonBlockMoved, From: &old, To: &block})
+		}
+	}
+
+	for i := range from {
+		block := from[i]
+		if !toIds[block.ID] {
+			changes = append(changes, LessonBlockChange{BlockID: block.ID, Type: block.Type, Change: LessonBlockRemoved, From: &block})
+		}
+	}
+
+	return changes
+}
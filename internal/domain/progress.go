@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CourseCompletion is recorded once, when student finishes the last published lesson of the course.
+// Course stays completed even if new lessons are published later.
+type CourseCompletion struct {
+	CourseID    primitive.ObjectID `json:"courseId" bson:"courseId"`
+	CompletedAt time.Time          `json:"completedAt" bson:"completedAt"`
+}
+
+// ModuleProgress counts published lessons of the module finished by student.
+type ModuleProgress struct {
+	ModuleID        primitive.ObjectID `json:"moduleId"`
+	Name            string             `json:"name"`
+	LessonsTotal    int                `json:"lessonsTotal"`
+	LessonsFinished int                `json:"lessonsFinished"`
+	Percent         int                `json:"percent"`
+}
+
+type CourseProgress struct {
+	CourseID        primitive.ObjectID `json:"courseId"`
+	Name            string             `json:"name"`
+	LessonsTotal    int                `json:"lessonsTotal"`
+	LessonsFinished int                `json:"lessonsFinished"`
+	Percent         int                `json:"percent"`
+	CompletedAt     *time.Time         `json:"completedAt,omitempty"`
+	Modules         []ModuleProgress   `json:"modules"`
+}
+
+// IsFinished reports whether all published lessons of the course are finished.
+func (p CourseProgress) IsFinished() bool {
+	return p.LessonsTotal > 0 && p.LessonsFinished == p.LessonsTotal
+}
+
+// CourseProgressStats aggregates progress of the course students.
+// Student is started after finishing any lesson, HalfCompleted includes completed students.
+type CourseProgressStats struct {
+	CourseID      primitive.ObjectID `json:"courseId"`
+	LessonsTotal  int                `json:"lessonsTotal"`
+	Started       int                `json:"started"`
+	HalfCompleted int                `json:"halfCompleted"`
+	Completed     int                `json:"completed"`
+}
+
+// NewCourseProgress calculates student's progress by published modules of the course.
+func NewCourseProgress(course Course, modules []Module, lessons StudentLessons) CourseProgress {
+	finished := lessons.finishedSet()
+
+	progress := CourseProgress{
+		CourseID:    course.ID,
+		Name:        course.Name,
+		CompletedAt: lessons.CompletedAt(course.ID),
+		Modules:     make([]ModuleProgress, 0, len(modules)),
+	}
+
+	for _, module := range modules {
+		moduleProgress := ModuleProgress{ModuleID: module.ID, Name: module.Name}
+
+		for _, lesson := range module.Lessons {
+			if !lesson.Published {
+				continue
+			}
+
+			moduleProgress.LessonsTotal++
+
+			if finished[lesson.ID] {
+				moduleProgress.LessonsFinished++
+			}
+		}
+
+		moduleProgress.Percent = percent(moduleProgress.LessonsFinished, moduleProgress.LessonsTotal)
+
+		progress.LessonsTotal += moduleProgress.LessonsTotal
+		progress.LessonsFinished += moduleProgress.LessonsFinished
+		progress.Modules = append(progress.Modules, moduleProgress)
+	}
+
+	progress.Percent = percent(progress.LessonsFinished, progress.LessonsTotal)
+
+	return progress
+}
+
+// CompletedAt returns time the course was completed, it's nil for not completed course.
+func (s StudentLessons) CompletedAt(courseId primitive.ObjectID) *time.Time {
+	for _, completion := range s.Completed {
+		if completion.CourseID == courseId {
+			completedAt := completion.CompletedAt
+
+			return &completedAt
+		}
+	}
+
+	return nil
+}
+
+// CountFinished returns the number of finished lessons from the given ones.
+func (s StudentLessons) CountFinished(lessonIds []primitive.ObjectID) int {
+	finished := s.finishedSet()
+
+	var count int
+
+	for _, id := range lessonIds {
+		if finished[id] {
+			count++
+		}
+	}
+
+	return count
+}
+
+// PercentFinished returns the share of the given lessons finished by student.
+func (s StudentLessons) PercentFinished(lessonIds []primitive.ObjectID) int {
+	return percent(s.CountFinished(lessonIds), len(lessonIds))
+}
+
+func (s StudentLessons) finishedSet() map[primitive.ObjectID]bool {
+	finished := make(map[primitive.ObjectID]bool, len(s.Finished))
+	for _, id := range s.Finished {
+		finished[id] = true
+	}
+
+	return finished
+}
+
+func percent(value, total int) int {
+	if total == 0 {
+		return 0
+	}
+
+	return value * 100 / total
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 type PaginationQuery struct {
 	Skip  int64 `form:"skip"`
 	Limit int64 `form:"limit"`
@@ -9,18 +11,42 @@ type SearchQuery struct {
 	Search string `form:"search"`
 }
 
+// StudentFiltersQuery is bound from query parameters and stored as filters of the saved segment.
+// Fields are custom field filters in "key:value" form, Tags must all be set on the student.
 type StudentFiltersQuery struct {
-	RegisterDateFrom  string `form:"registerDateFrom"`
-	RegisterDateTo    string `form:"registerDateTo"`
-	LastVisitDateFrom string `form:"lastVisitDateFrom"`
-	LastVisitDateTo   string `form:"lastVisitDateTo"`
-	Verified          *bool  `form:"verified"`
+	RegisterDateFrom  string   `form:"registerDateFrom" json:"registerDateFrom,omitempty" bson:"registerDateFrom,omitempty"`
+	RegisterDateTo    string   `form:"registerDateTo" json:"registerDateTo,omitempty" bson:"registerDateTo,omitempty"`
+	LastVisitDateFrom string   `form:"lastVisitDateFrom" json:"lastVisitDateFrom,omitempty" bson:"lastVisitDateFrom,omitempty"`
+	LastVisitDateTo   string   `form:"lastVisitDateTo" json:"lastVisitDateTo,omitempty" bson:"lastVisitDateTo,omitempty"`
+	Verified          *bool    `form:"verified" json:"verified,omitempty" bson:"verified,omitempty"`
+	Tags              []string `form:"tags" json:"tags,omitempty" bson:"tags,omitempty"`
+	Fields            []string `form:"fields" json:"fields,omitempty" bson:"fields,omitempty"`
+}
+
+// FieldFilters parses Fields into key-value pairs.
+func (q StudentFiltersQuery) FieldFilters() (map[string]string, error) {
+	filters := make(map[string]string, len(q.Fields))
+
+	for _, field := range q.Fields {
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 || !IsValidStudentFieldKey(parts[0]) {
+			return nil, ErrStudentFilterInvalid
+		}
+
+		filters[parts[0]] = parts[1]
+	}
+
+	return filters, nil
 }
 
+// GetStudentsQuery filters students by query parameters and, when Segment id is passed,
+// by filters of the saved segment as well. SegmentFilters is set by the service.
 type GetStudentsQuery struct {
 	PaginationQuery
 	SearchQuery
 	StudentFiltersQuery
+	Segment        string          `form:"segment"`
+	SegmentFilters *StudentSegment `form:"-"`
 }
 
 type OrdersFiltersQuery struct {
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OfferAccess keeps the time student got access to the offer, it's the start of drip content.
+type OfferAccess struct {
+	OfferID   primitive.ObjectID `json:"offerId" bson:"offerId"`
+	GrantedAt time.Time          `json:"grantedAt" bson:"grantedAt"`
+}
+
+// ContentLockedError is returned for scheduled or drip content that isn't available yet.
+type ContentLockedError struct {
+	AvailableAt time.Time
+}
+
+func (e ContentLockedError) Error() string {
+	return fmt.Sprintf("%s, it will be available at %s", ErrContentIsLocked, e.AvailableAt.Format(time.RFC3339))
+}
+
+func (e ContentLockedError) Unwrap() error {
+	return ErrContentIsLocked
+}
+
+// AccessStartedAt returns the earliest time student got access to one of the offers.
+// Free modules and offers granted before access was tracked start at registration.
+func (s Student) AccessStartedAt(offers []Offer) time.Time {
+	var startedAt time.Time
+
+	for _, access := range s.OffersAccess {
+		for _, offer := range offers {
+			if offer.ID == access.OfferID && (startedAt.IsZero() || access.GrantedAt.Before(startedAt)) {
+				startedAt = access.GrantedAt
+			}
+		}
+	}
+
+	if startedAt.IsZero() {
+		return s.RegisteredAt
+	}
+
+	return startedAt
+}
+
+// IsPublishedOrScheduled reports whether module is shown to students, scheduled module is locked till PublishAt.
+func (m Module) IsPublishedOrScheduled() bool {
+	return m.Published || m.PublishAt != nil
+}
+
+// IsPublishedOrScheduled reports whether lesson is shown to students, scheduled lesson is locked till PublishAt.
+func (l Lesson) IsPublishedOrScheduled() bool {
+	return l.Published || l.PublishAt != nil
+}
+
+// ScheduledAt returns time the lesson is published by schedule, lesson is never published before its module.
+// It's nil for published lesson of published module.
+func (l Lesson) ScheduledAt(module Module) *time.Time {
+	if l.PublishAt == nil || (module.PublishAt != nil && module.PublishAt.After(*l.PublishAt)) {
+		return module.PublishAt
+	}
+
+	return l.PublishAt
+}
+
+// HasDrip reports whether module or any of its lessons unlock relative to student access.
+func (m Module) HasDrip() bool {
+	if m.DripDays > 0 {
+		return true
+	}
+
+	for _, lesson := range m.Lessons {
+		if lesson.DripDays > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnlocksAt returns time since module is available for student, zero time means it's available right away.
+func (m Module) UnlocksAt(accessStartedAt time.Time) time.Time {
+	return contentAvailableAt(m.PublishAt, m.DripDays, accessStartedAt)
+}
+
+// UnlocksAt returns time since lesson is available for student, lesson is never available before its module.
+func (l Lesson) UnlocksAt(moduleAvailableAt, accessStartedAt time.Time) time.Time {
+	availableAt := contentAvailableAt(l.PublishAt, l.DripDays, accessStartedAt)
+	if moduleAvailableAt.After(availableAt) {
+		return moduleAvailableAt
+	}
+
+	return availableAt
+}
+
+func contentAvailableAt(publishAt *time.Time, dripDays uint, accessStartedAt time.Time) time.Time {
+	var availableAt time.Time
+	if publishAt != nil {
+		availableAt = *publishAt
+	}
+
+	if dripDays > 0 {
+		if dripAt := accessStartedAt.AddDate(0, 0, int(dripDays)); dripAt.After(availableAt) {
+			availableAt = dripAt
+		}
+	}
+
+	return availableAt
+}
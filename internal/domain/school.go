@@ -21,16 +21,19 @@ type School struct {
 }
 
 type Settings struct {
-	Color               string      `json:"color" bson:"color,omitempty"`
-	Domains             []string    `json:"domains" bson:"domains,omitempty"`
-	ContactInfo         ContactInfo `json:"contactInfo" bson:"contactInfo,omitempty"`
-	Pages               Pages       `json:"pages" bson:"pages,omitempty"`
-	ShowPaymentImages   bool        `json:"showPaymentImages" bson:"showPaymentImages,omitempty"`
-	Logo                string      `json:"logo" bson:"logo,omitempty"`
-	GoogleAnalyticsCode string      `json:"googleAnalyticsCode" bson:"googleAnalyticsCode,omitempty"`
-	Fondy               Fondy       `json:"fondy" bson:"fondy,omitempty"`
-	SendPulse           SendPulse   `json:"sendpulse" bson:"sendpulse,omitempty"`
-	DisableRegistration bool        `json:"disableRegistration" bson:"disableRegistration,omitempty"`
+	Color               string         `json:"color" bson:"color,omitempty"`
+	Domains             []string       `json:"domains" bson:"domains,omitempty"`
+	ContactInfo         ContactInfo    `json:"contactInfo" bson:"contactInfo,omitempty"`
+	Pages               Pages          `json:"pages" bson:"pages,omitempty"`
+	ShowPaymentImages   bool           `json:"showPaymentImages" bson:"showPaymentImages,omitempty"`
+	Logo                string         `json:"logo" bson:"logo,omitempty"`
+	GoogleAnalyticsCode string         `json:"googleAnalyticsCode" bson:"googleAnalyticsCode,omitempty"`
+	Fondy               Fondy          `json:"fondy" bson:"fondy,omitempty"`
+	SendPulse           SendPulse      `json:"sendpulse" bson:"sendpulse,omitempty"`
+	DisableRegistration bool           `json:"disableRegistration" bson:"disableRegistration,omitempty"`
+	MagicLinkLogin      bool           `json:"magicLinkLogin" bson:"magicLinkLogin,omitempty"`
+	OIDCProviders       []OIDCProvider `json:"oidcProviders" bson:"oidcProviders,omitempty"`
+	StudentFields       []StudentField `json:"studentFields" bson:"studentFields,omitempty"`
 }
 
 func (s Settings) GetDomain() string {
@@ -43,6 +46,28 @@ type Fondy struct {
 	Connected        bool   `json:"connected" bson:"connected"`
 }
 
+// OIDCProvider is an OpenID Connect provider students can sign in with ("Sign in with Google").
+// Endpoints are discovered by Issuer, explicitly set endpoints take precedence over discovered ones.
+type OIDCProvider struct {
+	Name         string `json:"name" bson:"name"`
+	Issuer       string `json:"issuer" bson:"issuer,omitempty"`
+	ClientID     string `json:"clientId" bson:"clientId"`
+	ClientSecret string `json:"-" bson:"clientSecret"`
+	AuthURL      string `json:"authUrl,omitempty" bson:"authUrl,omitempty"`
+	TokenURL     string `json:"tokenUrl,omitempty" bson:"tokenUrl,omitempty"`
+	UserInfoURL  string `json:"userInfoUrl,omitempty" bson:"userInfoUrl,omitempty"`
+}
+
+func (s Settings) GetOIDCProvider(name string) (OIDCProvider, error) {
+	for _, provider := range s.OIDCProviders {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+
+	return OIDCProvider{}, ErrOIDCProviderNotFound
+}
+
 type SendPulse struct {
 	ID        string `json:"id" bson:"id"`
 	Secret    string `json:"secret" bson:"secret"`
@@ -64,12 +89,57 @@ type Pages struct {
 	NewsletterConsent string `json:"newsletterConsent" bson:"newsletterConsent,omitempty"`
 }
 
+// Admin roles, role defines which parts of the school admin panel are available.
+const (
+	AdminRoleOwner   = "owner"
+	AdminRoleEditor  = "editor"
+	AdminRoleSupport = "support"
+	AdminRoleFinance = "finance"
+)
+
 type Admin struct {
-	ID       primitive.ObjectID `json:"id" bson:"_id"`
-	Name     string             `json:"name" bson:"name"`
-	Email    string             `json:"email" bson:"email"`
-	Password string             `json:"password" bson:"password"`
-	SchoolID primitive.ObjectID
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string             `json:"name" bson:"name"`
+	Email     string             `json:"email" bson:"email"`
+	Password  string             `json:"password" bson:"password"`
+	SchoolID  primitive.ObjectID `json:"schoolId" bson:"schoolId"`
+	UserID    primitive.ObjectID `json:"-" bson:"userId,omitempty"`
+	Role      string             `json:"role" bson:"role,omitempty"`
+	Pending   bool               `json:"pending" bson:"pending,omitempty"`
+	TwoFactor TwoFactor          `json:"-" bson:"twoFactor,omitempty"`
+}
+
+// GetRole returns admin's role. Admins created before roles were introduced are school owners.
+func (a Admin) GetRole() string {
+	if a.Role == "" {
+		return AdminRoleOwner
+	}
+
+	return a.Role
+}
+
+// IsActiveOwner reports whether admin is a school owner, who has accepted the invitation.
+func (a Admin) IsActiveOwner() bool {
+	return !a.Pending && a.GetRole() == AdminRoleOwner
+}
+
+func IsValidAdminRole(role string) bool {
+	switch role {
+	case AdminRoleOwner, AdminRoleEditor, AdminRoleSupport, AdminRoleFinance:
+		return true
+	default:
+		return false
+	}
+}
+
+// TwoFactor holds admin's TOTP settings. PendingSecret is set on enrolment and becomes Secret
+// once admin confirms it with a valid code. Recovery codes are stored as SHA256 hashes.
+type TwoFactor struct {
+	Enabled       bool     `bson:"enabled"`
+	Secret        string   `bson:"secret,omitempty"`
+	PendingSecret string   `bson:"pendingSecret,omitempty"`
+	RecoveryCodes []string `bson:"recoveryCodes,omitempty"`
+	LastUsedStep  int64    `bson:"lastUsedStep,omitempty"`
 }
 
 type UpdateSchoolSettingsInput struct {
@@ -81,6 +151,7 @@ type UpdateSchoolSettingsInput struct {
 	Pages               *UpdateSchoolSettingsPages
 	ShowPaymentImages   *bool
 	DisableRegistration *bool
+	MagicLinkLogin      *bool
 	GoogleAnalyticsCode *string
 	LogoURL             *string
 }
@@ -1,8 +1,32 @@
 package domain
 
-import "time"
+import (
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Roles are embedded into access tokens, so a token issued for one API can't be used with another.
+const (
+	RoleStudent = "student"
+	RoleAdmin   = "admin"
+	RoleUser    = "user"
+)
+
+// Session is created for every sign in, so each device has its own refresh token.
+// Refresh token is rotated on every use, previously issued tokens are kept in UsedTokens
+// to detect reuse of a stolen token, in which case the whole session is revoked.
+// Only SHA256 hashes of refresh tokens are stored.
 type Session struct {
-	RefreshToken string    `json:"refreshToken" bson:"refreshToken"`
-	ExpiresAt    time.Time `json:"expiresAt" bson:"expiresAt"`
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OwnerID      primitive.ObjectID `json:"-" bson:"ownerId"`
+	Role         string             `json:"-" bson:"role"`
+	SchoolID     primitive.ObjectID `json:"-" bson:"schoolId,omitempty"`
+	RefreshToken string             `json:"-" bson:"refreshToken"`
+	UsedTokens   []string           `json:"-" bson:"usedTokens,omitempty"`
+	UserAgent    string             `json:"userAgent" bson:"userAgent"`
+	IP           string             `json:"ip" bson:"ip"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
+	LastUsedAt   time.Time          `json:"lastUsedAt" bson:"lastUsedAt"`
+	ExpiresAt    time.Time          `json:"expiresAt" bson:"expiresAt"`
 }
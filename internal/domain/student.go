@@ -17,9 +17,18 @@ type Student struct {
 	AvailableModules []primitive.ObjectID `json:"availableModules" bson:"availableModules,omitempty"`
 	AvailableCourses []primitive.ObjectID `json:"availableCourses" bson:"availableCourses,omitempty"`
 	AvailableOffers  []primitive.ObjectID `json:"availableOffers" bson:"availableOffers,omitempty"`
+	OffersAccess     []OfferAccess        `json:"-" bson:"offersAccess,omitempty"`
 	Verification     Verification         `json:"verification" bson:"verification"`
-	Session          Session              `json:"session" bson:"session,omitempty"`
 	Blocked          bool                 `json:"blocked" bson:"blocked"`
+	Identities       []StudentIdentity    `json:"-" bson:"identities,omitempty"`
+	Tags             []string             `json:"tags" bson:"tags,omitempty"`
+	Fields           map[string]string    `json:"fields" bson:"fields,omitempty"`
+}
+
+// StudentIdentity links student to the account of OpenID Connect provider.
+type StudentIdentity struct {
+	Provider string `bson:"provider"`
+	Subject  string `bson:"subject"`
 }
 
 func (s Student) IsModuleAvailable(m Module) bool {
@@ -33,16 +42,22 @@ func (s Student) IsModuleAvailable(m Module) bool {
 }
 
 type Verification struct {
-	Code     string `json:"code" bson:"code"`
-	Verified bool   `json:"verified" bson:"verified"`
+	Code      string    `json:"code" bson:"code"`
+	Verified  bool      `json:"verified" bson:"verified"`
+	ExpiresAt time.Time `json:"-" bson:"expiresAt,omitempty"`
+	SentAt    time.Time `json:"-" bson:"sentAt,omitempty"`
 }
 
 type StudentLessons struct {
 	StudentID  primitive.ObjectID   `json:"studentId" bson:"studentId"`
 	Finished   []primitive.ObjectID `json:"finished" bson:"finished"`
 	LastOpened primitive.ObjectID   `json:"lastOpened" bson:"lastOpened"`
+	Completed  []CourseCompletion   `json:"completed" bson:"completed,omitempty"`
 }
 
+// DeletedStudentName replaces name in orders and survey results of the deleted student, email is erased.
+const DeletedStudentName = "Deleted student"
+
 type StudentInfoShort struct {
 	ID    primitive.ObjectID `json:"id" bson:"id"`
 	Name  string             `json:"name" bson:"name"`
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Types of student activity, the log is append-only and removed only with the student account.
+const (
+	StudentActivitySignIn          = "sign_in"
+	StudentActivityLessonOpened    = "lesson_opened"
+	StudentActivityLessonFinished  = "lesson_finished"
+	StudentActivitySurveySubmitted = "survey_submitted"
+	StudentActivityCourseCompleted = "course_completed"
+	StudentActivityOrderCreated    = "order_created"
+	StudentActivityOrderPaid       = "order_paid"
+	StudentActivityAccessGranted   = "access_granted"
+	StudentActivityAccessRevoked   = "access_revoked"
+)
+
+// Sign-in methods recorded with StudentActivitySignIn.
+const (
+	SignInMethodPassword  = "password"
+	SignInMethodOIDC      = "oidc"
+	SignInMethodMagicLink = "magic_link"
+)
+
+// StudentActivity is a single event of the student, related entities are set depending on the Type.
+// AdminID is set when action was made by the admin.
+type StudentActivity struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SchoolID  primitive.ObjectID `json:"schoolId" bson:"schoolId"`
+	StudentID primitive.ObjectID `json:"studentId" bson:"studentId"`
+	Type      string             `json:"type" bson:"type"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	CourseID  primitive.ObjectID `json:"courseId,omitempty" bson:"courseId,omitempty"`
+	ModuleID  primitive.ObjectID `json:"moduleId,omitempty" bson:"moduleId,omitempty"`
+	LessonID  primitive.ObjectID `json:"lessonId,omitempty" bson:"lessonId,omitempty"`
+	OfferID   primitive.ObjectID `json:"offerId,omitempty" bson:"offerId,omitempty"`
+	OrderID   primitive.ObjectID `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	AdminID   primitive.ObjectID `json:"adminId,omitempty" bson:"adminId,omitempty"`
+	Method    string             `json:"method,omitempty" bson:"method,omitempty"`
+	IP        string             `json:"ip,omitempty" bson:"ip,omitempty"`
+	UserAgent string             `json:"userAgent,omitempty" bson:"userAgent,omitempty"`
+}
+
+type GetStudentActivityQuery struct {
+	PaginationQuery
+	Types []string `form:"types"`
+}
+
+func IsValidStudentActivityType(activityType string) bool {
+	switch activityType {
+	case StudentActivitySignIn, StudentActivityLessonOpened, StudentActivityLessonFinished, StudentActivitySurveySubmitted,
+		StudentActivityCourseCompleted, StudentActivityOrderCreated, StudentActivityOrderPaid, StudentActivityAccessGranted, StudentActivityAccessRevoked:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of custom student fields, values are stored as strings and validated by type.
+const (
+	StudentFieldText   = "text"
+	StudentFieldNumber = "number"
+	StudentFieldDate   = "date"
+
+	StudentFieldDateLayout = "2006-01-02"
+
+	studentFieldMaxLength = 256
+	studentTagMaxLength   = 32
+)
+
+var (
+	studentFieldKeyRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+	studentTagRegexp      = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_.\-]*$`)
+)
+
+// StudentField is a custom field defined by the school, e.g. phone or company.
+// Values are kept in Student.Fields by Key.
+type StudentField struct {
+	Key  string `json:"key" bson:"key"`
+	Name string `json:"name" bson:"name"`
+	Type string `json:"type" bson:"type"`
+}
+
+func (f StudentField) IsValid() bool {
+	if !studentFieldKeyRegexp.MatchString(f.Key) || f.Name == "" {
+		return false
+	}
+
+	switch f.Type {
+	case StudentFieldText, StudentFieldNumber, StudentFieldDate:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f StudentField) IsValidValue(value string) bool {
+	switch f.Type {
+	case StudentFieldNumber:
+		_, err := strconv.ParseFloat(value, 64)
+
+		return err == nil
+	case StudentFieldDate:
+		_, err := time.Parse(StudentFieldDateLayout, value)
+
+		return err == nil
+	default:
+		return len(value) <= studentFieldMaxLength
+	}
+}
+
+func (s Settings) GetStudentField(key string) (StudentField, bool) {
+	for _, field := range s.StudentFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return StudentField{}, false
+}
+
+func IsValidStudentFieldKey(key string) bool {
+	return studentFieldKeyRegexp.MatchString(key)
+}
+
+// NormalizeStudentTag trims and lowercases tag, so "VIP " and "vip" are the same tag.
+func NormalizeStudentTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	if len([]rune(tag)) > studentTagMaxLength || !studentTagRegexp.MatchString(tag) {
+		return "", ErrStudentTagInvalid
+	}
+
+	return tag, nil
+}
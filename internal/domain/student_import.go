@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	StudentImportRunning  = "running"
+	StudentImportFinished = "finished"
+	StudentImportFailed   = "failed"
+
+	// emails sent to imported students
+	StudentImportEmailsNone         = ""
+	StudentImportEmailsWelcome      = "welcome"
+	StudentImportEmailsVerification = "verification"
+
+	StudentImportRowCreated = "created"
+	StudentImportRowValid   = "valid" // row passed validation in dry-run mode
+	StudentImportRowFailed  = "failed"
+)
+
+// StudentImport is a background job, which creates students from CSV file.
+// In dry-run mode rows are only validated.
+type StudentImport struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SchoolID   primitive.ObjectID `json:"-" bson:"schoolId"`
+	CreatedBy  primitive.ObjectID `json:"createdBy" bson:"createdBy"`
+	DryRun     bool               `json:"dryRun" bson:"dryRun"`
+	Emails     string             `json:"emails" bson:"emails,omitempty"`
+	Status     string             `json:"status" bson:"status"`
+	Total      int                `json:"total" bson:"total"`
+	Processed  int                `json:"processed" bson:"processed"`
+	Succeeded  int                `json:"succeeded" bson:"succeeded"`
+	Failed     int                `json:"failed" bson:"failed"`
+	Error      string             `json:"error,omitempty" bson:"error,omitempty"`
+	Rows       []StudentImportRow `json:"rows" bson:"rows,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	FinishedAt time.Time          `json:"finishedAt" bson:"finishedAt,omitempty"`
+}
+
+// StudentImportRow is a result of the CSV row, Line starts with 1 for the header.
+type StudentImportRow struct {
+	Line      int                `json:"line" bson:"line"`
+	Email     string             `json:"email" bson:"email"`
+	Status    string             `json:"status" bson:"status"`
+	Error     string             `json:"error,omitempty" bson:"error,omitempty"`
+	StudentID primitive.ObjectID `json:"studentId,omitempty" bson:"studentId,omitempty"`
+}
+
+func IsValidStudentImportEmails(emails string) bool {
+	switch emails {
+	case StudentImportEmailsNone, StudentImportEmailsWelcome, StudentImportEmailsVerification:
+		return true
+	default:
+		return false
+	}
+}
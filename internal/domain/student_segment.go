@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StudentSegment is a saved set of student filters, it's applied to students list and export by id.
+type StudentSegment struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	SchoolID  primitive.ObjectID  `json:"-" bson:"schoolId"`
+	Name      string              `json:"name" bson:"name"`
+	Search    string              `json:"search" bson:"search,omitempty"`
+	Filters   StudentFiltersQuery `json:"filters" bson:"filters"`
+	CreatedAt time.Time           `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt" bson:"updatedAt,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// One-time token purposes, token issued for one purpose can't be used for another.
+const (
+	TokenPurposePasswordReset      = "passwordReset"
+	TokenPurposeTwoFactorChallenge = "twoFactorChallenge"
+	TokenPurposeAdminInvitation    = "adminInvitation"
+	TokenPurposeOIDCState          = "oidcState"
+	TokenPurposeMagicLink          = "magicLink"
+	TokenPurposeEmailChange        = "emailChange"
+	TokenPurposeReauthentication   = "reauthentication"
+)
+
+// OneTimeToken is a short-lived single-use token, which is sent to the account owner by email.
+// Only SHA256 hash of the token is stored.
+type OneTimeToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Purpose   string             `bson:"purpose"`
+	Hash      string             `bson:"hash"`
+	OwnerID   primitive.ObjectID `bson:"ownerId"`
+	Role      string             `bson:"role"`
+	SchoolID  primitive.ObjectID `bson:"schoolId,omitempty"`
+	Email     string             `bson:"email,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"time"
+	"errors"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,33 +18,199 @@ func NewAdminsRepo(db *mongo.Database) *AdminsRepo {
 	return &AdminsRepo{db: db.Collection(adminsCollection)}
 }
 
-func (r *AdminsRepo) GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Admin, error) {
+func (r *AdminsRepo) Create(ctx context.Context, admin *domain.Admin) error {
+	res, err := r.db.InsertOne(ctx, admin)
+	if err != nil {
+		return err
+	}
+
+	admin.ID = res.InsertedID.(primitive.ObjectID) //nolint:forcetypeassert
+
+	return nil
+}
+
+func (r *AdminsRepo) GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Admin, error) {
 	var admin domain.Admin
-	err := r.db.FindOne(ctx, bson.M{"schoolId": schoolId, "email": email, "password": password}).Decode(&admin)
+	if err := r.db.FindOne(ctx, bson.M{"schoolId": schoolId, "email": email}).Decode(&admin); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Admin{}, domain.ErrUserNotFound
+		}
 
-	return admin, err
+		return domain.Admin{}, err
+	}
+
+	return admin, nil
 }
 
-func (r *AdminsRepo) GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Admin, error) {
+func (r *AdminsRepo) GetByUser(ctx context.Context, schoolId, userId primitive.ObjectID) (domain.Admin, error) {
 	var admin domain.Admin
-	err := r.db.FindOne(ctx, bson.M{
-		"session.refreshToken": refreshToken, "schoolId": schoolId,
-		"session.expiresAt": bson.M{"$gt": time.Now()},
-	}).Decode(&admin)
+	if err := r.db.FindOne(ctx, bson.M{"schoolId": schoolId, "userId": userId}).Decode(&admin); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Admin{}, domain.ErrUserNotFound
+		}
+
+		return domain.Admin{}, err
+	}
+
+	return admin, nil
+}
+
+func (r *AdminsRepo) LinkUser(ctx context.Context, id, userId primitive.ObjectID) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"userId": userId}})
 
-	return admin, err
+	return err
 }
 
-func (r *AdminsRepo) SetSession(ctx context.Context, id primitive.ObjectID, session domain.Session) error {
-	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"session": session}})
+func (r *AdminsRepo) SetPassword(ctx context.Context, id primitive.ObjectID, password string) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"password": password}})
 
 	return err
 }
 
 func (r *AdminsRepo) GetById(ctx context.Context, id primitive.ObjectID) (domain.Admin, error) {
 	var admin domain.Admin
+	if err := r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&admin); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Admin{}, domain.ErrUserNotFound
+		}
+
+		return domain.Admin{}, err
+	}
+
+	return admin, nil
+}
+
+func (r *AdminsRepo) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error) {
+	var admins []domain.Admin
+
+	cur, err := r.db.Find(ctx, bson.M{"schoolId": schoolId})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &admins)
+
+	return admins, err
+}
+
+// CountOwners counts active school owners, admins without role are owners as well.
+func (r *AdminsRepo) CountOwners(ctx context.Context, schoolId primitive.ObjectID) (int64, error) {
+	return r.db.CountDocuments(ctx, bson.M{
+		"schoolId": schoolId,
+		"role":     bson.M{"$in": bson.A{domain.AdminRoleOwner, nil}},
+		"pending":  bson.M{"$ne": true},
+	})
+}
+
+func (r *AdminsRepo) SetRole(ctx context.Context, schoolId, id primitive.ObjectID, role string) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": id, "schoolId": schoolId}, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *AdminsRepo) UpdateInvitation(ctx context.Context, inp UpdateAdminInvitationInput) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": inp.ID, "schoolId": inp.SchoolID, "pending": true},
+		bson.M{"$set": bson.M{"name": inp.Name, "role": inp.Role}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *AdminsRepo) AcceptInvitation(ctx context.Context, inp AcceptAdminInvitationInput) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": inp.ID, "schoolId": inp.SchoolID, "pending": true}, bson.M{
+		"$set":   bson.M{"name": inp.Name, "password": inp.Password},
+		"$unset": bson.M{"pending": ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *AdminsRepo) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "schoolId": schoolId})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *AdminsRepo) SetTwoFactorPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id, "twoFactor.enabled": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"twoFactor.pendingSecret": secret}})
+
+	return err
+}
+
+func (r *AdminsRepo) EnableTwoFactor(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodes []string) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"twoFactor": domain.TwoFactor{
+		Enabled:       true,
+		Secret:        secret,
+		RecoveryCodes: recoveryCodes,
+	}}})
+
+	return err
+}
+
+func (r *AdminsRepo) DisableTwoFactor(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"twoFactor": ""}})
+
+	return err
+}
+
+// SetTwoFactorLastUsedStep fails with domain.ErrTwoFactorCodeInvalid if code from the same (or later) step was already used.
+func (r *AdminsRepo) SetTwoFactorLastUsedStep(ctx context.Context, id primitive.ObjectID, step int64) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"twoFactor.lastUsedStep": bson.M{"$lt": step}},
+			{"twoFactor.lastUsedStep": bson.M{"$exists": false}},
+		},
+	}, bson.M{"$set": bson.M{"twoFactor.lastUsedStep": step}})
+	if err != nil {
+		return err
+	}
+
+	if res.ModifiedCount == 0 {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+func (r *AdminsRepo) UseTwoFactorRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": id, "twoFactor.recoveryCodes": codeHash},
+		bson.M{"$pull": bson.M{"twoFactor.recoveryCodes": codeHash}})
+	if err != nil {
+		return err
+	}
 
-	err := r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&admin)
+	if res.ModifiedCount == 0 {
+		return domain.ErrTwoFactorCodeInvalid
+	}
 
-	return admin, err
+	return nil
 }
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type APIKeysRepo struct {
+	db *mongo.Collection
+}
+
+func NewAPIKeysRepo(db *mongo.Database) *APIKeysRepo {
+	return &APIKeysRepo{db: db.Collection(apiKeysCollection)}
+}
+
+func (r *APIKeysRepo) Create(ctx context.Context, key *domain.APIKey) error {
+	res, err := r.db.InsertOne(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	key.ID = res.InsertedID.(primitive.ObjectID) //nolint:forcetypeassert
+
+	return nil
+}
+
+func (r *APIKeysRepo) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+
+	cur, err := r.db.Find(ctx, bson.M{"schoolId": schoolId})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &keys)
+
+	return keys, err
+}
+
+func (r *APIKeysRepo) GetByHash(ctx context.Context, schoolId primitive.ObjectID, hash string) (domain.APIKey, error) {
+	var key domain.APIKey
+	if err := r.db.FindOne(ctx, bson.M{"schoolId": schoolId, "hash": hash}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.APIKey{}, domain.ErrAPIKeyInvalid
+		}
+
+		return domain.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeysRepo) SetLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastUsedAt": lastUsedAt}})
+
+	return err
+}
+
+func (r *APIKeysRepo) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "schoolId": schoolId})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
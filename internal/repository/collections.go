@@ -1,17 +1,24 @@
 package repository
 
 const (
-	adminsCollection         = "admins"
-	studentsCollection       = "students"
-	studentLessonsCollection = "studentLessons"
-	schoolsCollection        = "schools"
-	promocodesCollection     = "promocodes"
-	offersCollection         = "offers"
-	packagesCollection       = "packages"
-	modulesCollection        = "modules"
-	contentCollection        = "content"
-	ordersCollection         = "orders"
-	usersCollection          = "users"
-	filesCollection          = "files"
-	surveyResultsCollection  = "surveyResults"
+	adminsCollection            = "admins"
+	studentsCollection          = "students"
+	studentLessonsCollection    = "studentLessons"
+	schoolsCollection           = "schools"
+	promocodesCollection        = "promocodes"
+	offersCollection            = "offers"
+	packagesCollection          = "packages"
+	modulesCollection           = "modules"
+	contentCollection           = "content"
+	ordersCollection            = "orders"
+	usersCollection             = "users"
+	filesCollection             = "files"
+	surveyResultsCollection     = "surveyResults"
+	sessionsCollection          = "sessions"
+	oneTimeTokensCollection     = "oneTimeTokens"
+	apiKeysCollection           = "apiKeys"
+	studentImportsCollection    = "studentImports"
+	studentSegmentsCollection   = "studentSegments"
+	studentActivitiesCollection = "studentActivities"
+	lessonVersionsCollection    = "lessonVersions"
 )
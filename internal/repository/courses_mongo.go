@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type CoursesRepo struct {
@@ -25,6 +27,21 @@ func (r *CoursesRepo) Create(ctx context.Context, schoolId primitive.ObjectID, c
 	return course.ID, err
 }
 
+func (r *CoursesRepo) GetById(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.Course, error) {
+	var school domain.School
+
+	opts := options.FindOne().SetProjection(bson.M{"courses.$": 1})
+	if err := r.db.FindOne(ctx, bson.M{"_id": schoolId, "courses._id": courseId}, opts).Decode(&school); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Course{}, domain.ErrCourseNotFound
+		}
+
+		return domain.Course{}, err
+	}
+
+	return school.Courses[0], nil
+}
+
 func (r *CoursesRepo) Update(ctx context.Context, inp UpdateCourseInput) error {
 	updateQuery := bson.M{}
 
@@ -50,6 +67,10 @@ func (r *CoursesRepo) Update(ctx context.Context, inp UpdateCourseInput) error {
 		updateQuery["courses.$.published"] = *inp.Published
 	}
 
+	if inp.Gating != nil {
+		updateQuery["courses.$.gating"] = *inp.Gating
+	}
+
 	_, err := r.db.UpdateOne(ctx,
 		bson.M{"_id": inp.SchoolID, "courses._id": inp.ID}, bson.M{"$set": updateQuery})
 
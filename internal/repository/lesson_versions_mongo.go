@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LessonContentVersionsRepo struct {
+	db *mongo.Collection
+}
+
+func NewLessonContentVersionsRepo(db *mongo.Database) *LessonContentVersionsRepo {
+	return &LessonContentVersionsRepo{db: db.Collection(lessonVersionsCollection)}
+}
+
+func (r *LessonContentVersionsRepo) Create(ctx context.Context, version domain.LessonContentVersion) error {
+	_, err := r.db.InsertOne(ctx, version)
+
+	return err
+}
+
+// GetByLesson returns versions from the newest one, without blocks.
+func (r *LessonContentVersionsRepo) GetByLesson(ctx context.Context, schoolId, lessonId primitive.ObjectID,
+	query *domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error) {
+	opts := getPaginationOpts(query)
+	opts.SetSort(bson.M{"version": -1})
+	opts.SetProjection(bson.M{"blocks": 0})
+
+	filter := bson.M{"schoolId": schoolId, "lessonId": lessonId}
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var versions []domain.LessonContentVersion
+	if err := cur.All(ctx, &versions); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := r.db.CountDocuments(ctx, filter)
+
+	return versions, count, err
+}
+
+func (r *LessonContentVersionsRepo) GetByVersion(ctx context.Context, schoolId, lessonId primitive.ObjectID,
+	version int) (domain.LessonContentVersion, error) {
+	var res domain.LessonContentVersion
+	if err := r.db.FindOne(ctx, bson.M{"schoolId": schoolId, "lessonId": lessonId, "version": version}).Decode(&res); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.LessonContentVersion{}, domain.ErrLessonVersionNotFound
+		}
+
+		return domain.LessonContentVersion{}, err
+	}
+
+	return res, nil
+}
+
+func (r *LessonContentVersionsRepo) DeleteByLessons(ctx context.Context, schoolId primitive.ObjectID, lessonIds []primitive.ObjectID) error {
+	_, err := r.db.DeleteMany(ctx, bson.M{"schoolId": schoolId, "lessonId": bson.M{"$in": lessonIds}})
+
+	return err
+}
@@ -40,11 +40,32 @@ func (r *LessonContentRepo) GetByLesson(ctx context.Context, lessonId primitive.
 	return content, err
 }
 
-func (r *LessonContentRepo) Update(ctx context.Context, schoolId, lessonId primitive.ObjectID, content string) error {
+// NextVersion increments lesson content version counter and returns the content with the new LastVersion.
+func (r *LessonContentRepo) NextVersion(ctx context.Context, schoolId, lessonId primitive.ObjectID) (domain.LessonContent, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var content domain.LessonContent
+	err := r.db.FindOneAndUpdate(ctx, bson.M{"lessonId": lessonId, "schoolId": schoolId},
+		bson.M{"$inc": bson.M{"lastVersion": 1}}, opts).Decode(&content)
+
+	return content, err
+}
+
+func (r *LessonContentRepo) Publish(ctx context.Context, schoolId, lessonId primitive.ObjectID, version int, blocks []domain.LessonBlock) error {
+	opts := &options.UpdateOptions{}
+	opts.SetUpsert(true)
+
+	_, err := r.db.UpdateOne(ctx, bson.M{"lessonId": lessonId, "schoolId": schoolId},
+		bson.M{"$set": bson.M{"blocks": blocks, "version": version}, "$unset": bson.M{"content": "", "draft": ""}}, opts)
+
+	return err
+}
+
+func (r *LessonContentRepo) SaveDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID, draft domain.LessonDraft) error {
 	opts := &options.UpdateOptions{}
 	opts.SetUpsert(true)
 
-	_, err := r.db.UpdateOne(ctx, bson.M{"lessonId": lessonId, "schoolId": schoolId}, bson.M{"$set": bson.M{"content": content}}, opts)
+	_, err := r.db.UpdateOne(ctx, bson.M{"lessonId": lessonId, "schoolId": schoolId}, bson.M{"$set": bson.M{"draft": draft}}, opts)
 
 	return err
 }
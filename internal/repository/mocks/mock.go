@@ -7,6 +7,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/zhashkevych/creatly-backend/internal/domain"
@@ -65,48 +66,76 @@ func (mr *MockUsersMockRecorder) Create(ctx, user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUsers)(nil).Create), ctx, user)
 }
 
-// GetByCredentials mocks base method.
-func (m *MockUsers) GetByCredentials(ctx context.Context, email, password string) (domain.User, error) {
+// GetByEmail mocks base method.
+func (m *MockUsers) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCredentials", ctx, email, password)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
 	ret0, _ := ret[0].(domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCredentials indicates an expected call of GetByCredentials.
-func (mr *MockUsersMockRecorder) GetByCredentials(ctx, email, password interface{}) *gomock.Call {
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUsersMockRecorder) GetByEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentials", reflect.TypeOf((*MockUsers)(nil).GetByCredentials), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUsers)(nil).GetByEmail), ctx, email)
 }
 
-// GetByRefreshToken mocks base method.
-func (m *MockUsers) GetByRefreshToken(ctx context.Context, refreshToken string) (domain.User, error) {
+// GetById mocks base method.
+func (m *MockUsers) GetById(ctx context.Context, id primitive.ObjectID) (domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByRefreshToken", ctx, refreshToken)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByRefreshToken indicates an expected call of GetByRefreshToken.
-func (mr *MockUsersMockRecorder) GetByRefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+// GetById indicates an expected call of GetById.
+func (mr *MockUsersMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUsers)(nil).GetById), ctx, id)
+}
+
+// SetLastVisit mocks base method.
+func (m *MockUsers) SetLastVisit(ctx context.Context, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLastVisit", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLastVisit indicates an expected call of SetLastVisit.
+func (mr *MockUsersMockRecorder) SetLastVisit(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastVisit", reflect.TypeOf((*MockUsers)(nil).SetLastVisit), ctx, userID)
+}
+
+// SetPassword mocks base method.
+func (m *MockUsers) SetPassword(ctx context.Context, userID primitive.ObjectID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockUsersMockRecorder) SetPassword(ctx, userID, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRefreshToken", reflect.TypeOf((*MockUsers)(nil).GetByRefreshToken), ctx, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUsers)(nil).SetPassword), ctx, userID, password)
 }
 
-// SetSession mocks base method.
-func (m *MockUsers) SetSession(ctx context.Context, userID primitive.ObjectID, session domain.Session) error {
+// SetVerificationCode mocks base method.
+func (m *MockUsers) SetVerificationCode(ctx context.Context, userID primitive.ObjectID, inp repository.SetVerificationCodeInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetSession", ctx, userID, session)
+	ret := m.ctrl.Call(m, "SetVerificationCode", ctx, userID, inp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetSession indicates an expected call of SetSession.
-func (mr *MockUsersMockRecorder) SetSession(ctx, userID, session interface{}) *gomock.Call {
+// SetVerificationCode indicates an expected call of SetVerificationCode.
+func (mr *MockUsersMockRecorder) SetVerificationCode(ctx, userID, inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSession", reflect.TypeOf((*MockUsers)(nil).SetSession), ctx, userID, session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerificationCode", reflect.TypeOf((*MockUsers)(nil).SetVerificationCode), ctx, userID, inp)
 }
 
 // Verify mocks base method.
@@ -191,6 +220,21 @@ func (mr *MockSchoolsMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockSchools)(nil).GetById), ctx, id)
 }
 
+// GetByIds mocks base method.
+func (m *MockSchools) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.School)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockSchoolsMockRecorder) GetByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockSchools)(nil).GetByIds), ctx, ids)
+}
+
 // SetFondyCredentials mocks base method.
 func (m *MockSchools) SetFondyCredentials(ctx context.Context, id primitive.ObjectID, fondy domain.Fondy) error {
 	m.ctrl.T.Helper()
@@ -205,6 +249,34 @@ func (mr *MockSchoolsMockRecorder) SetFondyCredentials(ctx, id, fondy interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFondyCredentials", reflect.TypeOf((*MockSchools)(nil).SetFondyCredentials), ctx, id, fondy)
 }
 
+// SetOIDCProviders mocks base method.
+func (m *MockSchools) SetOIDCProviders(ctx context.Context, id primitive.ObjectID, providers []domain.OIDCProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOIDCProviders", ctx, id, providers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOIDCProviders indicates an expected call of SetOIDCProviders.
+func (mr *MockSchoolsMockRecorder) SetOIDCProviders(ctx, id, providers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCProviders", reflect.TypeOf((*MockSchools)(nil).SetOIDCProviders), ctx, id, providers)
+}
+
+// SetStudentFields mocks base method.
+func (m *MockSchools) SetStudentFields(ctx context.Context, id primitive.ObjectID, fields []domain.StudentField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStudentFields", ctx, id, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStudentFields indicates an expected call of SetStudentFields.
+func (mr *MockSchoolsMockRecorder) SetStudentFields(ctx, id, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStudentFields", reflect.TypeOf((*MockSchools)(nil).SetStudentFields), ctx, id, fields)
+}
+
 // UpdateSettings mocks base method.
 func (m *MockSchools) UpdateSettings(ctx context.Context, id primitive.ObjectID, inp domain.UpdateSchoolSettingsInput) error {
 	m.ctrl.T.Helper()
@@ -242,6 +314,20 @@ func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockStudents) AddTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, schoolId, studentIds, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockStudentsMockRecorder) AddTags(ctx, schoolId, studentIds, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockStudents)(nil).AddTags), ctx, schoolId, studentIds, tags)
+}
+
 // AttachOffer mocks base method.
 func (m *MockStudents) AttachOffer(ctx context.Context, studentId, offerId primitive.ObjectID, moduleIds []primitive.ObjectID) error {
 	m.ctrl.T.Helper()
@@ -298,19 +384,19 @@ func (mr *MockStudentsMockRecorder) DetachOffer(ctx, studentId, offerId, moduleI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachOffer", reflect.TypeOf((*MockStudents)(nil).DetachOffer), ctx, studentId, offerId, moduleIds)
 }
 
-// GetByCredentials mocks base method.
-func (m *MockStudents) GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Student, error) {
+// GetByEmail mocks base method.
+func (m *MockStudents) GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Student, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCredentials", ctx, schoolId, email, password)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, schoolId, email)
 	ret0, _ := ret[0].(domain.Student)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCredentials indicates an expected call of GetByCredentials.
-func (mr *MockStudentsMockRecorder) GetByCredentials(ctx, schoolId, email, password interface{}) *gomock.Call {
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockStudentsMockRecorder) GetByEmail(ctx, schoolId, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentials", reflect.TypeOf((*MockStudents)(nil).GetByCredentials), ctx, schoolId, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockStudents)(nil).GetByEmail), ctx, schoolId, email)
 }
 
 // GetById mocks base method.
@@ -328,19 +414,19 @@ func (mr *MockStudentsMockRecorder) GetById(ctx, schoolId, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockStudents)(nil).GetById), ctx, schoolId, id)
 }
 
-// GetByRefreshToken mocks base method.
-func (m *MockStudents) GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Student, error) {
+// GetByIdentity mocks base method.
+func (m *MockStudents) GetByIdentity(ctx context.Context, schoolId primitive.ObjectID, identity domain.StudentIdentity) (domain.Student, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByRefreshToken", ctx, schoolId, refreshToken)
+	ret := m.ctrl.Call(m, "GetByIdentity", ctx, schoolId, identity)
 	ret0, _ := ret[0].(domain.Student)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByRefreshToken indicates an expected call of GetByRefreshToken.
-func (mr *MockStudentsMockRecorder) GetByRefreshToken(ctx, schoolId, refreshToken interface{}) *gomock.Call {
+// GetByIdentity indicates an expected call of GetByIdentity.
+func (mr *MockStudentsMockRecorder) GetByIdentity(ctx, schoolId, identity interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRefreshToken", reflect.TypeOf((*MockStudents)(nil).GetByRefreshToken), ctx, schoolId, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdentity", reflect.TypeOf((*MockStudents)(nil).GetByIdentity), ctx, schoolId, identity)
 }
 
 // GetBySchool mocks base method.
@@ -359,194 +445,1035 @@ func (mr *MockStudentsMockRecorder) GetBySchool(ctx, schoolId, query interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockStudents)(nil).GetBySchool), ctx, schoolId, query)
 }
 
-// GiveAccessToModule mocks base method.
-func (m *MockStudents) GiveAccessToModule(ctx context.Context, studentId, moduleId primitive.ObjectID) error {
+// GiveAccessToModule mocks base method.
+func (m *MockStudents) GiveAccessToModule(ctx context.Context, studentId, moduleId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GiveAccessToModule", ctx, studentId, moduleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GiveAccessToModule indicates an expected call of GiveAccessToModule.
+func (mr *MockStudentsMockRecorder) GiveAccessToModule(ctx, studentId, moduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveAccessToModule", reflect.TypeOf((*MockStudents)(nil).GiveAccessToModule), ctx, studentId, moduleId)
+}
+
+// IterateBySchool mocks base method.
+func (m *MockStudents) IterateBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery, fn func(domain.Student) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateBySchool", ctx, schoolId, query, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateBySchool indicates an expected call of IterateBySchool.
+func (mr *MockStudentsMockRecorder) IterateBySchool(ctx, schoolId, query, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateBySchool", reflect.TypeOf((*MockStudents)(nil).IterateBySchool), ctx, schoolId, query, fn)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockStudents) LinkIdentity(ctx context.Context, studentId primitive.ObjectID, identity domain.StudentIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, studentId, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockStudentsMockRecorder) LinkIdentity(ctx, studentId, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockStudents)(nil).LinkIdentity), ctx, studentId, identity)
+}
+
+// MarkVerified mocks base method.
+func (m *MockStudents) MarkVerified(ctx context.Context, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkVerified", ctx, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkVerified indicates an expected call of MarkVerified.
+func (mr *MockStudentsMockRecorder) MarkVerified(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkVerified", reflect.TypeOf((*MockStudents)(nil).MarkVerified), ctx, studentId)
+}
+
+// RemoveTags mocks base method.
+func (m *MockStudents) RemoveTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, schoolId, studentIds, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockStudentsMockRecorder) RemoveTags(ctx, schoolId, studentIds, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockStudents)(nil).RemoveTags), ctx, schoolId, studentIds, tags)
+}
+
+// SetFields mocks base method.
+func (m *MockStudents) SetFields(ctx context.Context, inp repository.SetStudentFieldsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFields", ctx, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFields indicates an expected call of SetFields.
+func (mr *MockStudentsMockRecorder) SetFields(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFields", reflect.TypeOf((*MockStudents)(nil).SetFields), ctx, inp)
+}
+
+// SetLastVisit mocks base method.
+func (m *MockStudents) SetLastVisit(ctx context.Context, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLastVisit", ctx, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLastVisit indicates an expected call of SetLastVisit.
+func (mr *MockStudentsMockRecorder) SetLastVisit(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastVisit", reflect.TypeOf((*MockStudents)(nil).SetLastVisit), ctx, studentId)
+}
+
+// SetPassword mocks base method.
+func (m *MockStudents) SetPassword(ctx context.Context, studentId primitive.ObjectID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, studentId, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockStudentsMockRecorder) SetPassword(ctx, studentId, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockStudents)(nil).SetPassword), ctx, studentId, password)
+}
+
+// SetVerificationCode mocks base method.
+func (m *MockStudents) SetVerificationCode(ctx context.Context, studentId primitive.ObjectID, inp repository.SetVerificationCodeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerificationCode", ctx, studentId, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVerificationCode indicates an expected call of SetVerificationCode.
+func (mr *MockStudentsMockRecorder) SetVerificationCode(ctx, studentId, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerificationCode", reflect.TypeOf((*MockStudents)(nil).SetVerificationCode), ctx, studentId, inp)
+}
+
+// Update mocks base method.
+func (m *MockStudents) Update(ctx context.Context, inp domain.UpdateStudentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStudentsMockRecorder) Update(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudents)(nil).Update), ctx, inp)
+}
+
+// Verify mocks base method.
+func (m *MockStudents) Verify(ctx context.Context, code string) (domain.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, code)
+	ret0, _ := ret[0].(domain.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockStudentsMockRecorder) Verify(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockStudents)(nil).Verify), ctx, code)
+}
+
+// MockStudentLessons is a mock of StudentLessons interface.
+type MockStudentLessons struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentLessonsMockRecorder
+}
+
+// MockStudentLessonsMockRecorder is the mock recorder for MockStudentLessons.
+type MockStudentLessonsMockRecorder struct {
+	mock *MockStudentLessons
+}
+
+// NewMockStudentLessons creates a new mock instance.
+func NewMockStudentLessons(ctrl *gomock.Controller) *MockStudentLessons {
+	mock := &MockStudentLessons{ctrl: ctrl}
+	mock.recorder = &MockStudentLessonsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentLessons) EXPECT() *MockStudentLessonsMockRecorder {
+	return m.recorder
+}
+
+// AddCompleted mocks base method.
+func (m *MockStudentLessons) AddCompleted(ctx context.Context, studentId primitive.ObjectID, completion domain.CourseCompletion) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompleted", ctx, studentId, completion)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCompleted indicates an expected call of AddCompleted.
+func (mr *MockStudentLessonsMockRecorder) AddCompleted(ctx, studentId, completion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompleted", reflect.TypeOf((*MockStudentLessons)(nil).AddCompleted), ctx, studentId, completion)
+}
+
+// AddFinished mocks base method.
+func (m *MockStudentLessons) AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFinished", ctx, studentId, lessonId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFinished indicates an expected call of AddFinished.
+func (mr *MockStudentLessonsMockRecorder) AddFinished(ctx, studentId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFinished", reflect.TypeOf((*MockStudentLessons)(nil).AddFinished), ctx, studentId, lessonId)
+}
+
+// GetByCourse mocks base method.
+func (m *MockStudentLessons) GetByCourse(ctx context.Context, courseId primitive.ObjectID, lessonIds []primitive.ObjectID) ([]domain.StudentLessons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", ctx, courseId, lessonIds)
+	ret0, _ := ret[0].([]domain.StudentLessons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockStudentLessonsMockRecorder) GetByCourse(ctx, courseId, lessonIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockStudentLessons)(nil).GetByCourse), ctx, courseId, lessonIds)
+}
+
+// GetByStudent mocks base method.
+func (m *MockStudentLessons) GetByStudent(ctx context.Context, studentId primitive.ObjectID) (domain.StudentLessons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, studentId)
+	ret0, _ := ret[0].(domain.StudentLessons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockStudentLessonsMockRecorder) GetByStudent(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockStudentLessons)(nil).GetByStudent), ctx, studentId)
+}
+
+// GetByStudents mocks base method.
+func (m *MockStudentLessons) GetByStudents(ctx context.Context, studentIds []primitive.ObjectID) ([]domain.StudentLessons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudents", ctx, studentIds)
+	ret0, _ := ret[0].([]domain.StudentLessons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudents indicates an expected call of GetByStudents.
+func (mr *MockStudentLessonsMockRecorder) GetByStudents(ctx, studentIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudents", reflect.TypeOf((*MockStudentLessons)(nil).GetByStudents), ctx, studentIds)
+}
+
+// SetLastOpened mocks base method.
+func (m *MockStudentLessons) SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLastOpened", ctx, studentId, lessonId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLastOpened indicates an expected call of SetLastOpened.
+func (mr *MockStudentLessonsMockRecorder) SetLastOpened(ctx, studentId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastOpened", reflect.TypeOf((*MockStudentLessons)(nil).SetLastOpened), ctx, studentId, lessonId)
+}
+
+// MockAdmins is a mock of Admins interface.
+type MockAdmins struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminsMockRecorder
+}
+
+// MockAdminsMockRecorder is the mock recorder for MockAdmins.
+type MockAdminsMockRecorder struct {
+	mock *MockAdmins
+}
+
+// NewMockAdmins creates a new mock instance.
+func NewMockAdmins(ctrl *gomock.Controller) *MockAdmins {
+	mock := &MockAdmins{ctrl: ctrl}
+	mock.recorder = &MockAdminsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmins) EXPECT() *MockAdminsMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockAdmins) AcceptInvitation(ctx context.Context, inp repository.AcceptAdminInvitationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockAdminsMockRecorder) AcceptInvitation(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockAdmins)(nil).AcceptInvitation), ctx, inp)
+}
+
+// CountOwners mocks base method.
+func (m *MockAdmins) CountOwners(ctx context.Context, schoolId primitive.ObjectID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", ctx, schoolId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockAdminsMockRecorder) CountOwners(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockAdmins)(nil).CountOwners), ctx, schoolId)
+}
+
+// Create mocks base method.
+func (m *MockAdmins) Create(ctx context.Context, admin *domain.Admin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, admin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAdminsMockRecorder) Create(ctx, admin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdmins)(nil).Create), ctx, admin)
+}
+
+// Delete mocks base method.
+func (m *MockAdmins) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, schoolId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAdminsMockRecorder) Delete(ctx, schoolId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmins)(nil).Delete), ctx, schoolId, id)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockAdmins) DisableTwoFactor(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockAdminsMockRecorder) DisableTwoFactor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockAdmins)(nil).DisableTwoFactor), ctx, id)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockAdmins) EnableTwoFactor(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, id, secret, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockAdminsMockRecorder) EnableTwoFactor(ctx, id, secret, recoveryCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockAdmins)(nil).EnableTwoFactor), ctx, id, secret, recoveryCodes)
+}
+
+// GetByEmail mocks base method.
+func (m *MockAdmins) GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, schoolId, email)
+	ret0, _ := ret[0].(domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockAdminsMockRecorder) GetByEmail(ctx, schoolId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockAdmins)(nil).GetByEmail), ctx, schoolId, email)
+}
+
+// GetById mocks base method.
+func (m *MockAdmins) GetById(ctx context.Context, id primitive.ObjectID) (domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAdminsMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAdmins)(nil).GetById), ctx, id)
+}
+
+// GetBySchool mocks base method.
+func (m *MockAdmins) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySchool", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySchool indicates an expected call of GetBySchool.
+func (mr *MockAdminsMockRecorder) GetBySchool(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockAdmins)(nil).GetBySchool), ctx, schoolId)
+}
+
+// GetByUser mocks base method.
+func (m *MockAdmins) GetByUser(ctx context.Context, schoolId, userId primitive.ObjectID) (domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, schoolId, userId)
+	ret0, _ := ret[0].(domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockAdminsMockRecorder) GetByUser(ctx, schoolId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockAdmins)(nil).GetByUser), ctx, schoolId, userId)
+}
+
+// LinkUser mocks base method.
+func (m *MockAdmins) LinkUser(ctx context.Context, id, userId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkUser", ctx, id, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkUser indicates an expected call of LinkUser.
+func (mr *MockAdminsMockRecorder) LinkUser(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUser", reflect.TypeOf((*MockAdmins)(nil).LinkUser), ctx, id, userId)
+}
+
+// SetPassword mocks base method.
+func (m *MockAdmins) SetPassword(ctx context.Context, id primitive.ObjectID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockAdminsMockRecorder) SetPassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockAdmins)(nil).SetPassword), ctx, id, password)
+}
+
+// SetRole mocks base method.
+func (m *MockAdmins) SetRole(ctx context.Context, schoolId, id primitive.ObjectID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, schoolId, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminsMockRecorder) SetRole(ctx, schoolId, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdmins)(nil).SetRole), ctx, schoolId, id, role)
+}
+
+// SetTwoFactorLastUsedStep mocks base method.
+func (m *MockAdmins) SetTwoFactorLastUsedStep(ctx context.Context, id primitive.ObjectID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorLastUsedStep", ctx, id, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorLastUsedStep indicates an expected call of SetTwoFactorLastUsedStep.
+func (mr *MockAdminsMockRecorder) SetTwoFactorLastUsedStep(ctx, id, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorLastUsedStep", reflect.TypeOf((*MockAdmins)(nil).SetTwoFactorLastUsedStep), ctx, id, step)
+}
+
+// SetTwoFactorPendingSecret mocks base method.
+func (m *MockAdmins) SetTwoFactorPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorPendingSecret", ctx, id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorPendingSecret indicates an expected call of SetTwoFactorPendingSecret.
+func (mr *MockAdminsMockRecorder) SetTwoFactorPendingSecret(ctx, id, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorPendingSecret", reflect.TypeOf((*MockAdmins)(nil).SetTwoFactorPendingSecret), ctx, id, secret)
+}
+
+// UpdateInvitation mocks base method.
+func (m *MockAdmins) UpdateInvitation(ctx context.Context, inp repository.UpdateAdminInvitationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvitation", ctx, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInvitation indicates an expected call of UpdateInvitation.
+func (mr *MockAdminsMockRecorder) UpdateInvitation(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitation", reflect.TypeOf((*MockAdmins)(nil).UpdateInvitation), ctx, inp)
+}
+
+// UseTwoFactorRecoveryCode mocks base method.
+func (m *MockAdmins) UseTwoFactorRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorRecoveryCode", ctx, id, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTwoFactorRecoveryCode indicates an expected call of UseTwoFactorRecoveryCode.
+func (mr *MockAdminsMockRecorder) UseTwoFactorRecoveryCode(ctx, id, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorRecoveryCode", reflect.TypeOf((*MockAdmins)(nil).UseTwoFactorRecoveryCode), ctx, id, codeHash)
+}
+
+// MockSessions is a mock of Sessions interface.
+type MockSessions struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionsMockRecorder
+}
+
+// MockSessionsMockRecorder is the mock recorder for MockSessions.
+type MockSessionsMockRecorder struct {
+	mock *MockSessions
+}
+
+// NewMockSessions creates a new mock instance.
+func NewMockSessions(ctrl *gomock.Controller) *MockSessions {
+	mock := &MockSessions{ctrl: ctrl}
+	mock.recorder = &MockSessionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessions) EXPECT() *MockSessionsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessions) Create(ctx context.Context, session domain.Session) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionsMockRecorder) Create(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessions)(nil).Create), ctx, session)
+}
+
+// Delete mocks base method.
+func (m *MockSessions) Delete(ctx context.Context, ownerId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ownerId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSessionsMockRecorder) Delete(ctx, ownerId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessions)(nil).Delete), ctx, ownerId, id)
+}
+
+// DeleteByOwner mocks base method.
+func (m *MockSessions) DeleteByOwner(ctx context.Context, ownerId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByOwner", ctx, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByOwner indicates an expected call of DeleteByOwner.
+func (mr *MockSessionsMockRecorder) DeleteByOwner(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByOwner", reflect.TypeOf((*MockSessions)(nil).DeleteByOwner), ctx, ownerId)
+}
+
+// DeleteByUsedToken mocks base method.
+func (m *MockSessions) DeleteByUsedToken(ctx context.Context, role, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsedToken", ctx, role, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUsedToken indicates an expected call of DeleteByUsedToken.
+func (mr *MockSessionsMockRecorder) DeleteByUsedToken(ctx, role, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsedToken", reflect.TypeOf((*MockSessions)(nil).DeleteByUsedToken), ctx, role, refreshToken)
+}
+
+// GetByOwner mocks base method.
+func (m *MockSessions) GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", ctx, ownerId)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockSessionsMockRecorder) GetByOwner(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockSessions)(nil).GetByOwner), ctx, ownerId)
+}
+
+// Rotate mocks base method.
+func (m *MockSessions) Rotate(ctx context.Context, inp repository.RotateSessionInput) (domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, inp)
+	ret0, _ := ret[0].(domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionsMockRecorder) Rotate(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessions)(nil).Rotate), ctx, inp)
+}
+
+// MockOneTimeTokens is a mock of OneTimeTokens interface.
+type MockOneTimeTokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockOneTimeTokensMockRecorder
+}
+
+// MockOneTimeTokensMockRecorder is the mock recorder for MockOneTimeTokens.
+type MockOneTimeTokensMockRecorder struct {
+	mock *MockOneTimeTokens
+}
+
+// NewMockOneTimeTokens creates a new mock instance.
+func NewMockOneTimeTokens(ctrl *gomock.Controller) *MockOneTimeTokens {
+	mock := &MockOneTimeTokens{ctrl: ctrl}
+	mock.recorder = &MockOneTimeTokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOneTimeTokens) EXPECT() *MockOneTimeTokensMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOneTimeTokens) Consume(ctx context.Context, inp repository.ConsumeOneTimeTokenInput) (domain.OneTimeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, inp)
+	ret0, _ := ret[0].(domain.OneTimeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOneTimeTokensMockRecorder) Consume(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOneTimeTokens)(nil).Consume), ctx, inp)
+}
+
+// Create mocks base method.
+func (m *MockOneTimeTokens) Create(ctx context.Context, token domain.OneTimeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOneTimeTokensMockRecorder) Create(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOneTimeTokens)(nil).Create), ctx, token)
+}
+
+// MockAPIKeys is a mock of APIKeys interface.
+type MockAPIKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysMockRecorder
+}
+
+// MockAPIKeysMockRecorder is the mock recorder for MockAPIKeys.
+type MockAPIKeysMockRecorder struct {
+	mock *MockAPIKeys
+}
+
+// NewMockAPIKeys creates a new mock instance.
+func NewMockAPIKeys(ctrl *gomock.Controller) *MockAPIKeys {
+	mock := &MockAPIKeys{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeys) EXPECT() *MockAPIKeysMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeys) Create(ctx context.Context, key *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeysMockRecorder) Create(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeys)(nil).Create), ctx, key)
+}
+
+// Delete mocks base method.
+func (m *MockAPIKeys) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, schoolId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPIKeysMockRecorder) Delete(ctx, schoolId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIKeys)(nil).Delete), ctx, schoolId, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeys) GetByHash(ctx context.Context, schoolId primitive.ObjectID, hash string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, schoolId, hash)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeysMockRecorder) GetByHash(ctx, schoolId, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeys)(nil).GetByHash), ctx, schoolId, hash)
+}
+
+// GetBySchool mocks base method.
+func (m *MockAPIKeys) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySchool", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySchool indicates an expected call of GetBySchool.
+func (mr *MockAPIKeysMockRecorder) GetBySchool(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockAPIKeys)(nil).GetBySchool), ctx, schoolId)
+}
+
+// SetLastUsed mocks base method.
+func (m *MockAPIKeys) SetLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GiveAccessToModule", ctx, studentId, moduleId)
+	ret := m.ctrl.Call(m, "SetLastUsed", ctx, id, lastUsedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GiveAccessToModule indicates an expected call of GiveAccessToModule.
-func (mr *MockStudentsMockRecorder) GiveAccessToModule(ctx, studentId, moduleId interface{}) *gomock.Call {
+// SetLastUsed indicates an expected call of SetLastUsed.
+func (mr *MockAPIKeysMockRecorder) SetLastUsed(ctx, id, lastUsedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveAccessToModule", reflect.TypeOf((*MockStudents)(nil).GiveAccessToModule), ctx, studentId, moduleId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastUsed", reflect.TypeOf((*MockAPIKeys)(nil).SetLastUsed), ctx, id, lastUsedAt)
+}
+
+// MockStudentImports is a mock of StudentImports interface.
+type MockStudentImports struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentImportsMockRecorder
+}
+
+// MockStudentImportsMockRecorder is the mock recorder for MockStudentImports.
+type MockStudentImportsMockRecorder struct {
+	mock *MockStudentImports
+}
+
+// NewMockStudentImports creates a new mock instance.
+func NewMockStudentImports(ctrl *gomock.Controller) *MockStudentImports {
+	mock := &MockStudentImports{ctrl: ctrl}
+	mock.recorder = &MockStudentImportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentImports) EXPECT() *MockStudentImportsMockRecorder {
+	return m.recorder
 }
 
-// SetSession mocks base method.
-func (m *MockStudents) SetSession(ctx context.Context, studentId primitive.ObjectID, session domain.Session) error {
+// Create mocks base method.
+func (m *MockStudentImports) Create(ctx context.Context, studentImport *domain.StudentImport) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetSession", ctx, studentId, session)
+	ret := m.ctrl.Call(m, "Create", ctx, studentImport)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetSession indicates an expected call of SetSession.
-func (mr *MockStudentsMockRecorder) SetSession(ctx, studentId, session interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockStudentImportsMockRecorder) Create(ctx, studentImport interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSession", reflect.TypeOf((*MockStudents)(nil).SetSession), ctx, studentId, session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStudentImports)(nil).Create), ctx, studentImport)
 }
 
-// Update mocks base method.
-func (m *MockStudents) Update(ctx context.Context, inp domain.UpdateStudentInput) error {
+// Finish mocks base method.
+func (m *MockStudentImports) Finish(ctx context.Context, studentImport domain.StudentImport) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, inp)
+	ret := m.ctrl.Call(m, "Finish", ctx, studentImport)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockStudentsMockRecorder) Update(ctx, inp interface{}) *gomock.Call {
+// Finish indicates an expected call of Finish.
+func (mr *MockStudentImportsMockRecorder) Finish(ctx, studentImport interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudents)(nil).Update), ctx, inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockStudentImports)(nil).Finish), ctx, studentImport)
 }
 
-// Verify mocks base method.
-func (m *MockStudents) Verify(ctx context.Context, code string) (domain.Student, error) {
+// GetById mocks base method.
+func (m *MockStudentImports) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", ctx, code)
-	ret0, _ := ret[0].(domain.Student)
+	ret := m.ctrl.Call(m, "GetById", ctx, schoolId, id)
+	ret0, _ := ret[0].(domain.StudentImport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Verify indicates an expected call of Verify.
-func (mr *MockStudentsMockRecorder) Verify(ctx, code interface{}) *gomock.Call {
+// GetById indicates an expected call of GetById.
+func (mr *MockStudentImportsMockRecorder) GetById(ctx, schoolId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockStudents)(nil).Verify), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockStudentImports)(nil).GetById), ctx, schoolId, id)
 }
 
-// MockStudentLessons is a mock of StudentLessons interface.
-type MockStudentLessons struct {
+// SetProgress mocks base method.
+func (m *MockStudentImports) SetProgress(ctx context.Context, id primitive.ObjectID, inp repository.StudentImportProgressInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProgress", ctx, id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProgress indicates an expected call of SetProgress.
+func (mr *MockStudentImportsMockRecorder) SetProgress(ctx, id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgress", reflect.TypeOf((*MockStudentImports)(nil).SetProgress), ctx, id, inp)
+}
+
+// MockStudentActivities is a mock of StudentActivities interface.
+type MockStudentActivities struct {
 	ctrl     *gomock.Controller
-	recorder *MockStudentLessonsMockRecorder
+	recorder *MockStudentActivitiesMockRecorder
 }
 
-// MockStudentLessonsMockRecorder is the mock recorder for MockStudentLessons.
-type MockStudentLessonsMockRecorder struct {
-	mock *MockStudentLessons
+// MockStudentActivitiesMockRecorder is the mock recorder for MockStudentActivities.
+type MockStudentActivitiesMockRecorder struct {
+	mock *MockStudentActivities
 }
 
-// NewMockStudentLessons creates a new mock instance.
-func NewMockStudentLessons(ctrl *gomock.Controller) *MockStudentLessons {
-	mock := &MockStudentLessons{ctrl: ctrl}
-	mock.recorder = &MockStudentLessonsMockRecorder{mock}
+// NewMockStudentActivities creates a new mock instance.
+func NewMockStudentActivities(ctrl *gomock.Controller) *MockStudentActivities {
+	mock := &MockStudentActivities{ctrl: ctrl}
+	mock.recorder = &MockStudentActivitiesMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockStudentLessons) EXPECT() *MockStudentLessonsMockRecorder {
+func (m *MockStudentActivities) EXPECT() *MockStudentActivitiesMockRecorder {
 	return m.recorder
 }
 
-// AddFinished mocks base method.
-func (m *MockStudentLessons) AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+// Create mocks base method.
+func (m *MockStudentActivities) Create(ctx context.Context, activity domain.StudentActivity) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFinished", ctx, studentId, lessonId)
+	ret := m.ctrl.Call(m, "Create", ctx, activity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddFinished indicates an expected call of AddFinished.
-func (mr *MockStudentLessonsMockRecorder) AddFinished(ctx, studentId, lessonId interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockStudentActivitiesMockRecorder) Create(ctx, activity interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFinished", reflect.TypeOf((*MockStudentLessons)(nil).AddFinished), ctx, studentId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStudentActivities)(nil).Create), ctx, activity)
 }
 
-// SetLastOpened mocks base method.
-func (m *MockStudentLessons) SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+// DeleteByStudent mocks base method.
+func (m *MockStudentActivities) DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetLastOpened", ctx, studentId, lessonId)
+	ret := m.ctrl.Call(m, "DeleteByStudent", ctx, studentId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetLastOpened indicates an expected call of SetLastOpened.
-func (mr *MockStudentLessonsMockRecorder) SetLastOpened(ctx, studentId, lessonId interface{}) *gomock.Call {
+// DeleteByStudent indicates an expected call of DeleteByStudent.
+func (mr *MockStudentActivitiesMockRecorder) DeleteByStudent(ctx, studentId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastOpened", reflect.TypeOf((*MockStudentLessons)(nil).SetLastOpened), ctx, studentId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByStudent", reflect.TypeOf((*MockStudentActivities)(nil).DeleteByStudent), ctx, studentId)
 }
 
-// MockAdmins is a mock of Admins interface.
-type MockAdmins struct {
+// GetByStudent mocks base method.
+func (m *MockStudentActivities) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID, query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, schoolId, studentId, query)
+	ret0, _ := ret[0].([]domain.StudentActivity)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockStudentActivitiesMockRecorder) GetByStudent(ctx, schoolId, studentId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockStudentActivities)(nil).GetByStudent), ctx, schoolId, studentId, query)
+}
+
+// MockStudentSegments is a mock of StudentSegments interface.
+type MockStudentSegments struct {
 	ctrl     *gomock.Controller
-	recorder *MockAdminsMockRecorder
+	recorder *MockStudentSegmentsMockRecorder
 }
 
-// MockAdminsMockRecorder is the mock recorder for MockAdmins.
-type MockAdminsMockRecorder struct {
-	mock *MockAdmins
+// MockStudentSegmentsMockRecorder is the mock recorder for MockStudentSegments.
+type MockStudentSegmentsMockRecorder struct {
+	mock *MockStudentSegments
 }
 
-// NewMockAdmins creates a new mock instance.
-func NewMockAdmins(ctrl *gomock.Controller) *MockAdmins {
-	mock := &MockAdmins{ctrl: ctrl}
-	mock.recorder = &MockAdminsMockRecorder{mock}
+// NewMockStudentSegments creates a new mock instance.
+func NewMockStudentSegments(ctrl *gomock.Controller) *MockStudentSegments {
+	mock := &MockStudentSegments{ctrl: ctrl}
+	mock.recorder = &MockStudentSegmentsMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAdmins) EXPECT() *MockAdminsMockRecorder {
+func (m *MockStudentSegments) EXPECT() *MockStudentSegmentsMockRecorder {
 	return m.recorder
 }
 
-// GetByCredentials mocks base method.
-func (m *MockAdmins) GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Admin, error) {
+// Create mocks base method.
+func (m *MockStudentSegments) Create(ctx context.Context, segment *domain.StudentSegment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCredentials", ctx, schoolId, email, password)
-	ret0, _ := ret[0].(domain.Admin)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Create", ctx, segment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStudentSegmentsMockRecorder) Create(ctx, segment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStudentSegments)(nil).Create), ctx, segment)
+}
+
+// Delete mocks base method.
+func (m *MockStudentSegments) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, schoolId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetByCredentials indicates an expected call of GetByCredentials.
-func (mr *MockAdminsMockRecorder) GetByCredentials(ctx, schoolId, email, password interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockStudentSegmentsMockRecorder) Delete(ctx, schoolId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentials", reflect.TypeOf((*MockAdmins)(nil).GetByCredentials), ctx, schoolId, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentSegments)(nil).Delete), ctx, schoolId, id)
 }
 
 // GetById mocks base method.
-func (m *MockAdmins) GetById(ctx context.Context, id primitive.ObjectID) (domain.Admin, error) {
+func (m *MockStudentSegments) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentSegment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", ctx, id)
-	ret0, _ := ret[0].(domain.Admin)
+	ret := m.ctrl.Call(m, "GetById", ctx, schoolId, id)
+	ret0, _ := ret[0].(domain.StudentSegment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockAdminsMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+func (mr *MockStudentSegmentsMockRecorder) GetById(ctx, schoolId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAdmins)(nil).GetById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockStudentSegments)(nil).GetById), ctx, schoolId, id)
 }
 
-// GetByRefreshToken mocks base method.
-func (m *MockAdmins) GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Admin, error) {
+// GetBySchool mocks base method.
+func (m *MockStudentSegments) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByRefreshToken", ctx, schoolId, refreshToken)
-	ret0, _ := ret[0].(domain.Admin)
+	ret := m.ctrl.Call(m, "GetBySchool", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.StudentSegment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByRefreshToken indicates an expected call of GetByRefreshToken.
-func (mr *MockAdminsMockRecorder) GetByRefreshToken(ctx, schoolId, refreshToken interface{}) *gomock.Call {
+// GetBySchool indicates an expected call of GetBySchool.
+func (mr *MockStudentSegmentsMockRecorder) GetBySchool(ctx, schoolId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRefreshToken", reflect.TypeOf((*MockAdmins)(nil).GetByRefreshToken), ctx, schoolId, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockStudentSegments)(nil).GetBySchool), ctx, schoolId)
 }
 
-// SetSession mocks base method.
-func (m *MockAdmins) SetSession(ctx context.Context, id primitive.ObjectID, session domain.Session) error {
+// Update mocks base method.
+func (m *MockStudentSegments) Update(ctx context.Context, inp repository.UpdateStudentSegmentInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetSession", ctx, id, session)
+	ret := m.ctrl.Call(m, "Update", ctx, inp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetSession indicates an expected call of SetSession.
-func (mr *MockAdminsMockRecorder) SetSession(ctx, id, session interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockStudentSegmentsMockRecorder) Update(ctx, inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSession", reflect.TypeOf((*MockAdmins)(nil).SetSession), ctx, id, session)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentSegments)(nil).Update), ctx, inp)
 }
 
 // MockCourses is a mock of Courses interface.
@@ -601,6 +1528,21 @@ func (mr *MockCoursesMockRecorder) Delete(ctx, schoolId, courseId interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourses)(nil).Delete), ctx, schoolId, courseId)
 }
 
+// GetById mocks base method.
+func (m *MockCourses) GetById(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, schoolId, courseId)
+	ret0, _ := ret[0].(domain.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCoursesMockRecorder) GetById(ctx, schoolId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCourses)(nil).GetById), ctx, schoolId, courseId)
+}
+
 // Update mocks base method.
 func (m *MockCourses) Update(ctx context.Context, inp repository.UpdateCourseInput) error {
 	m.ctrl.T.Helper()
@@ -795,6 +1737,21 @@ func (mr *MockModulesMockRecorder) GetById(ctx, moduleID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockModules)(nil).GetById), ctx, moduleID)
 }
 
+// GetByIds mocks base method.
+func (m *MockModules) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockModulesMockRecorder) GetByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockModules)(nil).GetByIds), ctx, ids)
+}
+
 // GetByLesson mocks base method.
 func (m *MockModules) GetByLesson(ctx context.Context, lessonID primitive.ObjectID) (domain.Module, error) {
 	m.ctrl.T.Helper()
@@ -855,6 +1812,20 @@ func (mr *MockModulesMockRecorder) GetPublishedById(ctx, moduleID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockModules)(nil).GetPublishedById), ctx, moduleID)
 }
 
+// PublishScheduled mocks base method.
+func (m *MockModules) PublishScheduled(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockModulesMockRecorder) PublishScheduled(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockModules)(nil).PublishScheduled), ctx)
+}
+
 // Update mocks base method.
 func (m *MockModules) Update(ctx context.Context, inp repository.UpdateModuleInput) error {
 	m.ctrl.T.Helper()
@@ -950,18 +1921,129 @@ func (mr *MockLessonContentMockRecorder) GetByLessons(ctx, lessonIds interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLessons", reflect.TypeOf((*MockLessonContent)(nil).GetByLessons), ctx, lessonIds)
 }
 
-// Update mocks base method.
-func (m *MockLessonContent) Update(ctx context.Context, schoolID, lessonID primitive.ObjectID, content string) error {
+// NextVersion mocks base method.
+func (m *MockLessonContent) NextVersion(ctx context.Context, schoolID, lessonID primitive.ObjectID) (domain.LessonContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextVersion", ctx, schoolID, lessonID)
+	ret0, _ := ret[0].(domain.LessonContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextVersion indicates an expected call of NextVersion.
+func (mr *MockLessonContentMockRecorder) NextVersion(ctx, schoolID, lessonID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextVersion", reflect.TypeOf((*MockLessonContent)(nil).NextVersion), ctx, schoolID, lessonID)
+}
+
+// Publish mocks base method.
+func (m *MockLessonContent) Publish(ctx context.Context, schoolID, lessonID primitive.ObjectID, version int, blocks []domain.LessonBlock) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, schoolID, lessonID, content)
+	ret := m.ctrl.Call(m, "Publish", ctx, schoolID, lessonID, version, blocks)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockLessonContentMockRecorder) Update(ctx, schoolID, lessonID, content interface{}) *gomock.Call {
+// Publish indicates an expected call of Publish.
+func (mr *MockLessonContentMockRecorder) Publish(ctx, schoolID, lessonID, version, blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockLessonContent)(nil).Publish), ctx, schoolID, lessonID, version, blocks)
+}
+
+// SaveDraft mocks base method.
+func (m *MockLessonContent) SaveDraft(ctx context.Context, schoolID, lessonID primitive.ObjectID, draft domain.LessonDraft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", ctx, schoolID, lessonID, draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockLessonContentMockRecorder) SaveDraft(ctx, schoolID, lessonID, draft interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockLessonContent)(nil).SaveDraft), ctx, schoolID, lessonID, draft)
+}
+
+// MockLessonContentVersions is a mock of LessonContentVersions interface.
+type MockLessonContentVersions struct {
+	ctrl     *gomock.Controller
+	recorder *MockLessonContentVersionsMockRecorder
+}
+
+// MockLessonContentVersionsMockRecorder is the mock recorder for MockLessonContentVersions.
+type MockLessonContentVersionsMockRecorder struct {
+	mock *MockLessonContentVersions
+}
+
+// NewMockLessonContentVersions creates a new mock instance.
+func NewMockLessonContentVersions(ctrl *gomock.Controller) *MockLessonContentVersions {
+	mock := &MockLessonContentVersions{ctrl: ctrl}
+	mock.recorder = &MockLessonContentVersionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLessonContentVersions) EXPECT() *MockLessonContentVersionsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLessonContentVersions) Create(ctx context.Context, version domain.LessonContentVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLessonContentVersionsMockRecorder) Create(ctx, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLessonContentVersions)(nil).Create), ctx, version)
+}
+
+// DeleteByLessons mocks base method.
+func (m *MockLessonContentVersions) DeleteByLessons(ctx context.Context, schoolID primitive.ObjectID, lessonIds []primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByLessons", ctx, schoolID, lessonIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByLessons indicates an expected call of DeleteByLessons.
+func (mr *MockLessonContentVersionsMockRecorder) DeleteByLessons(ctx, schoolID, lessonIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByLessons", reflect.TypeOf((*MockLessonContentVersions)(nil).DeleteByLessons), ctx, schoolID, lessonIds)
+}
+
+// GetByLesson mocks base method.
+func (m *MockLessonContentVersions) GetByLesson(ctx context.Context, schoolID, lessonID primitive.ObjectID, query *domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLesson", ctx, schoolID, lessonID, query)
+	ret0, _ := ret[0].([]domain.LessonContentVersion)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByLesson indicates an expected call of GetByLesson.
+func (mr *MockLessonContentVersionsMockRecorder) GetByLesson(ctx, schoolID, lessonID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLesson", reflect.TypeOf((*MockLessonContentVersions)(nil).GetByLesson), ctx, schoolID, lessonID, query)
+}
+
+// GetByVersion mocks base method.
+func (m *MockLessonContentVersions) GetByVersion(ctx context.Context, schoolID, lessonID primitive.ObjectID, version int) (domain.LessonContentVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVersion", ctx, schoolID, lessonID, version)
+	ret0, _ := ret[0].(domain.LessonContentVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVersion indicates an expected call of GetByVersion.
+func (mr *MockLessonContentVersionsMockRecorder) GetByVersion(ctx, schoolID, lessonID, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLessonContent)(nil).Update), ctx, schoolID, lessonID, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVersion", reflect.TypeOf((*MockLessonContentVersions)(nil).GetByVersion), ctx, schoolID, lessonID, version)
 }
 
 // MockPackages is a mock of Packages interface.
@@ -1350,6 +2432,20 @@ func (mr *MockOrdersMockRecorder) AddTransaction(ctx, id, transaction interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockOrders)(nil).AddTransaction), ctx, id, transaction)
 }
 
+// AnonymizeStudent mocks base method.
+func (m *MockOrders) AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeStudent", ctx, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeStudent indicates an expected call of AnonymizeStudent.
+func (mr *MockOrdersMockRecorder) AnonymizeStudent(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeStudent", reflect.TypeOf((*MockOrders)(nil).AnonymizeStudent), ctx, studentId)
+}
+
 // Create mocks base method.
 func (m *MockOrders) Create(ctx context.Context, order domain.Order) error {
 	m.ctrl.T.Helper()
@@ -1395,6 +2491,21 @@ func (mr *MockOrdersMockRecorder) GetBySchool(ctx, schoolId, pagination interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockOrders)(nil).GetBySchool), ctx, schoolId, pagination)
 }
 
+// GetByStudent mocks base method.
+func (m *MockOrders) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, schoolId, studentId)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockOrdersMockRecorder) GetByStudent(ctx, schoolId, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockOrders)(nil).GetByStudent), ctx, schoolId, studentId)
+}
+
 // SetStatus mocks base method.
 func (m *MockOrders) SetStatus(ctx context.Context, id primitive.ObjectID, status string) error {
 	m.ctrl.T.Helper()
@@ -1528,10 +2639,24 @@ func (m *MockSurveyResults) EXPECT() *MockSurveyResultsMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeStudent mocks base method.
+func (m *MockSurveyResults) AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeStudent", ctx, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeStudent indicates an expected call of AnonymizeStudent.
+func (mr *MockSurveyResultsMockRecorder) AnonymizeStudent(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeStudent", reflect.TypeOf((*MockSurveyResults)(nil).AnonymizeStudent), ctx, studentId)
+}
+
 // GetAllByModule mocks base method.
-func (m *MockSurveyResults) GetAllByModule(ctx context.Context, moduleID primitive.ObjectID, pagination *domain.PaginationQuery) ([]domain.SurveyResult, int64, error) {
+func (m *MockSurveyResults) GetAllByModule(ctx context.Context, moduleId primitive.ObjectID, pagination *domain.PaginationQuery) ([]domain.SurveyResult, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByModule", ctx, moduleID, pagination)
+	ret := m.ctrl.Call(m, "GetAllByModule", ctx, moduleId, pagination)
 	ret0, _ := ret[0].([]domain.SurveyResult)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -1539,24 +2664,39 @@ func (m *MockSurveyResults) GetAllByModule(ctx context.Context, moduleID primiti
 }
 
 // GetAllByModule indicates an expected call of GetAllByModule.
-func (mr *MockSurveyResultsMockRecorder) GetAllByModule(ctx, moduleID, pagination interface{}) *gomock.Call {
+func (mr *MockSurveyResultsMockRecorder) GetAllByModule(ctx, moduleId, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByModule", reflect.TypeOf((*MockSurveyResults)(nil).GetAllByModule), ctx, moduleId, pagination)
+}
+
+// GetAllByStudent mocks base method.
+func (m *MockSurveyResults) GetAllByStudent(ctx context.Context, studentId primitive.ObjectID) ([]domain.SurveyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByStudent", ctx, studentId)
+	ret0, _ := ret[0].([]domain.SurveyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByStudent indicates an expected call of GetAllByStudent.
+func (mr *MockSurveyResultsMockRecorder) GetAllByStudent(ctx, studentId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByModule", reflect.TypeOf((*MockSurveyResults)(nil).GetAllByModule), ctx, moduleID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByStudent", reflect.TypeOf((*MockSurveyResults)(nil).GetAllByStudent), ctx, studentId)
 }
 
 // GetByStudent mocks base method.
-func (m *MockSurveyResults) GetByStudent(ctx context.Context, moduleID, studentId primitive.ObjectID) (domain.SurveyResult, error) {
+func (m *MockSurveyResults) GetByStudent(ctx context.Context, moduleId, studentId primitive.ObjectID) (domain.SurveyResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStudent", ctx, moduleID, studentId)
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, moduleId, studentId)
 	ret0, _ := ret[0].(domain.SurveyResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByStudent indicates an expected call of GetByStudent.
-func (mr *MockSurveyResultsMockRecorder) GetByStudent(ctx, moduleID, studentId interface{}) *gomock.Call {
+func (mr *MockSurveyResultsMockRecorder) GetByStudent(ctx, moduleId, studentId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockSurveyResults)(nil).GetByStudent), ctx, moduleID, studentId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockSurveyResults)(nil).GetByStudent), ctx, moduleId, studentId)
 }
 
 // Save mocks base method.
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
@@ -88,6 +89,22 @@ func (r *ModulesRepo) GetByPackages(ctx context.Context, packageIds []primitive.
 	return modules, err
 }
 
+func (r *ModulesRepo) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error) {
+	var modules []domain.Module
+
+	opts := options.Find()
+	opts.SetSort(bson.M{"position": 1})
+
+	cur, err := r.db.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &modules)
+
+	return modules, err
+}
+
 func (r *ModulesRepo) Update(ctx context.Context, inp UpdateModuleInput) error {
 	updateQuery := bson.M{}
 
@@ -103,8 +120,16 @@ func (r *ModulesRepo) Update(ctx context.Context, inp UpdateModuleInput) error {
 		updateQuery["published"] = *inp.Published
 	}
 
+	if inp.DripDays != nil {
+		updateQuery["dripDays"] = *inp.DripDays
+	}
+
+	if inp.Gating != nil {
+		updateQuery["gating"] = *inp.Gating
+	}
+
 	_, err := r.db.UpdateOne(ctx,
-		bson.M{"_id": inp.ID, "schoolId": inp.SchoolID}, bson.M{"$set": updateQuery})
+		bson.M{"_id": inp.ID, "schoolId": inp.SchoolID}, scheduleUpdate(updateQuery, "", inp.Published, inp.PublishAt))
 
 	return err
 }
@@ -143,12 +168,55 @@ func (r *ModulesRepo) UpdateLesson(ctx context.Context, inp UpdateLessonInput) e
 		updateQuery["lessons.$.published"] = *inp.Published
 	}
 
+	if inp.DripDays != nil {
+		updateQuery["lessons.$.dripDays"] = *inp.DripDays
+	}
+
 	_, err := r.db.UpdateOne(ctx,
-		bson.M{"lessons._id": inp.ID, "schoolId": inp.SchoolID}, bson.M{"$set": updateQuery})
+		bson.M{"lessons._id": inp.ID, "schoolId": inp.SchoolID}, scheduleUpdate(updateQuery, "lessons.$.", inp.Published, inp.PublishAt))
 
 	return err
 }
 
+// PublishScheduled publishes modules and lessons, which publish time has come.
+func (r *ModulesRepo) PublishScheduled(ctx context.Context) error {
+	now := time.Now()
+
+	if _, err := r.db.UpdateMany(ctx, bson.M{"publishAt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"published": true}, "$unset": bson.M{"publishAt": ""}}); err != nil {
+		return err
+	}
+
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"lesson.publishAt": bson.M{"$lte": now}}},
+	})
+
+	_, err := r.db.UpdateMany(ctx, bson.M{"lessons.publishAt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"lessons.$[lesson].published": true}, "$unset": bson.M{"lessons.$[lesson].publishAt": ""}}, opts)
+
+	return err
+}
+
+// scheduleUpdate builds update with publish time. Scheduled content is unpublished till publish time,
+// zero time or manual publishing cancel the schedule.
+func scheduleUpdate(set bson.M, prefix string, published *bool, publishAt *time.Time) bson.M {
+	update := bson.M{}
+
+	switch {
+	case publishAt != nil && !publishAt.IsZero():
+		set[prefix+"publishAt"] = *publishAt
+		set[prefix+"published"] = false
+	case publishAt != nil || (published != nil && *published):
+		update["$unset"] = bson.M{prefix + "publishAt": ""}
+	}
+
+	if len(set) > 0 || len(update) == 0 {
+		update["$set"] = set
+	}
+
+	return update
+}
+
 func (r *ModulesRepo) DeleteLesson(ctx context.Context, schoolId, id primitive.ObjectID) error {
 	_, err := r.db.UpdateOne(ctx, bson.M{"lessons._id": id, "schoolId": schoolId}, bson.M{"$pull": bson.M{"lessons": bson.M{"_id": id}}})
 
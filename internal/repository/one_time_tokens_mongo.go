@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OneTimeTokensRepo struct {
+	db *mongo.Collection
+}
+
+func NewOneTimeTokensRepo(db *mongo.Database) *OneTimeTokensRepo {
+	return &OneTimeTokensRepo{db: db.Collection(oneTimeTokensCollection)}
+}
+
+// Create saves new token, previously issued tokens of the owner with the same purpose are removed.
+// Tokens issued before the user is known are replaced by the school and email, tokens without both are kept.
+func (r *OneTimeTokensRepo) Create(ctx context.Context, token domain.OneTimeToken) error {
+	if filter := previousTokensFilter(token); filter != nil {
+		if _, err := r.db.DeleteMany(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.db.InsertOne(ctx, token)
+
+	return err
+}
+
+// Consume removes token and returns it, so the token can be used only once.
+func (r *OneTimeTokensRepo) Consume(ctx context.Context, inp ConsumeOneTimeTokenInput) (domain.OneTimeToken, error) {
+	filter := bson.M{
+		"hash": inp.Hash, "purpose": inp.Purpose, "role": inp.Role,
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	if inp.SchoolID.IsZero() {
+		filter["schoolId"] = bson.M{"$exists": false}
+	} else {
+		filter["schoolId"] = inp.SchoolID
+	}
+
+	if !inp.OwnerID.IsZero() {
+		filter["ownerId"] = inp.OwnerID
+	}
+
+	var token domain.OneTimeToken
+	if err := r.db.FindOneAndDelete(ctx, filter).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.OneTimeToken{}, domain.ErrOneTimeTokenInvalid
+		}
+
+		return domain.OneTimeToken{}, err
+	}
+
+	return token, nil
+}
+
+func previousTokensFilter(token domain.OneTimeToken) bson.M {
+	switch {
+	case !token.OwnerID.IsZero():
+		return bson.M{"ownerId": token.OwnerID, "purpose": token.Purpose}
+	case token.Email != "" && !token.SchoolID.IsZero():
+		return bson.M{
+			"ownerId": primitive.NilObjectID, "schoolId": token.SchoolID,
+			"email": token.Email, "purpose": token.Purpose,
+		}
+	default:
+		return nil
+	}
+}
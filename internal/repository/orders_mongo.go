@@ -102,3 +102,26 @@ func (r *OrdersRepo) SetStatus(ctx context.Context, id primitive.ObjectID, statu
 
 	return err
 }
+
+// AnonymizeStudent erases personal data from student snapshots, student id is kept.
+func (r *OrdersRepo) AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	_, err := r.db.UpdateMany(ctx, bson.M{"student.id": studentId}, bson.M{"$set": bson.M{
+		"student.name":  domain.DeletedStudentName,
+		"student.email": "",
+	}})
+
+	return err
+}
+
+func (r *OrdersRepo) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) ([]domain.Order, error) {
+	var orders []domain.Order
+
+	cur, err := r.db.Find(ctx, bson.M{"schoolId": schoolId, "student.id": studentId})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &orders)
+
+	return orders, err
+}
@@ -14,12 +14,23 @@ import (
 
 //go:generate mockgen -source=repository.go -destination=mocks/mock.go
 
+// SetVerificationCodeInput is used to issue a new verification code. Code is replaced only if account is still unverified
+// and the previous one was sent before SentBefore, otherwise domain.ErrVerificationSentRecently is returned.
+type SetVerificationCodeInput struct {
+	Code       string
+	ExpiresAt  time.Time
+	SentAt     time.Time
+	SentBefore time.Time
+}
+
 type Users interface {
 	Create(ctx context.Context, user domain.User) error
-	GetByCredentials(ctx context.Context, email, password string) (domain.User, error)
-	GetByRefreshToken(ctx context.Context, refreshToken string) (domain.User, error)
+	GetByEmail(ctx context.Context, email string) (domain.User, error)
+	GetById(ctx context.Context, id primitive.ObjectID) (domain.User, error)
 	Verify(ctx context.Context, userID primitive.ObjectID, code string) error
-	SetSession(ctx context.Context, userID primitive.ObjectID, session domain.Session) error
+	SetVerificationCode(ctx context.Context, userID primitive.ObjectID, inp SetVerificationCodeInput) error
+	SetLastVisit(ctx context.Context, userID primitive.ObjectID) error
+	SetPassword(ctx context.Context, userID primitive.ObjectID, password string) error
 	AttachSchool(ctx context.Context, userID, schoolID primitive.ObjectID) error
 }
 
@@ -27,35 +38,161 @@ type Schools interface {
 	Create(ctx context.Context, name string) (primitive.ObjectID, error)
 	GetByDomain(ctx context.Context, domainName string) (domain.School, error)
 	GetById(ctx context.Context, id primitive.ObjectID) (domain.School, error)
+	GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error)
 	UpdateSettings(ctx context.Context, id primitive.ObjectID, inp domain.UpdateSchoolSettingsInput) error
 	SetFondyCredentials(ctx context.Context, id primitive.ObjectID, fondy domain.Fondy) error
+	SetOIDCProviders(ctx context.Context, id primitive.ObjectID, providers []domain.OIDCProvider) error
+	SetStudentFields(ctx context.Context, id primitive.ObjectID, fields []domain.StudentField) error
+}
+
+// SetStudentFieldsInput sets values of student's custom fields, keys from Unset are removed.
+type SetStudentFieldsInput struct {
+	StudentID primitive.ObjectID
+	SchoolID  primitive.ObjectID
+	Set       map[string]string
+	Unset     []string
 }
 
 type Students interface {
 	Create(ctx context.Context, student *domain.Student) error
 	Update(ctx context.Context, inp domain.UpdateStudentInput) error
 	Delete(ctx context.Context, schoolId, studentId primitive.ObjectID) error
-	GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Student, error)
-	GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Student, error)
+	GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Student, error)
+	GetByIdentity(ctx context.Context, schoolId primitive.ObjectID, identity domain.StudentIdentity) (domain.Student, error)
 	GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.Student, error)
 	GetBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery) ([]domain.Student, int64, error)
-	SetSession(ctx context.Context, studentId primitive.ObjectID, session domain.Session) error
+	IterateBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery, fn func(student domain.Student) error) error
+	SetLastVisit(ctx context.Context, studentId primitive.ObjectID) error
+	SetPassword(ctx context.Context, studentId primitive.ObjectID, password string) error
 	GiveAccessToModule(ctx context.Context, studentId, moduleId primitive.ObjectID) error
 	AttachOffer(ctx context.Context, studentId, offerId primitive.ObjectID, moduleIds []primitive.ObjectID) error
 	DetachOffer(ctx context.Context, studentId, offerId primitive.ObjectID, moduleIds []primitive.ObjectID) error
 	Verify(ctx context.Context, code string) (domain.Student, error)
+	SetVerificationCode(ctx context.Context, studentId primitive.ObjectID, inp SetVerificationCodeInput) error
+	LinkIdentity(ctx context.Context, studentId primitive.ObjectID, identity domain.StudentIdentity) error
+	MarkVerified(ctx context.Context, studentId primitive.ObjectID) error
+	AddTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error
+	RemoveTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error
+	SetFields(ctx context.Context, inp SetStudentFieldsInput) error
 }
 
 type StudentLessons interface {
 	AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error
 	SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID) error
+	GetByStudent(ctx context.Context, studentId primitive.ObjectID) (domain.StudentLessons, error)
+	GetByStudents(ctx context.Context, studentIds []primitive.ObjectID) ([]domain.StudentLessons, error)
+	GetByCourse(ctx context.Context, courseId primitive.ObjectID, lessonIds []primitive.ObjectID) ([]domain.StudentLessons, error)
+	AddCompleted(ctx context.Context, studentId primitive.ObjectID, completion domain.CourseCompletion) (bool, error)
 }
 
 type Admins interface {
-	GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Admin, error)
-	GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Admin, error)
-	SetSession(ctx context.Context, id primitive.ObjectID, session domain.Session) error
+	Create(ctx context.Context, admin *domain.Admin) error
+	GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Admin, error)
+	GetByUser(ctx context.Context, schoolId, userId primitive.ObjectID) (domain.Admin, error)
+	LinkUser(ctx context.Context, id, userId primitive.ObjectID) error
+	SetPassword(ctx context.Context, id primitive.ObjectID, password string) error
 	GetById(ctx context.Context, id primitive.ObjectID) (domain.Admin, error)
+	GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error)
+	CountOwners(ctx context.Context, schoolId primitive.ObjectID) (int64, error)
+	SetRole(ctx context.Context, schoolId, id primitive.ObjectID, role string) error
+	UpdateInvitation(ctx context.Context, inp UpdateAdminInvitationInput) error
+	AcceptInvitation(ctx context.Context, inp AcceptAdminInvitationInput) error
+	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
+	SetTwoFactorPendingSecret(ctx context.Context, id primitive.ObjectID, secret string) error
+	EnableTwoFactor(ctx context.Context, id primitive.ObjectID, secret string, recoveryCodes []string) error
+	DisableTwoFactor(ctx context.Context, id primitive.ObjectID) error
+	SetTwoFactorLastUsedStep(ctx context.Context, id primitive.ObjectID, step int64) error
+	UseTwoFactorRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) error
+}
+
+type UpdateAdminInvitationInput struct {
+	ID       primitive.ObjectID
+	SchoolID primitive.ObjectID
+	Name     string
+	Role     string
+}
+
+type AcceptAdminInvitationInput struct {
+	ID       primitive.ObjectID
+	SchoolID primitive.ObjectID
+	Name     string
+	Password string
+}
+
+type RotateSessionInput struct {
+	Role            string
+	SchoolID        primitive.ObjectID
+	RefreshToken    string
+	NewRefreshToken string
+	UserAgent       string
+	IP              string
+	ExpiresAt       time.Time
+}
+
+type Sessions interface {
+	Create(ctx context.Context, session domain.Session) (primitive.ObjectID, error)
+	Rotate(ctx context.Context, inp RotateSessionInput) (domain.Session, error)
+	DeleteByUsedToken(ctx context.Context, role, refreshToken string) error
+	GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error)
+	Delete(ctx context.Context, ownerId, id primitive.ObjectID) error
+	DeleteByOwner(ctx context.Context, ownerId primitive.ObjectID) error
+}
+
+// ConsumeOneTimeTokenInput matches token by owner only if OwnerID is set.
+type ConsumeOneTimeTokenInput struct {
+	Hash     string
+	Purpose  string
+	Role     string
+	SchoolID primitive.ObjectID
+	OwnerID  primitive.ObjectID
+}
+
+type OneTimeTokens interface {
+	Create(ctx context.Context, token domain.OneTimeToken) error
+	Consume(ctx context.Context, inp ConsumeOneTimeTokenInput) (domain.OneTimeToken, error)
+}
+
+type APIKeys interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error)
+	GetByHash(ctx context.Context, schoolId primitive.ObjectID, hash string) (domain.APIKey, error)
+	SetLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error
+	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
+}
+
+type StudentImportProgressInput struct {
+	Processed int
+	Succeeded int
+	Failed    int
+}
+
+type StudentImports interface {
+	Create(ctx context.Context, studentImport *domain.StudentImport) error
+	GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error)
+	SetProgress(ctx context.Context, id primitive.ObjectID, inp StudentImportProgressInput) error
+	Finish(ctx context.Context, studentImport domain.StudentImport) error
+}
+
+type StudentActivities interface {
+	Create(ctx context.Context, activity domain.StudentActivity) error
+	GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID, query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error)
+	DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error
+}
+
+type UpdateStudentSegmentInput struct {
+	ID       primitive.ObjectID
+	SchoolID primitive.ObjectID
+	Name     string
+	Search   string
+	Filters  domain.StudentFiltersQuery
+}
+
+type StudentSegments interface {
+	Create(ctx context.Context, segment *domain.StudentSegment) error
+	GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentSegment, error)
+	GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error)
+	Update(ctx context.Context, inp UpdateStudentSegmentInput) error
+	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
 }
 
 type UpdateCourseInput struct {
@@ -66,10 +203,12 @@ type UpdateCourseInput struct {
 	Description *string
 	Color       *string
 	Published   *bool
+	Gating      *string
 }
 
 type Courses interface {
 	Create(ctx context.Context, schoolId primitive.ObjectID, course domain.Course) (primitive.ObjectID, error)
+	GetById(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.Course, error)
 	Update(ctx context.Context, inp UpdateCourseInput) error
 	Delete(ctx context.Context, schoolId, courseId primitive.ObjectID) error
 }
@@ -80,6 +219,9 @@ type UpdateModuleInput struct {
 	Name      string
 	Position  *uint
 	Published *bool
+	PublishAt *time.Time
+	DripDays  *uint
+	Gating    *string
 }
 
 type UpdateLessonInput struct {
@@ -88,6 +230,8 @@ type UpdateLessonInput struct {
 	Name      string
 	Position  *uint
 	Published *bool
+	PublishAt *time.Time
+	DripDays  *uint
 }
 
 type Modules interface {
@@ -97,6 +241,7 @@ type Modules interface {
 	GetPublishedById(ctx context.Context, moduleID primitive.ObjectID) (domain.Module, error)
 	GetById(ctx context.Context, moduleID primitive.ObjectID) (domain.Module, error)
 	GetByPackages(ctx context.Context, packageIds []primitive.ObjectID) ([]domain.Module, error)
+	GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error)
 	Update(ctx context.Context, inp UpdateModuleInput) error
 	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
 	DeleteByCourse(ctx context.Context, schoolId, courseId primitive.ObjectID) error
@@ -108,15 +253,25 @@ type Modules interface {
 	AttachPackage(ctx context.Context, schoolId, packageId primitive.ObjectID, modules []primitive.ObjectID) error
 	AttachSurvey(ctx context.Context, schoolId, id primitive.ObjectID, survey domain.Survey) error
 	DetachSurvey(ctx context.Context, schoolId, id primitive.ObjectID) error
+	PublishScheduled(ctx context.Context) error
 }
 
 type LessonContent interface {
 	GetByLessons(ctx context.Context, lessonIds []primitive.ObjectID) ([]domain.LessonContent, error)
 	GetByLesson(ctx context.Context, lessonID primitive.ObjectID) (domain.LessonContent, error)
-	Update(ctx context.Context, schoolID, lessonID primitive.ObjectID, content string) error
+	NextVersion(ctx context.Context, schoolID, lessonID primitive.ObjectID) (domain.LessonContent, error)
+	Publish(ctx context.Context, schoolID, lessonID primitive.ObjectID, version int, blocks []domain.LessonBlock) error
+	SaveDraft(ctx context.Context, schoolID, lessonID primitive.ObjectID, draft domain.LessonDraft) error
 	DeleteContent(ctx context.Context, schoolID primitive.ObjectID, lessonIds []primitive.ObjectID) error
 }
 
+type LessonContentVersions interface {
+	Create(ctx context.Context, version domain.LessonContentVersion) error
+	GetByLesson(ctx context.Context, schoolID, lessonID primitive.ObjectID, query *domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error)
+	GetByVersion(ctx context.Context, schoolID, lessonID primitive.ObjectID, version int) (domain.LessonContentVersion, error)
+	DeleteByLessons(ctx context.Context, schoolID primitive.ObjectID, lessonIds []primitive.ObjectID) error
+}
+
 type UpdatePackageInput struct {
 	ID       primitive.ObjectID
 	SchoolID primitive.ObjectID
@@ -168,6 +323,8 @@ type Orders interface {
 	GetBySchool(ctx context.Context, schoolId primitive.ObjectID, pagination domain.GetOrdersQuery) ([]domain.Order, int64, error)
 	GetById(ctx context.Context, id primitive.ObjectID) (domain.Order, error)
 	SetStatus(ctx context.Context, id primitive.ObjectID, status string) error
+	AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error
+	GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) ([]domain.Order, error)
 }
 
 type Files interface {
@@ -182,41 +339,57 @@ type SurveyResults interface {
 	Save(ctx context.Context, results domain.SurveyResult) error
 	GetAllByModule(ctx context.Context, moduleId primitive.ObjectID, pagination *domain.PaginationQuery) ([]domain.SurveyResult, int64, error)
 	GetByStudent(ctx context.Context, moduleId, studentId primitive.ObjectID) (domain.SurveyResult, error)
+	GetAllByStudent(ctx context.Context, studentId primitive.ObjectID) ([]domain.SurveyResult, error)
+	AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error
 }
 
 type Repositories struct {
-	Schools        Schools
-	Students       Students
-	StudentLessons StudentLessons
-	Courses        Courses
-	Modules        Modules
-	Packages       Packages
-	LessonContent  LessonContent
-	Offers         Offers
-	PromoCodes     PromoCodes
-	Orders         Orders
-	Admins         Admins
-	Users          Users
-	Files          Files
-	SurveyResults  SurveyResults
+	Schools           Schools
+	Students          Students
+	StudentLessons    StudentLessons
+	Courses           Courses
+	Modules           Modules
+	Packages          Packages
+	LessonContent     LessonContent
+	LessonVersions    LessonContentVersions
+	Offers            Offers
+	PromoCodes        PromoCodes
+	Orders            Orders
+	Admins            Admins
+	Users             Users
+	Files             Files
+	SurveyResults     SurveyResults
+	Sessions          Sessions
+	OneTimeTokens     OneTimeTokens
+	APIKeys           APIKeys
+	StudentImports    StudentImports
+	StudentSegments   StudentSegments
+	StudentActivities StudentActivities
 }
 
 func NewRepositories(db *mongo.Database) *Repositories {
 	return &Repositories{
-		Schools:        NewSchoolsRepo(db),
-		Students:       NewStudentsRepo(db),
-		StudentLessons: NewStudentLessonsRepo(db),
-		Courses:        NewCoursesRepo(db),
-		Modules:        NewModulesRepo(db),
-		LessonContent:  NewLessonContentRepo(db),
-		Offers:         NewOffersRepo(db),
-		PromoCodes:     NewPromocodeRepo(db),
-		Orders:         NewOrdersRepo(db),
-		Admins:         NewAdminsRepo(db),
-		Packages:       NewPackagesRepo(db),
-		Users:          NewUsersRepo(db),
-		Files:          NewFilesRepo(db),
-		SurveyResults:  NewSurveyResultsRepo(db),
+		Schools:           NewSchoolsRepo(db),
+		Students:          NewStudentsRepo(db),
+		StudentLessons:    NewStudentLessonsRepo(db),
+		Courses:           NewCoursesRepo(db),
+		Modules:           NewModulesRepo(db),
+		LessonContent:     NewLessonContentRepo(db),
+		LessonVersions:    NewLessonContentVersionsRepo(db),
+		Offers:            NewOffersRepo(db),
+		PromoCodes:        NewPromocodeRepo(db),
+		Orders:            NewOrdersRepo(db),
+		Admins:            NewAdminsRepo(db),
+		Packages:          NewPackagesRepo(db),
+		Users:             NewUsersRepo(db),
+		Files:             NewFilesRepo(db),
+		SurveyResults:     NewSurveyResultsRepo(db),
+		Sessions:          NewSessionsRepo(db),
+		OneTimeTokens:     NewOneTimeTokensRepo(db),
+		APIKeys:           NewAPIKeysRepo(db),
+		StudentImports:    NewStudentImportsRepo(db),
+		StudentSegments:   NewStudentSegmentsRepo(db),
+		StudentActivities: NewStudentActivitiesRepo(db),
 	}
 }
 
@@ -43,6 +43,19 @@ func (r *SchoolsRepo) GetById(ctx context.Context, id primitive.ObjectID) (domai
 	return school, err
 }
 
+func (r *SchoolsRepo) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error) {
+	var schools []domain.School
+
+	cur, err := r.db.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &schools)
+
+	return schools, err
+}
+
 func (r *SchoolsRepo) UpdateSettings(ctx context.Context, id primitive.ObjectID, inp domain.UpdateSchoolSettingsInput) error {
 	updateQuery := bson.M{}
 
@@ -78,6 +91,10 @@ func (r *SchoolsRepo) UpdateSettings(ctx context.Context, id primitive.ObjectID,
 		updateQuery["settings.disableRegistration"] = inp.DisableRegistration
 	}
 
+	if inp.MagicLinkLogin != nil {
+		updateQuery["settings.magicLinkLogin"] = inp.MagicLinkLogin
+	}
+
 	if inp.GoogleAnalyticsCode != nil {
 		updateQuery["settings.googleAnalyticsCode"] = *inp.GoogleAnalyticsCode
 	}
@@ -98,6 +115,18 @@ func (r *SchoolsRepo) SetFondyCredentials(ctx context.Context, id primitive.Obje
 	return err
 }
 
+func (r *SchoolsRepo) SetOIDCProviders(ctx context.Context, id primitive.ObjectID, providers []domain.OIDCProvider) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"settings.oidcProviders": providers}})
+
+	return err
+}
+
+func (r *SchoolsRepo) SetStudentFields(ctx context.Context, id primitive.ObjectID, fields []domain.StudentField) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"settings.studentFields": fields}})
+
+	return err
+}
+
 func setContactInfoUpdateQuery(updateQuery *bson.M, inp domain.UpdateSchoolSettingsInput) {
 	if inp.ContactInfo.Address != nil {
 		(*updateQuery)["settings.contactInfo.address"] = inp.ContactInfo.Address
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// usedTokensLimit is the number of rotated refresh tokens kept per session for reuse detection.
+const usedTokensLimit = 50
+
+type SessionsRepo struct {
+	db *mongo.Collection
+}
+
+func NewSessionsRepo(db *mongo.Database) *SessionsRepo {
+	return &SessionsRepo{db: db.Collection(sessionsCollection)}
+}
+
+func (r *SessionsRepo) Create(ctx context.Context, session domain.Session) (primitive.ObjectID, error) {
+	res, err := r.db.InsertOne(ctx, session)
+	if err != nil {
+		return primitive.ObjectID{}, err
+	}
+
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *SessionsRepo) Rotate(ctx context.Context, inp RotateSessionInput) (domain.Session, error) {
+	filter := bson.M{
+		"refreshToken": inp.RefreshToken, "role": inp.Role, "schoolId": inp.SchoolID,
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	if inp.SchoolID.IsZero() {
+		filter["schoolId"] = bson.M{"$exists": false}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"refreshToken": inp.NewRefreshToken,
+			"userAgent":    inp.UserAgent,
+			"ip":           inp.IP,
+			"lastUsedAt":   time.Now(),
+			"expiresAt":    inp.ExpiresAt,
+		},
+		"$push": bson.M{
+			"usedTokens": bson.M{"$each": []string{inp.RefreshToken}, "$slice": -usedTokensLimit},
+		},
+	}
+
+	var session domain.Session
+	if err := r.db.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).
+		Decode(&session); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Session{}, domain.ErrSessionNotFound
+		}
+
+		return domain.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionsRepo) DeleteByUsedToken(ctx context.Context, role, refreshToken string) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"usedTokens": refreshToken, "role": role})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionsRepo) GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error) {
+	opts := options.Find().SetSort(bson.M{"lastUsedAt": -1})
+
+	cur, err := r.db.Find(ctx, bson.M{"ownerId": ownerId, "expiresAt": bson.M{"$gt": time.Now()}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []domain.Session
+	err = cur.All(ctx, &sessions)
+
+	return sessions, err
+}
+
+func (r *SessionsRepo) Delete(ctx context.Context, ownerId, id primitive.ObjectID) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "ownerId": ownerId})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionsRepo) DeleteByOwner(ctx context.Context, ownerId primitive.ObjectID) error {
+	_, err := r.db.DeleteMany(ctx, bson.M{"ownerId": ownerId})
+
+	return err
+}
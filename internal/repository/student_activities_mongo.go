@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type StudentActivitiesRepo struct {
+	db *mongo.Collection
+}
+
+func NewStudentActivitiesRepo(db *mongo.Database) *StudentActivitiesRepo {
+	return &StudentActivitiesRepo{db: db.Collection(studentActivitiesCollection)}
+}
+
+func (r *StudentActivitiesRepo) Create(ctx context.Context, activity domain.StudentActivity) error {
+	_, err := r.db.InsertOne(ctx, activity)
+
+	return err
+}
+
+func (r *StudentActivitiesRepo) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID,
+	query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error) {
+	paginationOpts := getPaginationOpts(&query.PaginationQuery)
+	paginationOpts.SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+
+	filter := bson.M{"schoolId": schoolId, "studentId": studentId}
+	if len(query.Types) > 0 {
+		filter["type"] = bson.M{"$in": query.Types}
+	}
+
+	cur, err := r.db.Find(ctx, filter, paginationOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var activities []domain.StudentActivity
+	if err := cur.All(ctx, &activities); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := r.db.CountDocuments(ctx, filter)
+
+	return activities, count, err
+}
+
+func (r *StudentActivitiesRepo) DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	_, err := r.db.DeleteMany(ctx, bson.M{"studentId": studentId})
+
+	return err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type StudentImportsRepo struct {
+	db *mongo.Collection
+}
+
+func NewStudentImportsRepo(db *mongo.Database) *StudentImportsRepo {
+	return &StudentImportsRepo{db: db.Collection(studentImportsCollection)}
+}
+
+func (r *StudentImportsRepo) Create(ctx context.Context, studentImport *domain.StudentImport) error {
+	res, err := r.db.InsertOne(ctx, studentImport)
+	if err != nil {
+		return err
+	}
+
+	studentImport.ID = res.InsertedID.(primitive.ObjectID) //nolint:forcetypeassert
+
+	return nil
+}
+
+func (r *StudentImportsRepo) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error) {
+	var studentImport domain.StudentImport
+	if err := r.db.FindOne(ctx, bson.M{"_id": id, "schoolId": schoolId}).Decode(&studentImport); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.StudentImport{}, domain.ErrStudentImportNotFound
+		}
+
+		return domain.StudentImport{}, err
+	}
+
+	return studentImport, nil
+}
+
+func (r *StudentImportsRepo) SetProgress(ctx context.Context, id primitive.ObjectID, inp StudentImportProgressInput) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"processed": inp.Processed,
+		"succeeded": inp.Succeeded,
+		"failed":    inp.Failed,
+	}})
+
+	return err
+}
+
+func (r *StudentImportsRepo) Finish(ctx context.Context, studentImport domain.StudentImport) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentImport.ID}, bson.M{"$set": bson.M{
+		"status":     studentImport.Status,
+		"processed":  studentImport.Processed,
+		"succeeded":  studentImport.Succeeded,
+		"failed":     studentImport.Failed,
+		"error":      studentImport.Error,
+		"rows":       studentImport.Rows,
+		"finishedAt": time.Now(),
+	}})
+
+	return err
+}
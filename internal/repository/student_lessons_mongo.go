@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -34,3 +37,63 @@ func (r *StudentLessonsRepo) SetLastOpened(ctx context.Context, studentID, lesso
 
 	return err
 }
+
+// GetByStudent returns student's progress, it's empty if student haven't opened any lesson yet.
+func (r *StudentLessonsRepo) GetByStudent(ctx context.Context, studentID primitive.ObjectID) (domain.StudentLessons, error) {
+	var lessons domain.StudentLessons
+	if err := r.db.FindOne(ctx, bson.M{"studentId": studentID}).Decode(&lessons); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.StudentLessons{StudentID: studentID}, nil
+		}
+
+		return domain.StudentLessons{}, err
+	}
+
+	return lessons, nil
+}
+
+func (r *StudentLessonsRepo) GetByStudents(ctx context.Context, studentIds []primitive.ObjectID) ([]domain.StudentLessons, error) {
+	cur, err := r.db.Find(ctx, bson.M{"studentId": bson.M{"$in": studentIds}})
+	if err != nil {
+		return nil, err
+	}
+
+	var lessons []domain.StudentLessons
+	err = cur.All(ctx, &lessons)
+
+	return lessons, err
+}
+
+// GetByCourse returns progress of students, who finished any of the course lessons or completed the course.
+func (r *StudentLessonsRepo) GetByCourse(ctx context.Context, courseId primitive.ObjectID,
+	lessonIds []primitive.ObjectID) ([]domain.StudentLessons, error) {
+	if lessonIds == nil {
+		lessonIds = []primitive.ObjectID{}
+	}
+
+	cur, err := r.db.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"finished": bson.M{"$in": lessonIds}},
+		bson.M{"completed.courseId": courseId},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	var lessons []domain.StudentLessons
+	err = cur.All(ctx, &lessons)
+
+	return lessons, err
+}
+
+// AddCompleted records course completion, it returns false if the course was already completed.
+func (r *StudentLessonsRepo) AddCompleted(ctx context.Context, studentID primitive.ObjectID, completion domain.CourseCompletion) (bool, error) {
+	filter := bson.M{"studentId": studentID, "completed.courseId": bson.M{"$ne": completion.CourseID}}
+	update := bson.M{"$push": bson.M{"completed": completion}}
+
+	res, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
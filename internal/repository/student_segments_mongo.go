@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StudentSegmentsRepo struct {
+	db *mongo.Collection
+}
+
+func NewStudentSegmentsRepo(db *mongo.Database) *StudentSegmentsRepo {
+	return &StudentSegmentsRepo{db: db.Collection(studentSegmentsCollection)}
+}
+
+func (r *StudentSegmentsRepo) Create(ctx context.Context, segment *domain.StudentSegment) error {
+	res, err := r.db.InsertOne(ctx, segment)
+	if err != nil {
+		return err
+	}
+
+	segment.ID = res.InsertedID.(primitive.ObjectID) //nolint:forcetypeassert
+
+	return nil
+}
+
+func (r *StudentSegmentsRepo) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentSegment, error) {
+	var segment domain.StudentSegment
+	if err := r.db.FindOne(ctx, bson.M{"_id": id, "schoolId": schoolId}).Decode(&segment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.StudentSegment{}, domain.ErrStudentSegmentNotFound
+		}
+
+		return domain.StudentSegment{}, err
+	}
+
+	return segment, nil
+}
+
+func (r *StudentSegmentsRepo) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error) {
+	var segments []domain.StudentSegment
+
+	cur, err := r.db.Find(ctx, bson.M{"schoolId": schoolId}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &segments)
+
+	return segments, err
+}
+
+func (r *StudentSegmentsRepo) Update(ctx context.Context, inp UpdateStudentSegmentInput) error {
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": inp.ID, "schoolId": inp.SchoolID}, bson.M{"$set": bson.M{
+		"name":      inp.Name,
+		"search":    inp.Search,
+		"filters":   inp.Filters,
+		"updatedAt": time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrStudentSegmentNotFound
+	}
+
+	return nil
+}
+
+func (r *StudentSegmentsRepo) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "schoolId": schoolId})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrStudentSegmentNotFound
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type StudentsRepo struct {
@@ -66,26 +67,9 @@ func (r *StudentsRepo) Delete(ctx context.Context, schoolId, studentId primitive
 	return err
 }
 
-func (r *StudentsRepo) GetByCredentials(ctx context.Context, schoolId primitive.ObjectID, email, password string) (domain.Student, error) {
+func (r *StudentsRepo) GetByEmail(ctx context.Context, schoolId primitive.ObjectID, email string) (domain.Student, error) {
 	var student domain.Student
-	if err := r.db.FindOne(ctx, bson.M{"email": email, "password": password, "schoolId": schoolId, "verification.verified": true}).
-		Decode(&student); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return domain.Student{}, domain.ErrUserNotFound
-		}
-
-		return domain.Student{}, err
-	}
-
-	return student, nil
-}
-
-func (r *StudentsRepo) GetByRefreshToken(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (domain.Student, error) {
-	var student domain.Student
-	if err := r.db.FindOne(ctx, bson.M{
-		"session.refreshToken": refreshToken, "schoolId": schoolId,
-		"session.expiresAt": bson.M{"$gt": time.Now()},
-	}).Decode(&student); err != nil {
+	if err := r.db.FindOne(ctx, bson.M{"email": email, "schoolId": schoolId}).Decode(&student); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.Student{}, domain.ErrUserNotFound
 		}
@@ -114,10 +98,75 @@ func (r *StudentsRepo) GetBySchool(ctx context.Context, schoolId primitive.Objec
 	paginationOpts := getPaginationOpts(&query.PaginationQuery)
 	paginationOpts.SetSort(bson.M{"registeredAt": -1})
 
+	filter, err := studentsFilter(schoolId, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cur, err := r.db.Find(ctx, filter, paginationOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var students []domain.Student
+	if err := cur.All(ctx, &students); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := r.db.CountDocuments(ctx, filter)
+
+	return students, count, err
+}
+
+// IterateBySchool calls fn for every student matching the query filters, pagination is ignored.
+// Students are read from cursor one by one, so the whole list is never kept in memory.
+func (r *StudentsRepo) IterateBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery,
+	fn func(student domain.Student) error) error {
+	filter, err := studentsFilter(schoolId, query)
+	if err != nil {
+		return err
+	}
+
+	cur, err := r.db.Find(ctx, filter, options.Find().SetSort(bson.M{"registeredAt": -1}))
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var student domain.Student
+		if err := cur.Decode(&student); err != nil {
+			return err
+		}
+
+		if err := fn(student); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func studentsFilter(schoolId primitive.ObjectID, query domain.GetStudentsQuery) (bson.M, error) {
 	filter := bson.M{"$and": []bson.M{{"schoolId": schoolId}}}
 
-	if query.Search != "" {
-		expression := primitive.Regex{Pattern: query.Search}
+	if err := appendStudentFilters(filter, query.Search, query.StudentFiltersQuery); err != nil {
+		return nil, err
+	}
+
+	if query.SegmentFilters != nil {
+		if err := appendStudentFilters(filter, query.SegmentFilters.Search, query.SegmentFilters.Filters); err != nil {
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
+
+func appendStudentFilters(filter bson.M, search string, filters domain.StudentFiltersQuery) error {
+	if search != "" {
+		expression := primitive.Regex{Pattern: search}
 
 		filter["$and"] = append(filter["$and"].([]bson.M), bson.M{
 			"$or": []bson.M{
@@ -127,37 +176,133 @@ func (r *StudentsRepo) GetBySchool(ctx context.Context, schoolId primitive.Objec
 		})
 	}
 
-	if query.Verified != nil {
+	if filters.Verified != nil {
 		filter["$and"] = append(filter["$and"].([]bson.M), bson.M{
-			"verification.verified": *query.Verified,
+			"verification.verified": *filters.Verified,
 		})
 	}
 
-	if err := filterDateQueries(query.RegisterDateFrom, query.RegisterDateTo, "registeredAt", filter); err != nil {
-		return nil, 0, err
+	if len(filters.Tags) > 0 {
+		filter["$and"] = append(filter["$and"].([]bson.M), bson.M{
+			"tags": bson.M{"$all": filters.Tags},
+		})
 	}
 
-	if err := filterDateQueries(query.LastVisitDateFrom, query.LastVisitDateTo, "lastVisitAt", filter); err != nil {
-		return nil, 0, err
+	fields, err := filters.FieldFilters()
+	if err != nil {
+		return err
 	}
 
-	cur, err := r.db.Find(ctx, filter, paginationOpts)
+	for key, value := range fields {
+		filter["$and"] = append(filter["$and"].([]bson.M), bson.M{
+			"fields." + key: value,
+		})
+	}
+
+	if err := filterDateQueries(filters.RegisterDateFrom, filters.RegisterDateTo, "registeredAt", filter); err != nil {
+		return err
+	}
+
+	return filterDateQueries(filters.LastVisitDateFrom, filters.LastVisitDateTo, "lastVisitAt", filter)
+}
+
+func (r *StudentsRepo) AddTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error {
+	_, err := r.db.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": studentIds}, "schoolId": schoolId},
+		bson.M{"$addToSet": bson.M{"tags": bson.M{"$each": tags}}})
+
+	return err
+}
+
+func (r *StudentsRepo) RemoveTags(ctx context.Context, schoolId primitive.ObjectID, studentIds []primitive.ObjectID, tags []string) error {
+	_, err := r.db.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": studentIds}, "schoolId": schoolId},
+		bson.M{"$pull": bson.M{"tags": bson.M{"$in": tags}}})
+
+	return err
+}
+
+func (r *StudentsRepo) SetFields(ctx context.Context, inp SetStudentFieldsInput) error {
+	updateQuery := bson.M{}
+
+	if len(inp.Set) > 0 {
+		set := bson.M{}
+		for key, value := range inp.Set {
+			set["fields."+key] = value
+		}
+
+		updateQuery["$set"] = set
+	}
+
+	if len(inp.Unset) > 0 {
+		unset := bson.M{}
+		for _, key := range inp.Unset {
+			unset["fields."+key] = ""
+		}
+
+		updateQuery["$unset"] = unset
+	}
+
+	if len(updateQuery) == 0 {
+		return nil
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": inp.StudentID, "schoolId": inp.SchoolID}, updateQuery)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 
-	var students []domain.Student
-	if err := cur.All(ctx, &students); err != nil {
-		return nil, 0, err
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
 	}
 
-	count, err := r.db.CountDocuments(ctx, filter)
+	return nil
+}
 
-	return students, count, err
+func (r *StudentsRepo) SetLastVisit(ctx context.Context, studentID primitive.ObjectID) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$set": bson.M{"lastVisitAt": time.Now()}})
+
+	return err
+}
+
+func (r *StudentsRepo) GetByIdentity(ctx context.Context, schoolId primitive.ObjectID, identity domain.StudentIdentity) (domain.Student, error) {
+	var student domain.Student
+	if err := r.db.FindOne(ctx, bson.M{
+		"schoolId":   schoolId,
+		"identities": bson.M{"$elemMatch": bson.M{"provider": identity.Provider, "subject": identity.Subject}},
+	}).Decode(&student); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Student{}, domain.ErrUserNotFound
+		}
+
+		return domain.Student{}, err
+	}
+
+	return student, nil
+}
+
+// LinkIdentity attaches provider's account to the student. Provider has verified the email,
+// so pending verification is completed as well.
+func (r *StudentsRepo) LinkIdentity(ctx context.Context, studentId primitive.ObjectID, identity domain.StudentIdentity) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentId}, bson.M{
+		"$addToSet": bson.M{"identities": identity},
+		"$set":      bson.M{"verification.verified": true, "verification.code": ""},
+		"$unset":    bson.M{"verification.expiresAt": ""},
+	})
+
+	return err
+}
+
+// MarkVerified completes pending verification, when student has proven the email some other way.
+func (r *StudentsRepo) MarkVerified(ctx context.Context, studentId primitive.ObjectID) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentId}, bson.M{
+		"$set":   bson.M{"verification.verified": true, "verification.code": ""},
+		"$unset": bson.M{"verification.expiresAt": ""},
+	})
+
+	return err
 }
 
-func (r *StudentsRepo) SetSession(ctx context.Context, studentID primitive.ObjectID, session domain.Session) error {
-	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$set": bson.M{"session": session, "lastVisitAt": time.Now()}})
+func (r *StudentsRepo) SetPassword(ctx context.Context, studentID primitive.ObjectID, password string) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$set": bson.M{"password": password}})
 
 	return err
 }
@@ -169,28 +314,54 @@ func (r *StudentsRepo) GiveAccessToModule(ctx context.Context, studentID, module
 }
 
 func (r *StudentsRepo) AttachOffer(ctx context.Context, studentID, offerID primitive.ObjectID, moduleIds []primitive.ObjectID) error {
-	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$addToSet": bson.M{
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$addToSet": bson.M{
 		"availableModules": bson.M{"$each": moduleIds},
 		"availableOffers":  offerID,
 	}})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	// access time is kept from the first grant, so drip content isn't locked again after re-purchase
+	_, err = r.db.UpdateOne(ctx, bson.M{"_id": studentID, "offersAccess.offerId": bson.M{"$ne": offerID}},
+		bson.M{"$push": bson.M{"offersAccess": domain.OfferAccess{OfferID: offerID, GrantedAt: time.Now()}}})
 
 	return err
 }
 
 func (r *StudentsRepo) DetachOffer(ctx context.Context, studentID, offerID primitive.ObjectID, moduleIds []primitive.ObjectID) error {
-	_, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$pull": bson.M{
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": studentID}, bson.M{"$pull": bson.M{
 		"availableModules": bson.M{"$in": moduleIds},
 		"availableOffers":  offerID,
+		"offersAccess":     bson.M{"offerId": offerID},
 	}})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
 }
 
 func (r *StudentsRepo) Verify(ctx context.Context, code string) (domain.Student, error) {
 	res := r.db.FindOneAndUpdate(ctx,
-		bson.M{"verification.code": code},
-		bson.M{"$set": bson.M{"verification.verified": true, "verification.code": ""}})
+		verificationCodeFilter(code),
+		bson.M{
+			"$set":   bson.M{"verification.verified": true, "verification.code": ""},
+			"$unset": bson.M{"verification.expiresAt": ""},
+		})
 	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return domain.Student{}, verificationCodeError(ctx, r.db, bson.M{"verification.code": code})
+		}
+
 		return domain.Student{}, res.Err()
 	}
 
@@ -199,3 +370,58 @@ func (r *StudentsRepo) Verify(ctx context.Context, code string) (domain.Student,
 
 	return student, err
 }
+
+func (r *StudentsRepo) SetVerificationCode(ctx context.Context, studentId primitive.ObjectID, inp SetVerificationCodeInput) error {
+	return setVerificationCode(ctx, r.db, studentId, inp)
+}
+
+// verificationCodeFilter matches accounts with given unexpired verification code, it's used for students and users.
+// Codes issued before expiration was introduced don't have expiresAt and are still accepted.
+func verificationCodeFilter(code string) bson.M {
+	return bson.M{
+		"verification.code": code,
+		"$or": []bson.M{
+			{"verification.expiresAt": bson.M{"$gt": time.Now()}},
+			{"verification.expiresAt": bson.M{"$exists": false}},
+		},
+	}
+}
+
+// verificationCodeError is called when verificationCodeFilter didn't match any account
+// to tell an expired code from an unknown one.
+func verificationCodeError(ctx context.Context, db *mongo.Collection, filter bson.M) error {
+	count, err := db.CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return domain.ErrVerificationCodeExpired
+	}
+
+	return domain.ErrVerificationCodeInvalid
+}
+
+func setVerificationCode(ctx context.Context, db *mongo.Collection, id primitive.ObjectID, inp SetVerificationCodeInput) error {
+	res, err := db.UpdateOne(ctx, bson.M{
+		"_id":                   id,
+		"verification.verified": false,
+		"$or": []bson.M{
+			{"verification.sentAt": bson.M{"$lt": inp.SentBefore}},
+			{"verification.sentAt": bson.M{"$exists": false}},
+		},
+	}, bson.M{"$set": bson.M{
+		"verification.code":      inp.Code,
+		"verification.expiresAt": inp.ExpiresAt,
+		"verification.sentAt":    inp.SentAt,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if res.ModifiedCount == 0 {
+		return domain.ErrVerificationSentRecently
+	}
+
+	return nil
+}
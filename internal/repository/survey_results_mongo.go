@@ -50,3 +50,26 @@ func (r *SurveyResultsRepo) GetByStudent(ctx context.Context, moduleID, studentI
 
 	return res, err
 }
+
+func (r *SurveyResultsRepo) GetAllByStudent(ctx context.Context, studentID primitive.ObjectID) ([]domain.SurveyResult, error) {
+	var results []domain.SurveyResult
+
+	cur, err := r.db.Find(ctx, bson.M{"student.id": studentID})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cur.All(ctx, &results)
+
+	return results, err
+}
+
+// AnonymizeStudent erases personal data from student snapshots, student id is kept.
+func (r *SurveyResultsRepo) AnonymizeStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	_, err := r.db.UpdateMany(ctx, bson.M{"student.id": studentId}, bson.M{"$set": bson.M{
+		"student.name":  domain.DeletedStudentName,
+		"student.email": "",
+	}})
+
+	return err
+}
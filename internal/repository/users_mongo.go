@@ -31,9 +31,9 @@ func (r *UsersRepo) Create(ctx context.Context, user domain.User) error {
 	return err
 }
 
-func (r *UsersRepo) GetByCredentials(ctx context.Context, email, password string) (domain.User, error) {
+func (r *UsersRepo) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	var user domain.User
-	if err := r.db.FindOne(ctx, bson.M{"email": email, "password": password}).Decode(&user); err != nil {
+	if err := r.db.FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.User{}, domain.ErrUserNotFound
 		}
@@ -44,12 +44,9 @@ func (r *UsersRepo) GetByCredentials(ctx context.Context, email, password string
 	return user, nil
 }
 
-func (r *UsersRepo) GetByRefreshToken(ctx context.Context, refreshToken string) (domain.User, error) {
+func (r *UsersRepo) GetById(ctx context.Context, id primitive.ObjectID) (domain.User, error) {
 	var user domain.User
-	if err := r.db.FindOne(ctx, bson.M{
-		"session.refreshToken": refreshToken,
-		"session.expiresAt":    bson.M{"$gt": time.Now()},
-	}).Decode(&user); err != nil {
+	if err := r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&user); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.User{}, domain.ErrUserNotFound
 		}
@@ -61,22 +58,36 @@ func (r *UsersRepo) GetByRefreshToken(ctx context.Context, refreshToken string)
 }
 
 func (r *UsersRepo) Verify(ctx context.Context, userID primitive.ObjectID, code string) error {
-	res, err := r.db.UpdateOne(ctx,
-		bson.M{"verification.code": code, "_id": userID},
-		bson.M{"$set": bson.M{"verification.verified": true, "verification.code": ""}})
+	filter := verificationCodeFilter(code)
+	filter["_id"] = userID
+
+	res, err := r.db.UpdateOne(ctx, filter, bson.M{
+		"$set":   bson.M{"verification.verified": true, "verification.code": ""},
+		"$unset": bson.M{"verification.expiresAt": ""},
+	})
 	if err != nil {
 		return err
 	}
 
 	if res.ModifiedCount == 0 {
-		return domain.ErrVerificationCodeInvalid
+		return verificationCodeError(ctx, r.db, bson.M{"_id": userID, "verification.code": code})
 	}
 
 	return nil
 }
 
-func (r *UsersRepo) SetSession(ctx context.Context, userID primitive.ObjectID, session domain.Session) error {
-	_, err := r.db.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"session": session, "lastVisitAt": time.Now()}})
+func (r *UsersRepo) SetVerificationCode(ctx context.Context, userID primitive.ObjectID, inp SetVerificationCodeInput) error {
+	return setVerificationCode(ctx, r.db, userID, inp)
+}
+
+func (r *UsersRepo) SetLastVisit(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"lastVisitAt": time.Now()}})
+
+	return err
+}
+
+func (r *UsersRepo) SetPassword(ctx context.Context, userID primitive.ObjectID, password string) error {
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"password": password}})
 
 	return err
 }
@@ -3,58 +3,298 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
 	"github.com/zhashkevych/creatly-backend/pkg/auth"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type AdminsService struct {
-	hasher       hash.PasswordHasher
-	tokenManager auth.TokenManager
+const (
+	twoFactorSecretLength         = 32
+	twoFactorChallengeTokenLength = 32
+	twoFactorChallengeTTL         = 5 * time.Minute
+	twoFactorRecoveryCodesCount   = 10
+	twoFactorRecoveryCodeLength   = 10
+)
 
-	repo        repository.Admins
-	schoolRepo  repository.Schools
-	studentRepo repository.Students
+type AdminsService struct {
+	hasher                hash.PasswordHasher
+	dummyPassword         *dummyPassword
+	otpGenerator          otp.Generator
+	totp                  otp.TOTP
+	sessionsService       Sessions
+	passwordResetsService PasswordResets
+	signInAttemptsService SignInAttempts
+	emailService          Emails
 
-	accessTokenTTL  time.Duration
-	refreshTokenTTL time.Duration
+	repo              repository.Admins
+	schoolRepo        repository.Schools
+	studentRepo       repository.Students
+	oneTimeTokensRepo repository.OneTimeTokens
 }
 
-func NewAdminsService(hasher hash.PasswordHasher, tokenManager auth.TokenManager,
-	repo repository.Admins, schoolRepo repository.Schools, studentRepo repository.Students,
-	accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *AdminsService {
+func NewAdminsService(hasher hash.PasswordHasher, otpGenerator otp.Generator, totp otp.TOTP, sessionsService Sessions,
+	passwordResetsService PasswordResets, signInAttemptsService SignInAttempts, emailService Emails, repo repository.Admins, schoolRepo repository.Schools,
+	studentRepo repository.Students, oneTimeTokensRepo repository.OneTimeTokens) *AdminsService {
 	return &AdminsService{
-		hasher:          hasher,
-		tokenManager:    tokenManager,
-		repo:            repo,
-		schoolRepo:      schoolRepo,
-		studentRepo:     studentRepo,
-		accessTokenTTL:  accessTokenTTL,
-		refreshTokenTTL: refreshTokenTTL,
+		hasher:                hasher,
+		dummyPassword:         newDummyPassword(hasher),
+		otpGenerator:          otpGenerator,
+		totp:                  totp,
+		sessionsService:       sessionsService,
+		passwordResetsService: passwordResetsService,
+		signInAttemptsService: signInAttemptsService,
+		emailService:          emailService,
+		repo:                  repo,
+		schoolRepo:            schoolRepo,
+		studentRepo:           studentRepo,
+		oneTimeTokensRepo:     oneTimeTokensRepo,
 	}
 }
 
-func (s *AdminsService) SignIn(ctx context.Context, input SchoolSignInInput) (Tokens, error) {
-	// student, err := s.repo.GetByCredentials(ctx, input.SchoolID, input.Email, s.hasher.Hash(input.Password))
-	student, err := s.repo.GetByCredentials(ctx, input.SchoolID, input.Email, input.Password) // TODO implement password hashing
+func (s *AdminsService) SignIn(ctx context.Context, input SchoolSignInInput) (AdminSignInResult, error) {
+	var admin domain.Admin
+
+	attemptsKey := SignInAttemptsKey{Role: domain.RoleAdmin, SchoolID: input.SchoolID, Email: input.Email}
+
+	if err := withSignInAttempts(s.signInAttemptsService, attemptsKey, func() error {
+		var err error
+
+		admin, err = s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				s.dummyPassword.Verify(input.Password)
+			}
+
+			return err
+		}
+
+		return verifyPassword(s.hasher, input.Password, admin.Password, func(passwordHash string) error {
+			return s.repo.SetPassword(ctx, admin.ID, passwordHash)
+		})
+	}, func(lockedUntil time.Time) {
+		s.notifyAccountLocked(admin, lockedUntil, input.SchoolDomain)
+	}); err != nil {
+		return AdminSignInResult{}, err
+	}
+
+	if admin.TwoFactor.Enabled {
+		challengeToken, err := s.createTwoFactorChallenge(ctx, admin.ID, input.SchoolID)
+
+		return AdminSignInResult{ChallengeToken: challengeToken}, err
+	}
+
+	tokens, err := s.createSession(ctx, admin.ID, input.SchoolID, input.SchoolDomain, input.Device)
+
+	return AdminSignInResult{Tokens: tokens}, err
+}
+
+func (s *AdminsService) SignInTwoFactor(ctx context.Context, input AdminTwoFactorSignInInput) (Tokens, error) {
+	// challenge is consumed on first attempt, so each password check allows only one guess of the code
+	challenge, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(input.ChallengeToken),
+		Purpose:  domain.TokenPurposeTwoFactorChallenge,
+		Role:     domain.RoleAdmin,
+		SchoolID: input.SchoolID,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	admin, err := s.repo.GetById(ctx, challenge.OwnerID)
 	if err != nil {
 		return Tokens{}, err
 	}
 
-	return s.createSession(ctx, student.ID)
+	if err := s.verifyTwoFactorCode(ctx, admin, input.Code); err != nil {
+		return Tokens{}, err
+	}
+
+	return s.createSession(ctx, admin.ID, input.SchoolID, input.SchoolDomain, input.Device)
 }
 
-func (s *AdminsService) RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (Tokens, error) {
-	student, err := s.repo.GetByRefreshToken(ctx, schoolId, refreshToken)
+func (s *AdminsService) EnrollTwoFactor(ctx context.Context, adminId primitive.ObjectID, issuer string) (TwoFactorEnrollment, error) {
+	admin, err := s.repo.GetById(ctx, adminId)
 	if err != nil {
-		return Tokens{}, err
+		return TwoFactorEnrollment{}, err
 	}
 
-	return s.createSession(ctx, student.ID)
+	if admin.TwoFactor.Enabled {
+		return TwoFactorEnrollment{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret := s.otpGenerator.RandomSecret(twoFactorSecretLength)
+	if err := s.repo.SetTwoFactorPendingSecret(ctx, adminId, secret); err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	return TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: s.totp.ProvisioningURI(secret, admin.Email, issuer),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once admin proves the enrolled secret was saved
+// to an authenticator app. Returned recovery codes are shown only once, only their hashes are stored.
+func (s *AdminsService) ConfirmTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) ([]string, error) {
+	admin, err := s.repo.GetById(ctx, adminId)
+	if err != nil {
+		return nil, err
+	}
+
+	if admin.TwoFactor.Enabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	if admin.TwoFactor.PendingSecret == "" {
+		return nil, domain.ErrTwoFactorNotEnrolled
+	}
+
+	step, ok := s.totp.ValidateTOTP(admin.TwoFactor.PendingSecret, code, time.Now())
+	if !ok {
+		return nil, domain.ErrTwoFactorCodeInvalid
+	}
+
+	recoveryCodes := make([]string, twoFactorRecoveryCodesCount)
+	recoveryCodeHashes := make([]string, twoFactorRecoveryCodesCount)
+
+	for i := range recoveryCodes {
+		recoveryCodes[i] = s.otpGenerator.RandomSecret(twoFactorRecoveryCodeLength)
+		recoveryCodeHashes[i] = auth.HashToken(recoveryCodes[i])
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, adminId, admin.TwoFactor.PendingSecret, recoveryCodeHashes); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, s.repo.SetTwoFactorLastUsedStep(ctx, adminId, step)
+}
+
+func (s *AdminsService) DisableTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) error {
+	admin, err := s.repo.GetById(ctx, adminId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.verifyTwoFactorCode(ctx, admin, code); err != nil {
+		return err
+	}
+
+	return s.repo.DisableTwoFactor(ctx, adminId)
+}
+
+// notifyAccountLocked warns admin that somebody is trying to guess the password.
+// Sign in is already failed at this point, so email errors are only logged.
+func (s *AdminsService) notifyAccountLocked(admin domain.Admin, lockedUntil time.Time, schoolDomain string) {
+	if admin.ID.IsZero() {
+		return
+	}
+
+	if err := s.emailService.SendAccountLockedEmail(AccountLockedEmailInput{
+		Email:       admin.Email,
+		Name:        admin.Name,
+		LockedUntil: lockedUntil,
+		Domain:      schoolDomain,
+	}); err != nil {
+		logger.Errorf("failed to notify admin %s about account lockout: %s", admin.ID.Hex(), err.Error())
+	}
+}
+
+func (s *AdminsService) createSession(ctx context.Context, adminId, schoolId primitive.ObjectID, schoolDomain string,
+	device Device) (Tokens, error) {
+	return s.sessionsService.Create(ctx, CreateSessionInput{
+		OwnerID:  adminId,
+		Role:     domain.RoleAdmin,
+		SchoolID: schoolId,
+		Audience: schoolDomain,
+		Device:   device,
+	})
+}
+
+func (s *AdminsService) createTwoFactorChallenge(ctx context.Context, adminId, schoolId primitive.ObjectID) (string, error) {
+	token := s.otpGenerator.RandomSecret(twoFactorChallengeTokenLength)
+	now := time.Now()
+
+	err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeTwoFactorChallenge,
+		Hash:      auth.HashToken(token),
+		OwnerID:   adminId,
+		Role:      domain.RoleAdmin,
+		SchoolID:  schoolId,
+		CreatedAt: now,
+		ExpiresAt: now.Add(twoFactorChallengeTTL),
+	})
+
+	return token, err
+}
+
+// verifyTwoFactorCode accepts either a TOTP code, which can't be used twice, or one of the recovery codes.
+func (s *AdminsService) verifyTwoFactorCode(ctx context.Context, admin domain.Admin, code string) error {
+	if !admin.TwoFactor.Enabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+
+	if step, ok := s.totp.ValidateTOTP(admin.TwoFactor.Secret, code, time.Now()); ok {
+		return s.repo.SetTwoFactorLastUsedStep(ctx, admin.ID, step)
+	}
+
+	return s.repo.UseTwoFactorRecoveryCode(ctx, admin.ID, auth.HashToken(strings.ToUpper(code)))
+}
+
+func (s *AdminsService) RefreshTokens(ctx context.Context, input SchoolRefreshTokensInput) (Tokens, error) {
+	_, tokens, err := s.sessionsService.Refresh(ctx, RefreshSessionInput{
+		RefreshToken: input.RefreshToken,
+		Role:         domain.RoleAdmin,
+		SchoolID:     input.SchoolID,
+		Audience:     input.SchoolDomain,
+		Device:       input.Device,
+	})
+
+	return tokens, err
+}
+
+func (s *AdminsService) RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error {
+	admin, err := s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+	if err != nil {
+		// don't reveal whether account with such email exists
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	// invited admin sets the password when accepting invitation
+	if admin.Pending {
+		return nil
+	}
+
+	token, err := s.passwordResetsService.CreateToken(ctx, admin.ID, domain.RoleAdmin, input.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendPasswordResetEmail(PasswordResetEmailInput{
+		Email:  admin.Email,
+		Name:   admin.Name,
+		Token:  token,
+		Domain: input.SchoolDomain,
+	})
+}
+
+func (s *AdminsService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	return resetPassword(ctx, s.hasher, s.passwordResetsService, s.sessionsService, domain.RoleAdmin, input,
+		func(adminId primitive.ObjectID, passwordHash string) error {
+			return s.repo.SetPassword(ctx, adminId, passwordHash)
+		})
 }
 
 func (s *AdminsService) GetCourses(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Course, error) {
@@ -111,31 +351,9 @@ func (s *AdminsService) UpdateStudent(ctx context.Context, inp domain.UpdateStud
 }
 
 func (s *AdminsService) DeleteStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
-	return s.studentRepo.Delete(ctx, schoolId, studentId)
-}
-
-func (s *AdminsService) createSession(ctx context.Context, adminID primitive.ObjectID) (Tokens, error) {
-	var (
-		res Tokens
-		err error
-	)
-
-	res.AccessToken, err = s.tokenManager.NewJWT(adminID.Hex(), s.accessTokenTTL)
-	if err != nil {
-		return res, err
+	if err := s.studentRepo.Delete(ctx, schoolId, studentId); err != nil {
+		return err
 	}
 
-	res.RefreshToken, err = s.tokenManager.NewRefreshToken()
-	if err != nil {
-		return res, err
-	}
-
-	session := domain.Session{
-		RefreshToken: res.RefreshToken,
-		ExpiresAt:    time.Now().Add(s.refreshTokenTTL),
-	}
-
-	err = s.repo.SetSession(ctx, adminID, session)
-
-	return res, err
+	return s.sessionsService.RevokeAll(ctx, studentId)
 }
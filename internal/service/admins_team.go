@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	adminInvitationTokenLength = 32
+	adminInvitationTTL         = 7 * 24 * time.Hour
+)
+
+func (s *AdminsService) GetById(ctx context.Context, schoolId, adminId primitive.ObjectID) (domain.Admin, error) {
+	admin, err := s.repo.GetById(ctx, adminId)
+	if err != nil {
+		return domain.Admin{}, err
+	}
+
+	if admin.SchoolID != schoolId {
+		return domain.Admin{}, domain.ErrUserNotFound
+	}
+
+	return admin, nil
+}
+
+func (s *AdminsService) GetTeam(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error) {
+	return s.repo.GetBySchool(ctx, schoolId)
+}
+
+// InviteAdmin creates pending admin account and sends invitation link to its email.
+// Inviting pending admin once again updates its role and sends a new link.
+func (s *AdminsService) InviteAdmin(ctx context.Context, input InviteAdminInput) error {
+	if !domain.IsValidAdminRole(input.Role) {
+		return domain.ErrAdminRoleInvalid
+	}
+
+	admin, err := s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+
+	switch {
+	case err == nil:
+		if !admin.Pending {
+			return domain.ErrAdminAlreadyExists
+		}
+
+		if err := s.repo.UpdateInvitation(ctx, repository.UpdateAdminInvitationInput{
+			ID:       admin.ID,
+			SchoolID: input.SchoolID,
+			Name:     input.Name,
+			Role:     input.Role,
+		}); err != nil {
+			return err
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		admin = domain.Admin{
+			Name:     input.Name,
+			Email:    input.Email,
+			SchoolID: input.SchoolID,
+			Role:     input.Role,
+			Pending:  true,
+		}
+
+		if err := s.repo.Create(ctx, &admin); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	token := s.otpGenerator.RandomSecret(adminInvitationTokenLength)
+
+	if err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeAdminInvitation,
+		Hash:      auth.HashToken(token),
+		OwnerID:   admin.ID,
+		Role:      domain.RoleAdmin,
+		SchoolID:  input.SchoolID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(adminInvitationTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendAdminInvitationEmail(AdminInvitationEmailInput{
+		Email:      input.Email,
+		Name:       input.Name,
+		SchoolName: input.SchoolName,
+		Role:       input.Role,
+		Token:      token,
+		Domain:     input.SchoolDomain,
+	})
+}
+
+func (s *AdminsService) AcceptInvitation(ctx context.Context, input AcceptAdminInvitationInput) error {
+	invitation, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(input.Token),
+		Purpose:  domain.TokenPurposeAdminInvitation,
+		Role:     domain.RoleAdmin,
+		SchoolID: input.SchoolID,
+	})
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.hasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.AcceptInvitation(ctx, repository.AcceptAdminInvitationInput{
+		ID:       invitation.OwnerID,
+		SchoolID: input.SchoolID,
+		Name:     input.Name,
+		Password: passwordHash,
+	})
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// invitation was already accepted with another token or admin was removed from the team
+		return domain.ErrOneTimeTokenInvalid
+	}
+
+	return err
+}
+
+func (s *AdminsService) SetRole(ctx context.Context, schoolId, adminId primitive.ObjectID, role string) error {
+	if !domain.IsValidAdminRole(role) {
+		return domain.ErrAdminRoleInvalid
+	}
+
+	admin, err := s.GetById(ctx, schoolId, adminId)
+	if err != nil {
+		return err
+	}
+
+	if admin.GetRole() == domain.AdminRoleOwner && role != domain.AdminRoleOwner && !admin.Pending {
+		if err := s.checkOtherOwnersExist(ctx, schoolId); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.SetRole(ctx, schoolId, adminId, role)
+}
+
+// RemoveFromTeam deletes admin account and revokes all its sessions.
+func (s *AdminsService) RemoveFromTeam(ctx context.Context, schoolId, currentAdminId, adminId primitive.ObjectID) error {
+	if currentAdminId == adminId {
+		return domain.ErrCannotRemoveSelf
+	}
+
+	admin, err := s.GetById(ctx, schoolId, adminId)
+	if err != nil {
+		return err
+	}
+
+	if admin.GetRole() == domain.AdminRoleOwner && !admin.Pending {
+		if err := s.checkOtherOwnersExist(ctx, schoolId); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, schoolId, adminId); err != nil {
+		return err
+	}
+
+	return s.sessionsService.RevokeAll(ctx, adminId)
+}
+
+func (s *AdminsService) checkOtherOwnersExist(ctx context.Context, schoolId primitive.ObjectID) error {
+	owners, err := s.repo.CountOwners(ctx, schoolId)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return domain.ErrLastSchoolOwner
+	}
+
+	return nil
+}
@@ -8,100 +8,422 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"github.com/xlzd/gotp"
+	"github.com/zhashkevych/creatly-backend/internal/config"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
 	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
 	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
 	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
 )
 
-var errInternalServErr = errors.New("test: internal server error")
+var (
+	errInternalServErr = errors.New("test: internal server error")
 
-func mockAdminService(t *testing.T) (*service.AdminsService, *mock_repository.MockAdmins, *mock_repository.MockSchools) {
+	legacyHasher = hash.NewSHA1Hasher("salt")
+	testHasher   = hash.NewMultiHasher(hash.NewBcryptHasher(bcrypt.MinCost), legacyHasher)
+
+	testAdminHasher = hash.NewMultiHasher(hash.NewBcryptHasher(bcrypt.MinCost), hash.NewPlaintextHasher())
+
+	testSignInAttemptsConfig = config.SignInAttemptsConfig{
+		FreeAttempts:    3,
+		MaxAttempts:     5,
+		BackoffBase:     time.Millisecond,
+		LockoutDuration: time.Minute,
+	}
+)
+
+type adminServiceMocks struct {
+	admins        *mock_repository.MockAdmins
+	schools       *mock_repository.MockSchools
+	sessions      *mock_repository.MockSessions
+	oneTimeTokens *mock_repository.MockOneTimeTokens
+	emails        *mock_service.MockEmails
+}
+
+func mockAdminService(t *testing.T) (*service.AdminsService, adminServiceMocks) {
 	t.Helper()
 
 	mockCtl := gomock.NewController(t)
 	defer mockCtl.Finish()
 
-	adminRepo := mock_repository.NewMockAdmins(mockCtl)
-	schoolsRepo := mock_repository.NewMockSchools(mockCtl)
+	mocks := adminServiceMocks{
+		admins:        mock_repository.NewMockAdmins(mockCtl),
+		schools:       mock_repository.NewMockSchools(mockCtl),
+		sessions:      mock_repository.NewMockSessions(mockCtl),
+		oneTimeTokens: mock_repository.NewMockOneTimeTokens(mockCtl),
+		emails:        mock_service.NewMockEmails(mockCtl),
+	}
 	studentsRepo := mock_repository.NewMockStudents(mockCtl)
 
+	otpGenerator := otp.NewGOTPGenerator()
+
 	adminService := service.NewAdminsService(
-		&hash.SHA1Hasher{},
-		&auth.Manager{},
-		adminRepo,
-		schoolsRepo,
+		testAdminHasher,
+		otpGenerator,
+		otpGenerator,
+		service.NewSessionsService(mocks.sessions, &auth.Manager{}, 1*time.Minute, 1*time.Minute),
+		service.NewPasswordResetsService(mocks.oneTimeTokens, otpGenerator, 1*time.Minute),
+		service.NewSignInAttemptsService(cache.NewMemoryCache(), testSignInAttemptsConfig),
+		mocks.emails,
+		mocks.admins,
+		mocks.schools,
 		studentsRepo,
-		1*time.Minute,
-		1*time.Minute,
+		mocks.oneTimeTokens,
 	)
 
-	return adminService, adminRepo, schoolsRepo
+	return adminService, mocks
 }
 
 func TestNewAdminsService_SignInErr(t *testing.T) {
-	adminService, adminRepo, _ := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	adminRepo.EXPECT().GetByCredentials(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Admin{}, errInternalServErr)
-	adminRepo.EXPECT().SetSession(ctx, gomock.Any(), gomock.Any())
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{}, errInternalServErr)
 
 	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{})
 
 	require.True(t, errors.Is(err, errInternalServErr))
-	require.Equal(t, service.Tokens{}, res)
+	require.Equal(t, service.AdminSignInResult{}, res)
+}
+
+func TestNewAdminsService_SignInWrongPassword(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	passwordHash, err := testHasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{Password: passwordHash}, nil)
+
+	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{Password: "wrong"})
+
+	require.True(t, errors.Is(err, domain.ErrUserNotFound))
+	require.Equal(t, service.AdminSignInResult{}, res)
 }
 
 func TestNewAdminsService_SignIn(t *testing.T) {
-	adminService, adminRepo, _ := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	adminRepo.EXPECT().GetByCredentials(ctx, gomock.Any(), gomock.Any(), gomock.Any())
-	adminRepo.EXPECT().SetSession(ctx, gomock.Any(), gomock.Any())
+	passwordHash, err := testHasher.Hash("qwerty123")
+	require.NoError(t, err)
 
-	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{})
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{Password: passwordHash}, nil)
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+
+	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{Password: "qwerty123"})
+
+	require.NoError(t, err)
+	require.Empty(t, res.ChallengeToken)
+}
+
+func TestNewAdminsService_SignInUnknownEmail(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{}, domain.ErrUserNotFound)
+
+	_, err := adminService.SignIn(ctx, service.SchoolSignInInput{Email: "unknown@test.com", Password: "qwerty123"})
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestNewAdminsService_SignInLegacyPlaintextPassword(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	admin := domain.Admin{ID: primitive.NewObjectID(), Password: "qwerty123"}
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(admin, nil)
+	mocks.admins.EXPECT().SetPassword(ctx, admin.ID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ primitive.ObjectID, newHash string) error {
+			require.NotEqual(t, admin.Password, newHash)
+			require.False(t, testAdminHasher.NeedsRehash(newHash))
+
+			return nil
+		})
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+
+	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{Password: "qwerty123"})
+
+	require.NoError(t, err)
+	require.Empty(t, res.ChallengeToken)
+}
+
+func TestNewAdminsService_SignInLockout(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	passwordHash, err := testHasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	admin := domain.Admin{ID: primitive.NewObjectID(), Email: "admin@test.com", Password: passwordHash}
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), admin.Email).Return(admin, nil).
+		Times(testSignInAttemptsConfig.MaxAttempts)
+	mocks.emails.EXPECT().SendAccountLockedEmail(gomock.Any()).DoAndReturn(func(inp service.AccountLockedEmailInput) error {
+		require.Equal(t, admin.Email, inp.Email)
+		require.True(t, inp.LockedUntil.After(time.Now()))
+
+		return nil
+	})
+
+	for i := 1; i <= testSignInAttemptsConfig.MaxAttempts; i++ {
+		// wait for backoff to pass, only lockout should reject correct password
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = adminService.SignIn(ctx, service.SchoolSignInInput{Email: admin.Email, Password: "wrong"})
+
+		if i < testSignInAttemptsConfig.MaxAttempts {
+			require.True(t, errors.Is(err, domain.ErrUserNotFound))
+		} else {
+			require.True(t, errors.Is(err, domain.ErrTooManyAttempts))
+		}
+	}
+
+	_, err = adminService.SignIn(ctx, service.SchoolSignInInput{Email: admin.Email, Password: "qwerty123"})
+
+	require.True(t, errors.Is(err, domain.ErrTooManyAttempts))
+}
+
+func TestNewAdminsService_SignInTwoFactorChallenge(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	passwordHash, err := testHasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	admin := domain.Admin{
+		ID:        primitive.NewObjectID(),
+		Password:  passwordHash,
+		TwoFactor: domain.TwoFactor{Enabled: true, Secret: "JBSWY3DPEHPK3PXP"},
+	}
+
+	var challenge domain.OneTimeToken
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(admin, nil)
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, created domain.OneTimeToken) error {
+		challenge = created
+
+		return nil
+	})
+
+	res, err := adminService.SignIn(ctx, service.SchoolSignInInput{Password: "qwerty123"})
+
+	require.NoError(t, err)
+	require.Equal(t, service.Tokens{}, res.Tokens)
+	require.Equal(t, auth.HashToken(res.ChallengeToken), challenge.Hash)
+	require.Equal(t, domain.TokenPurposeTwoFactorChallenge, challenge.Purpose)
+	require.Equal(t, admin.ID, challenge.OwnerID)
+}
+
+func TestNewAdminsService_SignInTwoFactor(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	secret := "JBSWY3DPEHPK3PXP"
+	admin := domain.Admin{ID: primitive.NewObjectID(), TwoFactor: domain.TwoFactor{Enabled: true, Secret: secret}}
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:    auth.HashToken("challenge"),
+		Purpose: domain.TokenPurposeTwoFactorChallenge,
+		Role:    domain.RoleAdmin,
+	}).Return(domain.OneTimeToken{OwnerID: admin.ID}, nil)
+	mocks.admins.EXPECT().GetById(ctx, admin.ID).Return(admin, nil)
+	mocks.admins.EXPECT().SetTwoFactorLastUsedStep(ctx, admin.ID, gomock.Any())
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+
+	_, err := adminService.SignInTwoFactor(ctx, service.AdminTwoFactorSignInInput{
+		ChallengeToken: "challenge",
+		Code:           gotp.NewDefaultTOTP(secret).Now(),
+	})
+
+	require.NoError(t, err)
+}
+
+func TestNewAdminsService_SignInTwoFactorRecoveryCode(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	admin := domain.Admin{ID: primitive.NewObjectID(), TwoFactor: domain.TwoFactor{Enabled: true, Secret: "JBSWY3DPEHPK3PXP"}}
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{OwnerID: admin.ID}, nil)
+	mocks.admins.EXPECT().GetById(ctx, admin.ID).Return(admin, nil)
+	mocks.admins.EXPECT().UseTwoFactorRecoveryCode(ctx, admin.ID, auth.HashToken("ABCDE23456")).
+		Return(domain.ErrTwoFactorCodeInvalid)
+
+	_, err := adminService.SignInTwoFactor(ctx, service.AdminTwoFactorSignInInput{
+		ChallengeToken: "challenge",
+		Code:           "abcde23456",
+	})
+
+	require.True(t, errors.Is(err, domain.ErrTwoFactorCodeInvalid))
+}
+
+func TestNewAdminsService_ConfirmTwoFactor(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	secret := "JBSWY3DPEHPK3PXP"
+	admin := domain.Admin{ID: primitive.NewObjectID(), TwoFactor: domain.TwoFactor{PendingSecret: secret}}
+
+	var storedHashes []string
+
+	mocks.admins.EXPECT().GetById(ctx, admin.ID).Return(admin, nil)
+	mocks.admins.EXPECT().EnableTwoFactor(ctx, admin.ID, secret, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ primitive.ObjectID, _ string, hashes []string) error {
+			storedHashes = hashes
+
+			return nil
+		})
+	mocks.admins.EXPECT().SetTwoFactorLastUsedStep(ctx, admin.ID, gomock.Any())
+
+	codes, err := adminService.ConfirmTwoFactor(ctx, admin.ID, gotp.NewDefaultTOTP(secret).Now())
+
+	require.NoError(t, err)
+	require.Len(t, codes, len(storedHashes))
+
+	for i := range codes {
+		require.Equal(t, auth.HashToken(codes[i]), storedHashes[i])
+	}
+}
+
+func TestNewAdminsService_ConfirmTwoFactorNotEnrolled(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	adminId := primitive.NewObjectID()
+
+	mocks.admins.EXPECT().GetById(ctx, adminId).Return(domain.Admin{ID: adminId}, nil)
+
+	_, err := adminService.ConfirmTwoFactor(ctx, adminId, "123456")
+
+	require.True(t, errors.Is(err, domain.ErrTwoFactorNotEnrolled))
+}
+
+func TestNewAdminsService_RequestPasswordResetUnknownEmail(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{}, domain.ErrUserNotFound)
+
+	err := adminService.RequestPasswordReset(ctx, service.RequestPasswordResetInput{Email: "admin@test.com"})
+
+	require.NoError(t, err)
+}
+
+func TestNewAdminsService_RequestPasswordReset(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	admin := domain.Admin{ID: primitive.NewObjectID(), Email: "admin@test.com"}
+
+	var token domain.OneTimeToken
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), admin.Email).Return(admin, nil)
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, created domain.OneTimeToken) error {
+		token = created
+
+		return nil
+	})
+	mocks.emails.EXPECT().SendPasswordResetEmail(gomock.Any()).DoAndReturn(func(inp service.PasswordResetEmailInput) error {
+		require.Equal(t, admin.Email, inp.Email)
+		require.Equal(t, auth.HashToken(inp.Token), token.Hash)
+
+		return nil
+	})
+
+	err := adminService.RequestPasswordReset(ctx, service.RequestPasswordResetInput{Email: admin.Email})
+
+	require.NoError(t, err)
+	require.Equal(t, domain.TokenPurposePasswordReset, token.Purpose)
+	require.Equal(t, admin.ID, token.OwnerID)
+}
+
+func TestNewAdminsService_ResetPasswordInvalidToken(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{}, domain.ErrOneTimeTokenInvalid)
+
+	err := adminService.ResetPassword(ctx, service.ResetPasswordInput{Token: "token", Password: "qwerty123"})
+
+	require.True(t, errors.Is(err, domain.ErrOneTimeTokenInvalid))
+}
+
+func TestNewAdminsService_ResetPassword(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	adminId := primitive.NewObjectID()
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:    auth.HashToken("token"),
+		Purpose: domain.TokenPurposePasswordReset,
+		Role:    domain.RoleAdmin,
+	}).Return(domain.OneTimeToken{OwnerID: adminId}, nil)
+	mocks.admins.EXPECT().SetPassword(ctx, adminId, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ primitive.ObjectID, passwordHash string) error {
+			ok, err := testHasher.Verify("qwerty123", passwordHash)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			return nil
+		})
+	mocks.sessions.EXPECT().DeleteByOwner(ctx, adminId)
+
+	err := adminService.ResetPassword(ctx, service.ResetPasswordInput{Token: "token", Password: "qwerty123"})
 
 	require.NoError(t, err)
-	require.IsType(t, service.Tokens{}, res)
 }
 
 func TestNewAdminsService_RefreshTokensErr(t *testing.T) {
-	adminService, adminRepo, _ := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	adminRepo.EXPECT().GetByRefreshToken(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{}, errInternalServErr)
+	mocks.sessions.EXPECT().Rotate(ctx, gomock.Any()).Return(domain.Session{}, errInternalServErr)
 
-	res, err := adminService.RefreshTokens(ctx, primitive.ObjectID{}, "")
+	res, err := adminService.RefreshTokens(ctx, service.SchoolRefreshTokensInput{})
 
 	require.True(t, errors.Is(err, errInternalServErr))
 	require.Equal(t, service.Tokens{}, res)
 }
 
 func TestNewAdminsService_RefreshTokens(t *testing.T) {
-	adminService, adminRepo, _ := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	adminRepo.EXPECT().GetByRefreshToken(ctx, gomock.Any(), gomock.Any())
-	adminRepo.EXPECT().SetSession(ctx, gomock.Any(), gomock.Any())
+	mocks.sessions.EXPECT().Rotate(ctx, gomock.Any())
 
-	res, err := adminService.RefreshTokens(ctx, primitive.ObjectID{}, "")
+	res, err := adminService.RefreshTokens(ctx, service.SchoolRefreshTokensInput{})
 
 	require.NoError(t, err)
 	require.IsType(t, service.Tokens{}, res)
 }
 
 func TestNewAdminsService_GetCoursesErr(t *testing.T) {
-	adminService, _, schoolsRepo := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	schoolsRepo.EXPECT().GetById(ctx, gomock.Any()).Return(domain.School{}, errInternalServErr)
+	mocks.schools.EXPECT().GetById(ctx, gomock.Any()).Return(domain.School{}, errInternalServErr)
 
 	res, err := adminService.GetCourses(ctx, primitive.ObjectID{})
 
@@ -110,11 +432,11 @@ func TestNewAdminsService_GetCoursesErr(t *testing.T) {
 }
 
 func TestNewAdminsService_GetCourses(t *testing.T) {
-	adminService, _, schoolsRepo := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	schoolsRepo.EXPECT().GetById(ctx, gomock.Any())
+	mocks.schools.EXPECT().GetById(ctx, gomock.Any())
 
 	res, err := adminService.GetCourses(ctx, primitive.ObjectID{})
 
@@ -123,11 +445,11 @@ func TestNewAdminsService_GetCourses(t *testing.T) {
 }
 
 func TestNewAdminsService_GetCourseByIdErr(t *testing.T) {
-	adminService, _, schoolsRepo := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	schoolsRepo.EXPECT().GetById(ctx, gomock.Any()).Return(domain.School{}, errInternalServErr)
+	mocks.schools.EXPECT().GetById(ctx, gomock.Any()).Return(domain.School{}, errInternalServErr)
 
 	res, err := adminService.GetCourseById(ctx, primitive.ObjectID{}, primitive.ObjectID{})
 
@@ -136,11 +458,11 @@ func TestNewAdminsService_GetCourseByIdErr(t *testing.T) {
 }
 
 func TestNewAdminsService_GetCourseByIdNotFoundErr(t *testing.T) {
-	adminService, _, schoolsRepo := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 
-	schoolsRepo.EXPECT().GetById(ctx, gomock.Any())
+	mocks.schools.EXPECT().GetById(ctx, gomock.Any())
 
 	_, err := adminService.GetCourseById(ctx, primitive.ObjectID{}, primitive.ObjectID{})
 
@@ -148,7 +470,7 @@ func TestNewAdminsService_GetCourseByIdNotFoundErr(t *testing.T) {
 }
 
 func TestNewAdminsService_GetCourseById(t *testing.T) {
-	adminService, _, schoolsRepo := mockAdminService(t)
+	adminService, mocks := mockAdminService(t)
 
 	ctx := context.Background()
 	s := domain.School{
@@ -160,9 +482,91 @@ func TestNewAdminsService_GetCourseById(t *testing.T) {
 		},
 	}
 
-	schoolsRepo.EXPECT().GetById(ctx, gomock.Any()).Return(s, nil)
+	mocks.schools.EXPECT().GetById(ctx, gomock.Any()).Return(s, nil)
 
 	_, err := adminService.GetCourseById(ctx, s.ID, s.Courses[0].ID)
 
 	require.NoError(t, err)
 }
+
+func TestNewAdminsService_InviteAdmin(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+
+	mocks.admins.EXPECT().GetByEmail(ctx, schoolId, "editor@test.com").Return(domain.Admin{}, domain.ErrUserNotFound)
+	mocks.admins.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, admin *domain.Admin) error {
+		require.True(t, admin.Pending)
+		require.Equal(t, domain.AdminRoleEditor, admin.Role)
+
+		admin.ID = primitive.NewObjectID()
+
+		return nil
+	})
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token domain.OneTimeToken) error {
+		require.Equal(t, domain.TokenPurposeAdminInvitation, token.Purpose)
+		require.False(t, token.OwnerID.IsZero())
+
+		return nil
+	})
+	mocks.emails.EXPECT().SendAdminInvitationEmail(gomock.Any()).DoAndReturn(func(inp service.AdminInvitationEmailInput) error {
+		require.NotEmpty(t, inp.Token)
+
+		return nil
+	})
+
+	err := adminService.InviteAdmin(ctx, service.InviteAdminInput{
+		Email:    "editor@test.com",
+		Name:     "Editor",
+		Role:     domain.AdminRoleEditor,
+		SchoolID: schoolId,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestNewAdminsService_InviteExistingAdmin(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+
+	mocks.admins.EXPECT().GetByEmail(ctx, gomock.Any(), gomock.Any()).Return(domain.Admin{ID: primitive.NewObjectID()}, nil)
+
+	err := adminService.InviteAdmin(ctx, service.InviteAdminInput{Role: domain.AdminRoleSupport})
+
+	require.True(t, errors.Is(err, domain.ErrAdminAlreadyExists))
+}
+
+func TestNewAdminsService_SetRoleLastOwner(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	admin := domain.Admin{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	mocks.admins.EXPECT().GetById(ctx, admin.ID).Return(admin, nil)
+	mocks.admins.EXPECT().CountOwners(ctx, admin.SchoolID).Return(int64(1), nil)
+
+	err := adminService.SetRole(ctx, admin.SchoolID, admin.ID, domain.AdminRoleFinance)
+
+	require.True(t, errors.Is(err, domain.ErrLastSchoolOwner))
+}
+
+func TestNewAdminsService_RemoveFromTeam(t *testing.T) {
+	adminService, mocks := mockAdminService(t)
+
+	ctx := context.Background()
+	admin := domain.Admin{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID(), Role: domain.AdminRoleSupport}
+
+	mocks.admins.EXPECT().GetById(ctx, admin.ID).Return(admin, nil)
+	mocks.admins.EXPECT().Delete(ctx, admin.SchoolID, admin.ID)
+	mocks.sessions.EXPECT().DeleteByOwner(ctx, admin.ID)
+
+	err := adminService.RemoveFromTeam(ctx, admin.SchoolID, primitive.NewObjectID(), admin.ID)
+
+	require.NoError(t, err)
+
+	err = adminService.RemoveFromTeam(ctx, admin.SchoolID, admin.ID, admin.ID)
+
+	require.True(t, errors.Is(err, domain.ErrCannotRemoveSelf))
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	apiKeyPrefix       = "ck_"
+	apiKeyLength       = 40
+	apiKeyVisiblePart  = 8
+	apiKeyLastUsedStep = time.Minute
+)
+
+type APIKeysService struct {
+	repo         repository.APIKeys
+	otpGenerator otp.Generator
+}
+
+func NewAPIKeysService(repo repository.APIKeys, otpGenerator otp.Generator) *APIKeysService {
+	return &APIKeysService{repo: repo, otpGenerator: otpGenerator}
+}
+
+// Create generates new key for the school. Raw key is returned only here, later it can't be restored.
+func (s *APIKeysService) Create(ctx context.Context, input CreateAPIKeyInput) (CreateAPIKeyResult, error) {
+	if len(input.Scopes) == 0 {
+		return CreateAPIKeyResult{}, domain.ErrAPIKeyScopeInvalid
+	}
+
+	for _, scope := range input.Scopes {
+		if !domain.IsValidAPIKeyScope(scope) {
+			return CreateAPIKeyResult{}, domain.ErrAPIKeyScopeInvalid
+		}
+	}
+
+	key := apiKeyPrefix + s.otpGenerator.RandomSecret(apiKeyLength)
+
+	apiKey := domain.APIKey{
+		SchoolID:  input.SchoolID,
+		Name:      input.Name,
+		Prefix:    key[:len(apiKeyPrefix)+apiKeyVisiblePart],
+		Hash:      auth.HashToken(key),
+		Scopes:    input.Scopes,
+		CreatedBy: input.AdminID,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, &apiKey); err != nil {
+		return CreateAPIKeyResult{}, err
+	}
+
+	return CreateAPIKeyResult{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *APIKeysService) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error) {
+	return s.repo.GetBySchool(ctx, schoolId)
+}
+
+func (s *APIKeysService) Revoke(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	return s.repo.Delete(ctx, schoolId, id)
+}
+
+// Authenticate finds school's key by its hash and records last usage time.
+// To avoid a write on every request, last usage time is updated not more often than once a minute.
+func (s *APIKeysService) Authenticate(ctx context.Context, schoolId primitive.ObjectID, key string) (domain.APIKey, error) {
+	apiKey, err := s.repo.GetByHash(ctx, schoolId, auth.HashToken(key))
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	now := time.Now()
+	if now.Sub(apiKey.LastUsedAt) >= apiKeyLastUsedStep {
+		if err := s.repo.SetLastUsed(ctx, apiKey.ID, now); err != nil {
+			return domain.APIKey{}, err
+		}
+
+		apiKey.LastUsedAt = now
+	}
+
+	return apiKey, nil
+}
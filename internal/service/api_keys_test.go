@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockAPIKeysService(t *testing.T) (*service.APIKeysService, *mock_repository.MockAPIKeys) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := mock_repository.NewMockAPIKeys(mockCtl)
+
+	return service.NewAPIKeysService(repo, otp.NewGOTPGenerator()), repo
+}
+
+func TestAPIKeysService_Create(t *testing.T) {
+	apiKeysService, repo := mockAPIKeysService(t)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+
+	var stored domain.APIKey
+
+	repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.APIKey) error {
+		stored = *key
+
+		return nil
+	})
+
+	res, err := apiKeysService.Create(ctx, service.CreateAPIKeyInput{
+		SchoolID: schoolId,
+		Name:     "CRM",
+		Scopes:   []string{"students:read", "orders:read"},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, auth.HashToken(res.Key), stored.Hash)
+	require.True(t, strings.HasPrefix(res.Key, stored.Prefix))
+	require.Equal(t, schoolId, stored.SchoolID)
+}
+
+func TestAPIKeysService_CreateInvalidScope(t *testing.T) {
+	apiKeysService, _ := mockAPIKeysService(t)
+
+	_, err := apiKeysService.Create(context.Background(), service.CreateAPIKeyInput{
+		Name:   "CRM",
+		Scopes: []string{"students:delete"},
+	})
+
+	require.ErrorIs(t, err, domain.ErrAPIKeyScopeInvalid)
+}
+
+func TestAPIKeysService_Authenticate(t *testing.T) {
+	apiKeysService, repo := mockAPIKeysService(t)
+
+	ctx := context.Background()
+	key := domain.APIKey{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	repo.EXPECT().GetByHash(ctx, key.SchoolID, auth.HashToken("key")).Return(key, nil)
+	repo.EXPECT().SetLastUsed(ctx, key.ID, gomock.Any())
+
+	res, err := apiKeysService.Authenticate(ctx, key.SchoolID, "key")
+
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), res.LastUsedAt, time.Second)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CourseProgressService struct {
+	studentLessonsRepo repository.StudentLessons
+	coursesRepo        repository.Courses
+	modulesService     Modules
+	activitiesService  StudentActivities
+}
+
+func NewCourseProgressService(studentLessonsRepo repository.StudentLessons, coursesRepo repository.Courses,
+	modulesService Modules, activitiesService StudentActivities) *CourseProgressService {
+	return &CourseProgressService{
+		studentLessonsRepo: studentLessonsRepo,
+		coursesRepo:        coursesRepo,
+		modulesService:     modulesService,
+		activitiesService:  activitiesService,
+	}
+}
+
+// GetByStudent returns progress of the published courses, student has access to any module of.
+func (s *CourseProgressService) GetByStudent(ctx context.Context, student domain.Student) ([]domain.CourseProgress, error) {
+	progress := make([]domain.CourseProgress, 0)
+
+	if len(student.AvailableModules) == 0 {
+		return progress, nil
+	}
+
+	modules, err := s.modulesService.GetByIds(ctx, student.AvailableModules)
+	if err != nil {
+		return nil, err
+	}
+
+	lessons, err := s.studentLessonsRepo.GetByStudent(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make(map[primitive.ObjectID]bool)
+
+	for _, module := range modules {
+		if added[module.CourseID] {
+			continue
+		}
+
+		added[module.CourseID] = true
+
+		courseProgress, err := s.getCourseProgress(ctx, student.SchoolID, module.CourseID, lessons)
+		if err != nil {
+			if errors.Is(err, domain.ErrCourseNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		progress = append(progress, courseProgress)
+	}
+
+	return progress, nil
+}
+
+func (s *CourseProgressService) GetByCourse(ctx context.Context, schoolId, studentId, courseId primitive.ObjectID) (domain.CourseProgress, error) {
+	lessons, err := s.studentLessonsRepo.GetByStudent(ctx, studentId)
+	if err != nil {
+		return domain.CourseProgress{}, err
+	}
+
+	return s.getCourseProgress(ctx, schoolId, courseId, lessons)
+}
+
+// CheckCompletion records course completion after student finished the last lesson of the module's course.
+func (s *CourseProgressService) CheckCompletion(ctx context.Context, studentId primitive.ObjectID, module domain.Module) error {
+	modules, err := s.modulesService.GetPublishedByCourseId(ctx, module.CourseID)
+	if err != nil {
+		return err
+	}
+
+	lessons, err := s.studentLessonsRepo.GetByStudent(ctx, studentId)
+	if err != nil {
+		return err
+	}
+
+	progress := domain.NewCourseProgress(domain.Course{ID: module.CourseID}, modules, lessons)
+	if !progress.IsFinished() || progress.CompletedAt != nil {
+		return nil
+	}
+
+	completed, err := s.studentLessonsRepo.AddCompleted(ctx, studentId, domain.CourseCompletion{
+		CourseID:    module.CourseID,
+		CompletedAt: time.Now(),
+	})
+	if err != nil || !completed {
+		return err
+	}
+
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  module.SchoolID,
+		StudentID: studentId,
+		Type:      domain.StudentActivityCourseCompleted,
+		CourseID:  module.CourseID,
+		ModuleID:  module.ID,
+	})
+
+	return nil
+}
+
+// GetStats aggregates progress of students by published lessons of the course.
+func (s *CourseProgressService) GetStats(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.CourseProgressStats, error) {
+	if _, err := s.coursesRepo.GetById(ctx, schoolId, courseId); err != nil {
+		return domain.CourseProgressStats{}, err
+	}
+
+	modules, err := s.modulesService.GetPublishedByCourseId(ctx, courseId)
+	if err != nil {
+		return domain.CourseProgressStats{}, err
+	}
+
+	lessonIds := make([]primitive.ObjectID, 0)
+
+	for _, module := range modules {
+		for _, lesson := range module.Lessons {
+			if lesson.Published {
+				lessonIds = append(lessonIds, lesson.ID)
+			}
+		}
+	}
+
+	studentsLessons, err := s.studentLessonsRepo.GetByCourse(ctx, courseId, lessonIds)
+	if err != nil {
+		return domain.CourseProgressStats{}, err
+	}
+
+	stats := domain.CourseProgressStats{CourseID: courseId, LessonsTotal: len(lessonIds)}
+
+	for _, lessons := range studentsLessons {
+		finished := lessons.CountFinished(lessonIds)
+		completed := lessons.CompletedAt(courseId) != nil
+
+		if finished == 0 && !completed {
+			continue
+		}
+
+		stats.Started++
+
+		if completed || finished*2 >= len(lessonIds) {
+			stats.HalfCompleted++
+		}
+
+		if completed {
+			stats.Completed++
+		}
+	}
+
+	return stats, nil
+}
+
+// getCourseProgress returns ErrCourseNotFound for unpublished course, it's hidden from students.
+func (s *CourseProgressService) getCourseProgress(ctx context.Context, schoolId, courseId primitive.ObjectID,
+	lessons domain.StudentLessons) (domain.CourseProgress, error) {
+	course, err := s.coursesRepo.GetById(ctx, schoolId, courseId)
+	if err != nil {
+		return domain.CourseProgress{}, err
+	}
+
+	if !course.Published {
+		return domain.CourseProgress{}, domain.ErrCourseNotFound
+	}
+
+	modules, err := s.modulesService.GetPublishedByCourseId(ctx, courseId)
+	if err != nil {
+		return domain.CourseProgress{}, err
+	}
+
+	return domain.NewCourseProgress(course, modules, lessons), nil
+}
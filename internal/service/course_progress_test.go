@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type courseProgressMocks struct {
+	studentLessons *mock_repository.MockStudentLessons
+	courses        *mock_repository.MockCourses
+	modules        *mock_service.MockModules
+	activities     *mock_repository.MockStudentActivities
+}
+
+func mockCourseProgressService(t *testing.T) (*service.CourseProgressService, courseProgressMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := courseProgressMocks{
+		studentLessons: mock_repository.NewMockStudentLessons(mockCtl),
+		courses:        mock_repository.NewMockCourses(mockCtl),
+		modules:        mock_service.NewMockModules(mockCtl),
+		activities:     mock_repository.NewMockStudentActivities(mockCtl),
+	}
+
+	return service.NewCourseProgressService(mocks.studentLessons, mocks.courses, mocks.modules,
+		service.NewStudentActivitiesService(mocks.activities)), mocks
+}
+
+func courseModules(courseId primitive.ObjectID) []domain.Module {
+	return []domain.Module{
+		{ID: primitive.NewObjectID(), Name: "first", CourseID: courseId, Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true},
+			{ID: primitive.NewObjectID(), Published: true},
+			{ID: primitive.NewObjectID()},
+		}},
+		{ID: primitive.NewObjectID(), Name: "second", CourseID: courseId, Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true},
+			{ID: primitive.NewObjectID(), Published: true},
+		}},
+	}
+}
+
+func TestCourseProgressService_GetByStudent(t *testing.T) {
+	progressService, mocks := mockCourseProgressService(t)
+
+	ctx := context.Background()
+	course := domain.Course{ID: primitive.NewObjectID(), Name: "course", Published: true}
+	modules := courseModules(course.ID)
+	hidden := domain.Module{ID: primitive.NewObjectID(), CourseID: primitive.NewObjectID()}
+	student := domain.Student{
+		ID:               primitive.NewObjectID(),
+		SchoolID:         primitive.NewObjectID(),
+		AvailableModules: []primitive.ObjectID{modules[0].ID, modules[1].ID, hidden.ID},
+	}
+
+	mocks.modules.EXPECT().GetByIds(ctx, student.AvailableModules).Return([]domain.Module{modules[0], modules[1], hidden}, nil)
+	mocks.studentLessons.EXPECT().GetByStudent(ctx, student.ID).Return(domain.StudentLessons{
+		Finished: []primitive.ObjectID{modules[0].Lessons[0].ID, modules[0].Lessons[1].ID, modules[0].Lessons[2].ID},
+	}, nil)
+	mocks.courses.EXPECT().GetById(ctx, student.SchoolID, course.ID).Return(course, nil)
+	mocks.modules.EXPECT().GetPublishedByCourseId(ctx, course.ID).Return(modules, nil)
+	mocks.courses.EXPECT().GetById(ctx, student.SchoolID, hidden.CourseID).Return(domain.Course{ID: hidden.CourseID}, nil)
+
+	progress, err := progressService.GetByStudent(ctx, student)
+	require.NoError(t, err)
+	require.Equal(t, []domain.CourseProgress{{
+		CourseID:        course.ID,
+		Name:            "course",
+		LessonsTotal:    4,
+		LessonsFinished: 2,
+		Percent:         50,
+		Modules: []domain.ModuleProgress{
+			{ModuleID: modules[0].ID, Name: "first", LessonsTotal: 2, LessonsFinished: 2, Percent: 100},
+			{ModuleID: modules[1].ID, Name: "second", LessonsTotal: 2},
+		},
+	}}, progress)
+}
+
+func TestCourseProgressService_CheckCompletion(t *testing.T) {
+	progressService, mocks := mockCourseProgressService(t)
+
+	ctx := context.Background()
+	courseId := primitive.NewObjectID()
+	modules := courseModules(courseId)
+	studentId := primitive.NewObjectID()
+	lessons := domain.StudentLessons{Finished: []primitive.ObjectID{
+		modules[0].Lessons[0].ID, modules[0].Lessons[1].ID, modules[1].Lessons[0].ID, modules[1].Lessons[1].ID,
+	}}
+
+	mocks.modules.EXPECT().GetPublishedByCourseId(ctx, courseId).Return(modules, nil).Times(2)
+	mocks.studentLessons.EXPECT().GetByStudent(ctx, studentId).Return(lessons, nil)
+	mocks.studentLessons.EXPECT().AddCompleted(ctx, studentId, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ primitive.ObjectID, completion domain.CourseCompletion) (bool, error) {
+			require.Equal(t, courseId, completion.CourseID)
+			require.False(t, completion.CompletedAt.IsZero())
+
+			return true, nil
+		})
+	mocks.activities.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, activity domain.StudentActivity) error {
+		require.Equal(t, domain.StudentActivityCourseCompleted, activity.Type)
+		require.Equal(t, courseId, activity.CourseID)
+
+		return nil
+	})
+
+	require.NoError(t, progressService.CheckCompletion(ctx, studentId, modules[1]))
+
+	lessons.Completed = []domain.CourseCompletion{{CourseID: courseId, CompletedAt: time.Now()}}
+	mocks.studentLessons.EXPECT().GetByStudent(ctx, studentId).Return(lessons, nil)
+
+	require.NoError(t, progressService.CheckCompletion(ctx, studentId, modules[1]))
+}
+
+func TestCourseProgressService_GetStats(t *testing.T) {
+	progressService, mocks := mockCourseProgressService(t)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+	courseId := primitive.NewObjectID()
+	modules := courseModules(courseId)
+	lessonIds := []primitive.ObjectID{modules[0].Lessons[0].ID, modules[0].Lessons[1].ID, modules[1].Lessons[0].ID, modules[1].Lessons[1].ID}
+
+	mocks.courses.EXPECT().GetById(ctx, schoolId, courseId).Return(domain.Course{ID: courseId}, nil)
+	mocks.modules.EXPECT().GetPublishedByCourseId(ctx, courseId).Return(modules, nil)
+	mocks.studentLessons.EXPECT().GetByCourse(ctx, courseId, lessonIds).Return([]domain.StudentLessons{
+		{Finished: lessonIds[:1]},
+		{Finished: lessonIds[:2]},
+		{Finished: []primitive.ObjectID{modules[0].Lessons[2].ID}},
+		{Completed: []domain.CourseCompletion{{CourseID: courseId, CompletedAt: time.Now()}}},
+	}, nil)
+
+	stats, err := progressService.GetStats(ctx, schoolId, courseId)
+	require.NoError(t, err)
+	require.Equal(t, domain.CourseProgressStats{
+		CourseID:      courseId,
+		LessonsTotal:  4,
+		Started:       3,
+		HalfCompleted: 2,
+		Completed:     1,
+	}, stats)
+}
@@ -27,12 +27,17 @@ func (s *CoursesService) Create(ctx context.Context, schoolId primitive.ObjectID
 }
 
 func (s *CoursesService) Update(ctx context.Context, inp UpdateCourseInput) error {
+	if inp.Gating != nil && !domain.IsValidGatingMode(*inp.Gating) {
+		return domain.ErrGatingModeInvalid
+	}
+
 	updateInput := repository.UpdateCourseInput{
 		Name:        inp.Name,
 		ImageURL:    inp.ImageURL,
 		Description: inp.Description,
 		Color:       inp.Color,
 		Published:   inp.Published,
+		Gating:      inp.Gating,
 	}
 
 	var err error
@@ -13,7 +13,15 @@ import (
 )
 
 const (
-	verificationLinkTmpl = "https://%s/verification?code=%s" // https://<school host>/verification?code=<verification_code>
+	verificationLinkTmpl         = "https://%s/verification?code=%s"    // https://<school host>/verification?code=<verification_code>
+	passwordResetLinkTmpl        = "https://%s/password-reset?token=%s" // https://<host>/password-reset?token=<reset_token>
+	passwordResetRequestLinkTmpl = "https://%s/password-reset"          // https://<host>/password-reset
+
+	adminInvitationLinkTmpl = "https://%s/admin/invitation?token=%s" // https://<school host>/admin/invitation?token=<invitation_token>
+	magicLinkTmpl           = "https://%s/magic-link?token=%s"       // https://<school host>/magic-link?token=<login_token>
+	emailChangeLinkTmpl     = "https://%s/account/email?token=%s"    // https://<school host>/account/email?token=<confirmation_token>
+
+	accountLockedTimeLayout = "02.01.2006 15:04 MST"
 )
 
 type EmailService struct {
@@ -31,6 +39,46 @@ type verificationEmailInput struct {
 	VerificationLink string
 }
 
+type passwordResetEmailInput struct {
+	PasswordResetLink string
+}
+
+type accountLockedEmailInput struct {
+	LockedUntil       string
+	PasswordResetLink string
+}
+
+type emailChangeEmailInput struct {
+	Name             string
+	ConfirmationLink string
+}
+
+type confirmationCodeEmailInput struct {
+	Name string
+	Code string
+}
+
+type studentWelcomeEmailInput struct {
+	Name              string
+	PasswordResetLink string
+}
+
+type dataExportEmailInput struct {
+	Name        string
+	DownloadURL string
+}
+
+type magicLinkEmailInput struct {
+	MagicLink string
+}
+
+type adminInvitationEmailInput struct {
+	Name           string
+	SchoolName     string
+	Role           string
+	InvitationLink string
+}
+
 type purchaseSuccessfulEmailInput struct {
 	Name       string
 	CourseName string
@@ -70,6 +118,112 @@ func (s *EmailService) SendStudentPurchaseSuccessfulEmail(input StudentPurchaseS
 	return s.sender.Send(sendInput)
 }
 
+func (s *EmailService) SendPasswordResetEmail(input PasswordResetEmailInput) error {
+	subject := fmt.Sprintf(s.config.Subjects.PasswordReset, input.Name)
+
+	templateInput := passwordResetEmailInput{fmt.Sprintf(passwordResetLinkTmpl, input.Domain, input.Token)}
+	sendInput := emailProvider.SendEmailInput{Subject: subject, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.PasswordReset, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendAccountLockedEmail(input AccountLockedEmailInput) error {
+	templateInput := accountLockedEmailInput{
+		LockedUntil:       input.LockedUntil.Format(accountLockedTimeLayout),
+		PasswordResetLink: fmt.Sprintf(passwordResetRequestLinkTmpl, input.Domain),
+	}
+	sendInput := emailProvider.SendEmailInput{Subject: s.config.Subjects.AccountLocked, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.AccountLocked, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendMagicLinkEmail(input MagicLinkEmailInput) error {
+	templateInput := magicLinkEmailInput{fmt.Sprintf(magicLinkTmpl, input.Domain, input.Token)}
+	sendInput := emailProvider.SendEmailInput{Subject: s.config.Subjects.MagicLink, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.MagicLink, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendEmailChangeEmail(input EmailChangeEmailInput) error {
+	templateInput := emailChangeEmailInput{
+		Name:             input.Name,
+		ConfirmationLink: fmt.Sprintf(emailChangeLinkTmpl, input.Domain, input.Token),
+	}
+	sendInput := emailProvider.SendEmailInput{Subject: s.config.Subjects.EmailChange, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.EmailChange, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendConfirmationCodeEmail(input ConfirmationCodeEmailInput) error {
+	templateInput := confirmationCodeEmailInput{Name: input.Name, Code: input.Code}
+	sendInput := emailProvider.SendEmailInput{Subject: s.config.Subjects.ConfirmationCode, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.ConfirmationCode, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendStudentWelcomeEmail(input StudentWelcomeEmailInput) error {
+	templateInput := studentWelcomeEmailInput{
+		Name:              input.Name,
+		PasswordResetLink: fmt.Sprintf(passwordResetRequestLinkTmpl, input.Domain),
+	}
+	sendInput := emailProvider.SendEmailInput{Subject: fmt.Sprintf(s.config.Subjects.StudentWelcome, input.Name), To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.StudentWelcome, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendDataExportEmail(input DataExportEmailInput) error {
+	templateInput := dataExportEmailInput{Name: input.Name, DownloadURL: input.DownloadURL}
+	sendInput := emailProvider.SendEmailInput{Subject: s.config.Subjects.DataExport, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.DataExport, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
+func (s *EmailService) SendAdminInvitationEmail(input AdminInvitationEmailInput) error {
+	subject := fmt.Sprintf(s.config.Subjects.AdminInvitation, input.SchoolName)
+
+	templateInput := adminInvitationEmailInput{
+		Name:           input.Name,
+		SchoolName:     input.SchoolName,
+		Role:           input.Role,
+		InvitationLink: fmt.Sprintf(adminInvitationLinkTmpl, input.Domain, input.Token),
+	}
+	sendInput := emailProvider.SendEmailInput{Subject: subject, To: input.Email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.AdminInvitation, templateInput); err != nil {
+		return err
+	}
+
+	return s.sender.Send(sendInput)
+}
+
 func (s *EmailService) SendUserVerificationEmail(input VerificationEmailInput) error {
 	// todo implement
 	return nil
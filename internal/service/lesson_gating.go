@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LessonGatingService struct {
+	coursesRepo        repository.Courses
+	studentLessonsRepo repository.StudentLessons
+	surveyResultsRepo  repository.SurveyResults
+	modulesService     Modules
+}
+
+func NewLessonGatingService(coursesRepo repository.Courses, studentLessonsRepo repository.StudentLessons,
+	surveyResultsRepo repository.SurveyResults, modulesService Modules) *LessonGatingService {
+	return &LessonGatingService{
+		coursesRepo:        coursesRepo,
+		studentLessonsRepo: studentLessonsRepo,
+		surveyResultsRepo:  surveyResultsRepo,
+		modulesService:     modulesService,
+	}
+}
+
+// GetPrerequisites returns missing prerequisites of the locked module lessons by lesson id.
+// Prerequisites are evaluated from finished lessons and submitted surveys of the student.
+func (s *LessonGatingService) GetPrerequisites(ctx context.Context, studentId primitive.ObjectID,
+	module domain.Module) (map[primitive.ObjectID]domain.Prerequisite, error) {
+	course, err := s.coursesRepo.GetById(ctx, module.SchoolID, module.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := module.GatingMode(course)
+	if mode != domain.GatingSequential && course.Gating != domain.GatingSequential {
+		return nil, nil
+	}
+
+	studentLessons, err := s.studentLessonsRepo.GetByStudent(ctx, studentId)
+	if err != nil {
+		return nil, err
+	}
+
+	finished := make(map[primitive.ObjectID]bool, len(studentLessons.Finished))
+	for _, id := range studentLessons.Finished {
+		finished[id] = true
+	}
+
+	var modulePrerequisite *domain.Prerequisite
+
+	if course.Gating == domain.GatingSequential {
+		modulePrerequisite, err = s.getModulePrerequisite(ctx, studentId, module, finished)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lessons := make([]domain.Lesson, 0, len(module.Lessons))
+
+	for _, lesson := range module.Lessons {
+		if lesson.IsPublishedOrScheduled() {
+			lessons = append(lessons, lesson)
+		}
+	}
+
+	sortLessons(lessons)
+
+	prerequisites := make(map[primitive.ObjectID]domain.Prerequisite)
+
+	for i, lesson := range lessons {
+		switch {
+		case modulePrerequisite != nil:
+			prerequisites[lesson.ID] = *modulePrerequisite
+		case mode == domain.GatingSequential && i > 0 && !finished[lessons[i-1].ID]:
+			previousId := lessons[i-1].ID
+			prerequisites[lesson.ID] = domain.Prerequisite{
+				Type:     domain.PrerequisiteLesson,
+				ModuleID: module.ID,
+				LessonID: &previousId,
+				Name:     lessons[i-1].Name,
+			}
+		}
+	}
+
+	return prerequisites, nil
+}
+
+// getModulePrerequisite returns unfinished lesson or required survey of the previous module in the course.
+func (s *LessonGatingService) getModulePrerequisite(ctx context.Context, studentId primitive.ObjectID, module domain.Module,
+	finished map[primitive.ObjectID]bool) (*domain.Prerequisite, error) {
+	// scheduled modules are listed to students, so they are in the sequence as well
+	modules, err := s.modulesService.GetScheduledByCourseId(ctx, module.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *domain.Module
+
+	for i := range modules {
+		if modules[i].ID == module.ID {
+			if i > 0 {
+				previous = &modules[i-1]
+			}
+
+			break
+		}
+	}
+
+	if previous == nil {
+		return nil, nil
+	}
+
+	for _, lesson := range previous.Lessons {
+		if lesson.IsPublishedOrScheduled() && !finished[lesson.ID] {
+			lessonId := lesson.ID
+
+			return &domain.Prerequisite{
+				Type:     domain.PrerequisiteLesson,
+				ModuleID: previous.ID,
+				LessonID: &lessonId,
+				Name:     lesson.Name,
+			}, nil
+		}
+	}
+
+	if !previous.Survey.Required || len(previous.Survey.Questions) == 0 {
+		return nil, nil
+	}
+
+	if _, err := s.surveyResultsRepo.GetByStudent(ctx, previous.ID, studentId); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &domain.Prerequisite{
+				Type:     domain.PrerequisiteSurvey,
+				ModuleID: previous.ID,
+				Name:     previous.Name,
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type lessonGatingMocks struct {
+	courses        *mock_repository.MockCourses
+	studentLessons *mock_repository.MockStudentLessons
+	surveyResults  *mock_repository.MockSurveyResults
+	modules        *mock_service.MockModules
+}
+
+func mockLessonGatingService(t *testing.T) (*service.LessonGatingService, lessonGatingMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := lessonGatingMocks{
+		courses:        mock_repository.NewMockCourses(mockCtl),
+		studentLessons: mock_repository.NewMockStudentLessons(mockCtl),
+		surveyResults:  mock_repository.NewMockSurveyResults(mockCtl),
+		modules:        mock_service.NewMockModules(mockCtl),
+	}
+
+	return service.NewLessonGatingService(mocks.courses, mocks.studentLessons, mocks.surveyResults, mocks.modules), mocks
+}
+
+func TestLessonGatingService_GetPrerequisitesOpen(t *testing.T) {
+	gatingService, mocks := mockLessonGatingService(t)
+
+	module := domain.Module{ID: primitive.NewObjectID(), CourseID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	mocks.courses.EXPECT().GetById(gomock.Any(), module.SchoolID, module.CourseID).Return(domain.Course{}, nil)
+
+	prerequisites, err := gatingService.GetPrerequisites(context.Background(), primitive.NewObjectID(), module)
+	require.NoError(t, err)
+	require.Empty(t, prerequisites)
+}
+
+func TestLessonGatingService_GetPrerequisitesSequentialModule(t *testing.T) {
+	gatingService, mocks := mockLessonGatingService(t)
+
+	studentId := primitive.NewObjectID()
+	lessons := []domain.Lesson{
+		{ID: primitive.NewObjectID(), Name: "second", Position: 1, Published: true},
+		{ID: primitive.NewObjectID(), Name: "first", Position: 0, Published: true},
+		{ID: primitive.NewObjectID(), Name: "draft", Position: 2},
+		{ID: primitive.NewObjectID(), Name: "third", Position: 3, Published: true},
+	}
+	module := domain.Module{
+		ID:       primitive.NewObjectID(),
+		CourseID: primitive.NewObjectID(),
+		SchoolID: primitive.NewObjectID(),
+		Gating:   domain.GatingSequential,
+		Lessons:  lessons,
+	}
+
+	mocks.courses.EXPECT().GetById(gomock.Any(), module.SchoolID, module.CourseID).Return(domain.Course{Gating: domain.GatingOpen}, nil)
+	mocks.studentLessons.EXPECT().GetByStudent(gomock.Any(), studentId).Return(domain.StudentLessons{
+		Finished: []primitive.ObjectID{lessons[1].ID},
+	}, nil)
+
+	prerequisites, err := gatingService.GetPrerequisites(context.Background(), studentId, module)
+	require.NoError(t, err)
+	require.Equal(t, map[primitive.ObjectID]domain.Prerequisite{
+		lessons[3].ID: {Type: domain.PrerequisiteLesson, ModuleID: module.ID, LessonID: &lessons[0].ID, Name: "second"},
+	}, prerequisites)
+}
+
+func TestLessonGatingService_GetPrerequisitesSequentialCourse(t *testing.T) {
+	gatingService, mocks := mockLessonGatingService(t)
+
+	studentId := primitive.NewObjectID()
+	courseId := primitive.NewObjectID()
+	previous := domain.Module{
+		ID:       primitive.NewObjectID(),
+		Name:     "intro",
+		CourseID: courseId,
+		Lessons:  []domain.Lesson{{ID: primitive.NewObjectID(), Published: true}},
+		Survey:   domain.Survey{Required: true, Questions: []domain.SurveyQuestion{{Question: "?"}}},
+	}
+	module := domain.Module{
+		ID:       primitive.NewObjectID(),
+		CourseID: courseId,
+		SchoolID: primitive.NewObjectID(),
+		Gating:   domain.GatingOpen,
+		Lessons:  []domain.Lesson{{ID: primitive.NewObjectID(), Published: true}},
+	}
+
+	mocks.courses.EXPECT().GetById(gomock.Any(), module.SchoolID, courseId).Return(domain.Course{Gating: domain.GatingSequential}, nil)
+	mocks.studentLessons.EXPECT().GetByStudent(gomock.Any(), studentId).Return(domain.StudentLessons{
+		Finished: []primitive.ObjectID{previous.Lessons[0].ID},
+	}, nil)
+	mocks.modules.EXPECT().GetScheduledByCourseId(gomock.Any(), courseId).Return([]domain.Module{previous, module}, nil)
+	mocks.surveyResults.EXPECT().GetByStudent(gomock.Any(), previous.ID, studentId).Return(domain.SurveyResult{}, mongo.ErrNoDocuments)
+
+	prerequisites, err := gatingService.GetPrerequisites(context.Background(), studentId, module)
+	require.NoError(t, err)
+	require.Equal(t, map[primitive.ObjectID]domain.Prerequisite{
+		module.Lessons[0].ID: {Type: domain.PrerequisiteSurvey, ModuleID: previous.ID, Name: "intro"},
+	}, prerequisites)
+}
+
+func TestLessonGatingService_GetPrerequisitesScheduledLesson(t *testing.T) {
+	gatingService, mocks := mockLessonGatingService(t)
+
+	studentId := primitive.NewObjectID()
+	courseId := primitive.NewObjectID()
+	publishAt := time.Now().Add(time.Hour)
+	previous := domain.Module{
+		ID:       primitive.NewObjectID(),
+		CourseID: courseId,
+		Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true},
+			{ID: primitive.NewObjectID(), Name: "scheduled", PublishAt: &publishAt},
+		},
+	}
+	module := domain.Module{
+		ID:       primitive.NewObjectID(),
+		CourseID: courseId,
+		SchoolID: primitive.NewObjectID(),
+		Lessons:  []domain.Lesson{{ID: primitive.NewObjectID(), Published: true}},
+	}
+
+	mocks.courses.EXPECT().GetById(gomock.Any(), module.SchoolID, courseId).Return(domain.Course{Gating: domain.GatingSequential}, nil)
+	mocks.studentLessons.EXPECT().GetByStudent(gomock.Any(), studentId).Return(domain.StudentLessons{
+		Finished: []primitive.ObjectID{previous.Lessons[0].ID},
+	}, nil)
+	mocks.modules.EXPECT().GetScheduledByCourseId(gomock.Any(), courseId).Return([]domain.Module{previous, module}, nil)
+
+	prerequisites, err := gatingService.GetPrerequisites(context.Background(), studentId, module)
+	require.NoError(t, err)
+	require.Equal(t, map[primitive.ObjectID]domain.Prerequisite{
+		module.Lessons[0].ID: {Type: domain.PrerequisiteLesson, ModuleID: previous.ID, LessonID: &previous.Lessons[1].ID, Name: "scheduled"},
+	}, prerequisites)
+}
+
+func TestStudentsService_SetLessonFinishedPrerequisite(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	previousId := primitive.NewObjectID()
+	module := domain.Module{ID: primitive.NewObjectID(), SchoolID: schoolId, Lessons: []domain.Lesson{{ID: lessonId, Published: true}}}
+	student := domain.Student{ID: primitive.NewObjectID(), AvailableModules: []primitive.ObjectID{module.ID}}
+	prerequisite := domain.Prerequisite{Type: domain.PrerequisiteLesson, ModuleID: module.ID, LessonID: &previousId, Name: "first"}
+
+	mocks.modules.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(module, nil)
+	mocks.students.EXPECT().GetById(gomock.Any(), schoolId, student.ID).Return(student, nil)
+	mocks.gating.EXPECT().GetPrerequisites(gomock.Any(), student.ID, module).Return(map[primitive.ObjectID]domain.Prerequisite{
+		lessonId: prerequisite,
+	}, nil)
+
+	err := studentService.SetLessonFinished(context.Background(), student.ID, lessonId)
+	require.ErrorIs(t, err, domain.ErrPrerequisiteMissing)
+	require.Contains(t, err.Error(), `finish lesson "first" first`)
+}
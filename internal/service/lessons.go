@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
@@ -11,12 +13,15 @@ import (
 )
 
 type LessonsService struct {
-	repo        repository.Modules
-	contentRepo repository.LessonContent
+	repo         repository.Modules
+	contentRepo  repository.LessonContent
+	versionsRepo repository.LessonContentVersions
+	filesRepo    repository.Files
 }
 
-func NewLessonsService(repo repository.Modules, contentRepo repository.LessonContent) *LessonsService {
-	return &LessonsService{repo: repo, contentRepo: contentRepo}
+func NewLessonsService(repo repository.Modules, contentRepo repository.LessonContent,
+	versionsRepo repository.LessonContentVersions, filesRepo repository.Files) *LessonsService {
+	return &LessonsService{repo: repo, contentRepo: contentRepo, versionsRepo: versionsRepo, filesRepo: filesRepo}
 }
 
 func (s *LessonsService) Create(ctx context.Context, inp AddLessonInput) (primitive.ObjectID, error) {
@@ -67,11 +72,29 @@ func (s *LessonsService) GetById(ctx context.Context, lessonId primitive.ObjectI
 		return lesson, err
 	}
 
-	lesson.Content = content.Content
+	lesson.Blocks = content.GetBlocks()
+	lesson.Version = content.Version
 
 	return lesson, nil
 }
 
+func (s *LessonsService) GetDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) (*domain.LessonDraft, error) {
+	content, err := s.contentRepo.GetByLesson(ctx, lessonId)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if content.SchoolID != schoolId {
+		return nil, nil
+	}
+
+	return content.Draft, nil
+}
+
 func (s *LessonsService) Update(ctx context.Context, inp UpdateLessonInput) error {
 	id, err := primitive.ObjectIDFromHex(inp.LessonID)
 	if err != nil {
@@ -83,25 +106,237 @@ func (s *LessonsService) Update(ctx context.Context, inp UpdateLessonInput) erro
 		return err
 	}
 
-	if inp.Name != "" || inp.Position != nil || inp.Published != nil {
+	if err := validateSchedule(inp.Published, inp.PublishAt); err != nil {
+		return err
+	}
+
+	if inp.Name != "" || inp.Position != nil || inp.Published != nil || inp.PublishAt != nil || inp.DripDays != nil {
 		if err := s.repo.UpdateLesson(ctx, repository.UpdateLessonInput{
 			ID:        id,
 			Name:      inp.Name,
 			Position:  inp.Position,
 			Published: inp.Published,
+			PublishAt: inp.PublishAt,
+			DripDays:  inp.DripDays,
 			SchoolID:  schoolID,
 		}); err != nil {
 			return err
 		}
 	}
 
-	if inp.Content != "" {
-		if err := s.contentRepo.Update(ctx, schoolID, id, inp.Content); err != nil {
+	blocks := inp.Blocks
+	if blocks == nil && inp.Content != "" {
+		blocks = []domain.LessonBlock{{Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: inp.Content}}}
+	}
+
+	if blocks == nil {
+		return nil
+	}
+
+	blocks, err = s.prepareBlocks(ctx, schoolID, blocks)
+	if err != nil {
+		return err
+	}
+
+	return s.saveVersion(ctx, domain.LessonContentVersion{
+		LessonID: id,
+		SchoolID: schoolID,
+		AdminID:  inp.AdminID,
+		Blocks:   blocks,
+	}, !inp.Draft)
+}
+
+func (s *LessonsService) PublishDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) error {
+	draft, err := s.GetDraft(ctx, schoolId, lessonId)
+	if err != nil {
+		return err
+	}
+
+	if draft == nil {
+		return domain.ErrLessonDraftNotFound
+	}
+
+	return s.contentRepo.Publish(ctx, schoolId, lessonId, draft.Version, draft.Blocks)
+}
+
+func (s *LessonsService) GetVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID,
+	query domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error) {
+	return s.versionsRepo.GetByLesson(ctx, schoolId, lessonId, &query)
+}
+
+func (s *LessonsService) GetVersion(ctx context.Context, schoolId, lessonId primitive.ObjectID, version int) (domain.LessonContentVersion, error) {
+	return s.versionsRepo.GetByVersion(ctx, schoolId, lessonId, version)
+}
+
+func (s *LessonsService) DiffVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID, from, to int) (domain.LessonContentDiff, error) {
+	fromVersion, err := s.versionsRepo.GetByVersion(ctx, schoolId, lessonId, from)
+	if err != nil {
+		return domain.LessonContentDiff{}, err
+	}
+
+	toVersion, err := s.versionsRepo.GetByVersion(ctx, schoolId, lessonId, to)
+	if err != nil {
+		return domain.LessonContentDiff{}, err
+	}
+
+	return domain.LessonContentDiff{
+		From:    from,
+		To:      to,
+		Changes: domain.DiffLessonBlocks(fromVersion.Blocks, toVersion.Blocks),
+	}, nil
+}
+
+// RestoreVersion saves blocks of the old version as a new version, so history is never rewritten.
+func (s *LessonsService) RestoreVersion(ctx context.Context, inp RestoreLessonVersionInput) error {
+	version, err := s.versionsRepo.GetByVersion(ctx, inp.SchoolID, inp.LessonID, inp.Version)
+	if err != nil {
+		return err
+	}
+
+	return s.saveVersion(ctx, domain.LessonContentVersion{
+		LessonID:     inp.LessonID,
+		SchoolID:     inp.SchoolID,
+		AdminID:      inp.AdminID,
+		RestoredFrom: version.Version,
+		Blocks:       version.Blocks,
+	}, inp.Publish)
+}
+
+func (s *LessonsService) saveVersion(ctx context.Context, version domain.LessonContentVersion, publish bool) error {
+	content, err := s.contentRepo.NextVersion(ctx, version.SchoolID, version.LessonID)
+	if err != nil {
+		return err
+	}
+
+	// content saved before versioning has no versions, it's kept as the first one to be restorable
+	if content.LastVersion == 1 && len(content.GetBlocks()) != 0 {
+		if err := s.versionsRepo.Create(ctx, domain.LessonContentVersion{
+			LessonID:  version.LessonID,
+			SchoolID:  version.SchoolID,
+			Version:   content.LastVersion,
+			Blocks:    content.GetBlocks(),
+			CreatedAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		if content, err = s.contentRepo.NextVersion(ctx, version.SchoolID, version.LessonID); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	version.Version = content.LastVersion
+	version.CreatedAt = time.Now()
+
+	if err := s.versionsRepo.Create(ctx, version); err != nil {
+		return err
+	}
+
+	if publish {
+		return s.contentRepo.Publish(ctx, version.SchoolID, version.LessonID, version.Version, version.Blocks)
+	}
+
+	return s.contentRepo.SaveDraft(ctx, version.SchoolID, version.LessonID, domain.LessonDraft{
+		Version:   version.Version,
+		AdminID:   version.AdminID,
+		Blocks:    version.Blocks,
+		UpdatedAt: version.CreatedAt,
+	})
+}
+
+// prepareBlocks orders blocks as they were passed, generates missing ids, validates blocks
+// and copies details of referenced files.
+func (s *LessonsService) prepareBlocks(ctx context.Context, schoolId primitive.ObjectID, blocks []domain.LessonBlock) ([]domain.LessonBlock, error) {
+	if len(blocks) > domain.LessonMaxBlocks {
+		return nil, domain.ErrLessonBlocksTooMany
+	}
+
+	res := make([]domain.LessonBlock, len(blocks))
+	ids := make(map[primitive.ObjectID]bool, len(blocks))
+
+	for i, block := range blocks {
+		block.Position = uint(i)
+
+		if block.ID.IsZero() || ids[block.ID] {
+			block.ID = primitive.NewObjectID()
+		}
+
+		ids[block.ID] = true
+
+		if err := block.Validate(); err != nil {
+			return nil, err
+		}
+
+		if block.IsFileBlock() {
+			file, err := s.getBlockFile(ctx, schoolId, block)
+			if err != nil {
+				return nil, err
+			}
+
+			block.File = file
+		}
+
+		if block.Quiz != nil {
+			block.Quiz = prepareQuiz(*block.Quiz)
+		}
+
+		res[i] = block
+	}
+
+	return res, nil
+}
+
+func (s *LessonsService) getBlockFile(ctx context.Context, schoolId primitive.ObjectID, block domain.LessonBlock) (*domain.FileBlock, error) {
+	file, err := s.filesRepo.GetByID(ctx, block.File.FileID, schoolId)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: block %d (%s) file doesn't exists", domain.ErrLessonBlockInvalid, block.Position, block.Type)
+		}
+
+		return nil, err
+	}
+
+	if expected := block.FileType(); expected != "" && file.Type != expected {
+		return nil, fmt.Errorf("%w: block %d (%s) file must be %s", domain.ErrLessonBlockInvalid, block.Position, block.Type, expected)
+	}
+
+	if file.Status != domain.UploadedToStorage || file.URL == "" {
+		return nil, fmt.Errorf("%w: block %d (%s) file is not uploaded yet", domain.ErrLessonBlockInvalid, block.Position, block.Type)
+	}
+
+	return &domain.FileBlock{
+		FileID:      file.ID,
+		Caption:     block.File.Caption,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		URL:         file.URL,
+	}, nil
+}
+
+func prepareQuiz(quiz domain.QuizBlock) *domain.QuizBlock {
+	questions := make([]domain.QuizQuestion, len(quiz.Questions))
+
+	for i, question := range quiz.Questions {
+		if question.ID.IsZero() {
+			question.ID = primitive.NewObjectID()
+		}
+
+		options := make([]domain.QuizOption, len(question.Options))
+
+		for j, option := range question.Options {
+			if option.ID.IsZero() {
+				option.ID = primitive.NewObjectID()
+			}
+
+			options[j] = option
+		}
+
+		question.Options = options
+		questions[i] = question
+	}
+
+	return &domain.QuizBlock{Questions: questions}
 }
 
 func (s *LessonsService) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
@@ -109,5 +344,9 @@ func (s *LessonsService) Delete(ctx context.Context, schoolId, id primitive.Obje
 }
 
 func (s *LessonsService) DeleteContent(ctx context.Context, schoolId primitive.ObjectID, lessonIds []primitive.ObjectID) error {
-	return s.contentRepo.DeleteContent(ctx, schoolId, lessonIds)
+	if err := s.contentRepo.DeleteContent(ctx, schoolId, lessonIds); err != nil {
+		return err
+	}
+
+	return s.versionsRepo.DeleteByLessons(ctx, schoolId, lessonIds)
 }
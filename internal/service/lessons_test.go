@@ -0,0 +1,283 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type lessonsMocks struct {
+	modules  *mock_repository.MockModules
+	content  *mock_repository.MockLessonContent
+	versions *mock_repository.MockLessonContentVersions
+	files    *mock_repository.MockFiles
+}
+
+func mockLessonsService(t *testing.T) (*service.LessonsService, lessonsMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := lessonsMocks{
+		modules:  mock_repository.NewMockModules(mockCtl),
+		content:  mock_repository.NewMockLessonContent(mockCtl),
+		versions: mock_repository.NewMockLessonContentVersions(mockCtl),
+		files:    mock_repository.NewMockFiles(mockCtl),
+	}
+
+	return service.NewLessonsService(mocks.modules, mocks.content, mocks.versions, mocks.files), mocks
+}
+
+func TestLessonsService_UpdateBlocks(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	video := domain.File{
+		ID:          primitive.NewObjectID(),
+		SchoolID:    schoolId,
+		Type:        domain.Video,
+		ContentType: "video/mp4",
+		Name:        "intro.mp4",
+		Size:        1024,
+		Status:      domain.UploadedToStorage,
+		URL:         "https://cdn.creatly.me/intro.mp4",
+	}
+
+	mocks.files.EXPECT().GetByID(gomock.Any(), video.ID, schoolId).Return(video, nil)
+	mocks.content.EXPECT().NextVersion(gomock.Any(), schoolId, lessonId).Return(domain.LessonContent{LastVersion: 3}, nil)
+	mocks.versions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, version domain.LessonContentVersion) error {
+		require.Equal(t, 3, version.Version)
+		require.Len(t, version.Blocks, 3)
+
+		return nil
+	})
+	mocks.content.EXPECT().Publish(gomock.Any(), schoolId, lessonId, 3, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ primitive.ObjectID, _ int, blocks []domain.LessonBlock) error {
+			require.Len(t, blocks, 3)
+
+			for i, block := range blocks {
+				require.Equal(t, uint(i), block.Position)
+				require.False(t, block.ID.IsZero())
+			}
+
+			require.Equal(t, video.URL, blocks[1].File.URL)
+			require.Equal(t, "Intro", blocks[1].File.Caption)
+			require.False(t, blocks[2].Quiz.Questions[0].Options[1].ID.IsZero())
+
+			return nil
+		})
+
+	err := lessonsService.Update(context.Background(), service.UpdateLessonInput{
+		LessonID: lessonId.Hex(),
+		SchoolID: schoolId.Hex(),
+		Blocks: []domain.LessonBlock{
+			{Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: "<p>Hello</p>"}},
+			{Type: domain.LessonBlockVideo, File: &domain.FileBlock{FileID: video.ID, Caption: "Intro", URL: "https://evil.com"}},
+			{Type: domain.LessonBlockQuiz, Quiz: &domain.QuizBlock{Questions: []domain.QuizQuestion{{
+				Text:    "2 + 2?",
+				Options: []domain.QuizOption{{Text: "3"}, {Text: "4", Correct: true}},
+			}}}},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestLessonsService_UpdateBlocksInvalid(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	image := domain.File{ID: primitive.NewObjectID(), Type: domain.Image, Status: domain.UploadedToStorage, URL: "https://cdn.creatly.me/a.png"}
+
+	mocks.files.EXPECT().GetByID(gomock.Any(), image.ID, schoolId).Return(image, nil)
+
+	for name, block := range map[string]domain.LessonBlock{
+		"unknown type":      {Type: "table", Text: &domain.TextBlock{HTML: "text"}},
+		"wrong payload":     {Type: domain.LessonBlockCode, Text: &domain.TextBlock{HTML: "text"}},
+		"empty text":        {Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: " "}},
+		"http embed":        {Type: domain.LessonBlockEmbed, Embed: &domain.EmbedBlock{URL: "http://youtube.com/embed/1"}},
+		"no correct answer": {Type: domain.LessonBlockQuiz, Quiz: &domain.QuizBlock{Questions: []domain.QuizQuestion{{Text: "?", Options: []domain.QuizOption{{Text: "a"}, {Text: "b"}}}}}},
+		"image as video":    {Type: domain.LessonBlockVideo, File: &domain.FileBlock{FileID: image.ID}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := lessonsService.Update(context.Background(), service.UpdateLessonInput{
+				LessonID: primitive.NewObjectID().Hex(),
+				SchoolID: schoolId.Hex(),
+				Blocks:   []domain.LessonBlock{block},
+			})
+			require.ErrorIs(t, err, domain.ErrLessonBlockInvalid)
+		})
+	}
+}
+
+func TestLessonsService_GetByIdLegacyContent(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	lessonId := primitive.NewObjectID()
+
+	mocks.modules.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(domain.Module{Lessons: []domain.Lesson{{ID: lessonId}}}, nil)
+	mocks.content.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(domain.LessonContent{LessonID: lessonId, Content: "<p>old</p>"}, nil)
+
+	lesson, err := lessonsService.GetById(context.Background(), lessonId)
+	require.NoError(t, err)
+	require.Equal(t, []domain.LessonBlock{{
+		ID:   lessonId,
+		Type: domain.LessonBlockText,
+		Text: &domain.TextBlock{HTML: "<p>old</p>"},
+	}}, lesson.Blocks)
+}
+
+func TestWithoutAnswers(t *testing.T) {
+	blocks := []domain.LessonBlock{{Type: domain.LessonBlockQuiz, Quiz: &domain.QuizBlock{Questions: []domain.QuizQuestion{{
+		Text:    "?",
+		Options: []domain.QuizOption{{Text: "a", Correct: true}, {Text: "b"}},
+	}}}}}
+
+	res := domain.WithoutAnswers(blocks)
+
+	require.False(t, res[0].Quiz.Questions[0].Options[0].Correct)
+	require.True(t, blocks[0].Quiz.Questions[0].Options[0].Correct)
+}
+
+func TestLessonsService_UpdateDraft(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	adminId := primitive.NewObjectID()
+
+	mocks.content.EXPECT().NextVersion(gomock.Any(), schoolId, lessonId).Return(domain.LessonContent{LastVersion: 2}, nil)
+	mocks.versions.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mocks.content.EXPECT().SaveDraft(gomock.Any(), schoolId, lessonId, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ primitive.ObjectID, draft domain.LessonDraft) error {
+			require.Equal(t, 2, draft.Version)
+			require.Equal(t, adminId, draft.AdminID)
+			require.Equal(t, "<p>draft</p>", draft.Blocks[0].Text.HTML)
+
+			return nil
+		})
+
+	err := lessonsService.Update(context.Background(), service.UpdateLessonInput{
+		LessonID: lessonId.Hex(),
+		SchoolID: schoolId.Hex(),
+		AdminID:  adminId,
+		Content:  "<p>draft</p>",
+		Draft:    true,
+	})
+	require.NoError(t, err)
+}
+
+func TestLessonsService_UpdateKeepsLegacyContent(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	legacy := domain.LessonContent{LessonID: lessonId, SchoolID: schoolId, LastVersion: 1, Content: "<p>legacy</p>"}
+
+	gomock.InOrder(
+		mocks.content.EXPECT().NextVersion(gomock.Any(), schoolId, lessonId).Return(legacy, nil),
+		mocks.versions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, version domain.LessonContentVersion) error {
+			require.Equal(t, 1, version.Version)
+			require.Equal(t, "<p>legacy</p>", version.Blocks[0].Text.HTML)
+
+			return nil
+		}),
+		mocks.content.EXPECT().NextVersion(gomock.Any(), schoolId, lessonId).Return(domain.LessonContent{LastVersion: 2}, nil),
+		mocks.versions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, version domain.LessonContentVersion) error {
+			require.Equal(t, 2, version.Version)
+			require.Equal(t, "<p>new</p>", version.Blocks[0].Text.HTML)
+
+			return nil
+		}),
+		mocks.content.EXPECT().Publish(gomock.Any(), schoolId, lessonId, 2, gomock.Any()).Return(nil),
+	)
+
+	err := lessonsService.Update(context.Background(), service.UpdateLessonInput{
+		LessonID: lessonId.Hex(),
+		SchoolID: schoolId.Hex(),
+		Content:  "<p>new</p>",
+	})
+	require.NoError(t, err)
+}
+
+func TestLessonsService_PublishDraft(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	draft := &domain.LessonDraft{
+		Version: 4,
+		Blocks:  []domain.LessonBlock{{ID: primitive.NewObjectID(), Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: "new"}}},
+	}
+
+	mocks.content.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(domain.LessonContent{SchoolID: schoolId, Version: 3, Draft: draft}, nil)
+	mocks.content.EXPECT().Publish(gomock.Any(), schoolId, lessonId, 4, draft.Blocks).Return(nil)
+
+	require.NoError(t, lessonsService.PublishDraft(context.Background(), schoolId, lessonId))
+
+	mocks.content.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(domain.LessonContent{SchoolID: schoolId, Version: 4}, nil)
+
+	require.ErrorIs(t, lessonsService.PublishDraft(context.Background(), schoolId, lessonId), domain.ErrLessonDraftNotFound)
+}
+
+func TestLessonsService_RestoreVersion(t *testing.T) {
+	lessonsService, mocks := mockLessonsService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	blocks := []domain.LessonBlock{{ID: primitive.NewObjectID(), Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: "old"}}}
+
+	mocks.versions.EXPECT().GetByVersion(gomock.Any(), schoolId, lessonId, 1).Return(domain.LessonContentVersion{Version: 1, Blocks: blocks}, nil)
+	mocks.content.EXPECT().NextVersion(gomock.Any(), schoolId, lessonId).Return(domain.LessonContent{LastVersion: 5}, nil)
+	mocks.versions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, version domain.LessonContentVersion) error {
+		require.Equal(t, 5, version.Version)
+		require.Equal(t, 1, version.RestoredFrom)
+
+		return nil
+	})
+	mocks.content.EXPECT().Publish(gomock.Any(), schoolId, lessonId, 5, blocks).Return(nil)
+
+	err := lessonsService.RestoreVersion(context.Background(), service.RestoreLessonVersionInput{
+		SchoolID: schoolId,
+		LessonID: lessonId,
+		Version:  1,
+		Publish:  true,
+	})
+	require.NoError(t, err)
+}
+
+func TestDiffLessonBlocks(t *testing.T) {
+	text := domain.LessonBlock{ID: primitive.NewObjectID(), Type: domain.LessonBlockText, Text: &domain.TextBlock{HTML: "a"}}
+	code := domain.LessonBlock{ID: primitive.NewObjectID(), Type: domain.LessonBlockCode, Position: 1, Code: &domain.CodeBlock{Code: "x"}}
+	embed := domain.LessonBlock{ID: primitive.NewObjectID(), Type: domain.LessonBlockEmbed, Position: 2, Embed: &domain.EmbedBlock{URL: "https://a.com"}}
+
+	changedText := text
+	changedText.Position = 1
+	changedText.Text = &domain.TextBlock{HTML: "b"}
+
+	movedCode := code
+	movedCode.Position = 0
+
+	quiz := domain.LessonBlock{ID: primitive.NewObjectID(), Type: domain.LessonBlockQuiz, Position: 2, Quiz: &domain.QuizBlock{}}
+
+	changes := domain.DiffLessonBlocks([]domain.LessonBlock{text, code, embed}, []domain.LessonBlock{movedCode, changedText, quiz})
+
+	res := make(map[primitive.ObjectID]string, len(changes))
+	for _, change := range changes {
+		res[change.BlockID] = change.Change
+	}
+
+	require.Equal(t, map[primitive.ObjectID]string{
+		text.ID:  domain.LessonBlockChanged,
+		code.ID:  domain.LessonBlockMoved,
+		embed.ID: domain.LessonBlockRemoved,
+		quiz.ID:  domain.LessonBlockAdded,
+	}, res)
+}
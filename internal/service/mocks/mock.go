@@ -7,6 +7,7 @@ package mock_service
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/zhashkevych/creatly-backend/internal/domain"
@@ -14,6 +15,222 @@ import (
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MockPasswordResets is a mock of PasswordResets interface.
+type MockPasswordResets struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetsMockRecorder
+}
+
+// MockPasswordResetsMockRecorder is the mock recorder for MockPasswordResets.
+type MockPasswordResetsMockRecorder struct {
+	mock *MockPasswordResets
+}
+
+// NewMockPasswordResets creates a new mock instance.
+func NewMockPasswordResets(ctrl *gomock.Controller) *MockPasswordResets {
+	mock := &MockPasswordResets{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResets) EXPECT() *MockPasswordResetsMockRecorder {
+	return m.recorder
+}
+
+// ConsumeToken mocks base method.
+func (m *MockPasswordResets) ConsumeToken(ctx context.Context, token, role string, schoolId primitive.ObjectID) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeToken", ctx, token, role, schoolId)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeToken indicates an expected call of ConsumeToken.
+func (mr *MockPasswordResetsMockRecorder) ConsumeToken(ctx, token, role, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeToken", reflect.TypeOf((*MockPasswordResets)(nil).ConsumeToken), ctx, token, role, schoolId)
+}
+
+// CreateToken mocks base method.
+func (m *MockPasswordResets) CreateToken(ctx context.Context, ownerId primitive.ObjectID, role string, schoolId primitive.ObjectID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, ownerId, role, schoolId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockPasswordResetsMockRecorder) CreateToken(ctx, ownerId, role, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockPasswordResets)(nil).CreateToken), ctx, ownerId, role, schoolId)
+}
+
+// MockSignInAttempts is a mock of SignInAttempts interface.
+type MockSignInAttempts struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignInAttemptsMockRecorder
+}
+
+// MockSignInAttemptsMockRecorder is the mock recorder for MockSignInAttempts.
+type MockSignInAttemptsMockRecorder struct {
+	mock *MockSignInAttempts
+}
+
+// NewMockSignInAttempts creates a new mock instance.
+func NewMockSignInAttempts(ctrl *gomock.Controller) *MockSignInAttempts {
+	mock := &MockSignInAttempts{ctrl: ctrl}
+	mock.recorder = &MockSignInAttemptsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignInAttempts) EXPECT() *MockSignInAttemptsMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockSignInAttempts) Check(key service.SignInAttemptsKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockSignInAttemptsMockRecorder) Check(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSignInAttempts)(nil).Check), key)
+}
+
+// Failed mocks base method.
+func (m *MockSignInAttempts) Failed(key service.SignInAttemptsKey) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Failed", key)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Failed indicates an expected call of Failed.
+func (mr *MockSignInAttemptsMockRecorder) Failed(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failed", reflect.TypeOf((*MockSignInAttempts)(nil).Failed), key)
+}
+
+// Succeeded mocks base method.
+func (m *MockSignInAttempts) Succeeded(key service.SignInAttemptsKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeeded", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeeded indicates an expected call of Succeeded.
+func (mr *MockSignInAttemptsMockRecorder) Succeeded(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeeded", reflect.TypeOf((*MockSignInAttempts)(nil).Succeeded), key)
+}
+
+// MockSessions is a mock of Sessions interface.
+type MockSessions struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionsMockRecorder
+}
+
+// MockSessionsMockRecorder is the mock recorder for MockSessions.
+type MockSessionsMockRecorder struct {
+	mock *MockSessions
+}
+
+// NewMockSessions creates a new mock instance.
+func NewMockSessions(ctrl *gomock.Controller) *MockSessions {
+	mock := &MockSessions{ctrl: ctrl}
+	mock.recorder = &MockSessionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessions) EXPECT() *MockSessionsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessions) Create(ctx context.Context, inp service.CreateSessionInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, inp)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionsMockRecorder) Create(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessions)(nil).Create), ctx, inp)
+}
+
+// GetByOwner mocks base method.
+func (m *MockSessions) GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", ctx, ownerId)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockSessionsMockRecorder) GetByOwner(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockSessions)(nil).GetByOwner), ctx, ownerId)
+}
+
+// Refresh mocks base method.
+func (m *MockSessions) Refresh(ctx context.Context, inp service.RefreshSessionInput) (domain.Session, service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, inp)
+	ret0, _ := ret[0].(domain.Session)
+	ret1, _ := ret[1].(service.Tokens)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSessionsMockRecorder) Refresh(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSessions)(nil).Refresh), ctx, inp)
+}
+
+// Revoke mocks base method.
+func (m *MockSessions) Revoke(ctx context.Context, ownerId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, ownerId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionsMockRecorder) Revoke(ctx, ownerId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessions)(nil).Revoke), ctx, ownerId, id)
+}
+
+// RevokeAll mocks base method.
+func (m *MockSessions) RevokeAll(ctx context.Context, ownerId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionsMockRecorder) RevokeAll(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSessions)(nil).RevokeAll), ctx, ownerId)
+}
+
 // MockUsers is a mock of Users interface.
 type MockUsers struct {
 	ctrl     *gomock.Controller
@@ -52,19 +269,106 @@ func (mr *MockUsersMockRecorder) CreateSchool(ctx, userID, schoolName interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchool", reflect.TypeOf((*MockUsers)(nil).CreateSchool), ctx, userID, schoolName)
 }
 
+// GetSchoolById mocks base method.
+func (m *MockUsers) GetSchoolById(ctx context.Context, userID, schoolID primitive.ObjectID) (domain.School, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchoolById", ctx, userID, schoolID)
+	ret0, _ := ret[0].(domain.School)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchoolById indicates an expected call of GetSchoolById.
+func (mr *MockUsersMockRecorder) GetSchoolById(ctx, userID, schoolID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchoolById", reflect.TypeOf((*MockUsers)(nil).GetSchoolById), ctx, userID, schoolID)
+}
+
+// GetSchools mocks base method.
+func (m *MockUsers) GetSchools(ctx context.Context, userID primitive.ObjectID) ([]domain.School, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchools", ctx, userID)
+	ret0, _ := ret[0].([]domain.School)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchools indicates an expected call of GetSchools.
+func (mr *MockUsersMockRecorder) GetSchools(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchools", reflect.TypeOf((*MockUsers)(nil).GetSchools), ctx, userID)
+}
+
+// ImpersonateAdmin mocks base method.
+func (m *MockUsers) ImpersonateAdmin(ctx context.Context, input service.UserImpersonateAdminInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImpersonateAdmin", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImpersonateAdmin indicates an expected call of ImpersonateAdmin.
+func (mr *MockUsersMockRecorder) ImpersonateAdmin(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImpersonateAdmin", reflect.TypeOf((*MockUsers)(nil).ImpersonateAdmin), ctx, input)
+}
+
 // RefreshTokens mocks base method.
-func (m *MockUsers) RefreshTokens(ctx context.Context, refreshToken string) (service.Tokens, error) {
+func (m *MockUsers) RefreshTokens(ctx context.Context, input service.UserRefreshTokensInput) (service.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshTokens", ctx, refreshToken)
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, input)
 	ret0, _ := ret[0].(service.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshTokens indicates an expected call of RefreshTokens.
-func (mr *MockUsersMockRecorder) RefreshTokens(ctx, refreshToken interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) RefreshTokens(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUsers)(nil).RefreshTokens), ctx, input)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockUsers) RequestPasswordReset(ctx context.Context, input service.RequestPasswordResetInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockUsersMockRecorder) RequestPasswordReset(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockUsers)(nil).RequestPasswordReset), ctx, input)
+}
+
+// ResendVerification mocks base method.
+func (m *MockUsers) ResendVerification(ctx context.Context, userID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockUsersMockRecorder) ResendVerification(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockUsers)(nil).ResendVerification), ctx, userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUsers) ResetPassword(ctx context.Context, input service.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUsersMockRecorder) ResetPassword(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUsers)(nil).RefreshTokens), ctx, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUsers)(nil).ResetPassword), ctx, input)
 }
 
 // SignIn mocks base method.
@@ -96,6 +400,20 @@ func (mr *MockUsersMockRecorder) SignUp(ctx, input interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockUsers)(nil).SignUp), ctx, input)
 }
 
+// UpdateSchool mocks base method.
+func (m *MockUsers) UpdateSchool(ctx context.Context, userID, schoolID primitive.ObjectID, input domain.UpdateSchoolSettingsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchool", ctx, userID, schoolID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchool indicates an expected call of UpdateSchool.
+func (mr *MockUsersMockRecorder) UpdateSchool(ctx, userID, schoolID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchool", reflect.TypeOf((*MockUsers)(nil).UpdateSchool), ctx, userID, schoolID, input)
+}
+
 // Verify mocks base method.
 func (m *MockUsers) Verify(ctx context.Context, userID primitive.ObjectID, hash string) error {
 	m.ctrl.T.Helper()
@@ -206,6 +524,49 @@ func (mr *MockSchoolsMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockSchools)(nil).GetById), ctx, id)
 }
 
+// GetByIds mocks base method.
+func (m *MockSchools) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.School)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockSchoolsMockRecorder) GetByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockSchools)(nil).GetByIds), ctx, ids)
+}
+
+// SetOIDCProviders mocks base method.
+func (m *MockSchools) SetOIDCProviders(ctx context.Context, schoolId primitive.ObjectID, providers []domain.OIDCProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOIDCProviders", ctx, schoolId, providers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOIDCProviders indicates an expected call of SetOIDCProviders.
+func (mr *MockSchoolsMockRecorder) SetOIDCProviders(ctx, schoolId, providers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCProviders", reflect.TypeOf((*MockSchools)(nil).SetOIDCProviders), ctx, schoolId, providers)
+}
+
+// SetStudentFields mocks base method.
+func (m *MockSchools) SetStudentFields(ctx context.Context, schoolId primitive.ObjectID, fields []domain.StudentField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStudentFields", ctx, schoolId, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStudentFields indicates an expected call of SetStudentFields.
+func (mr *MockSchoolsMockRecorder) SetStudentFields(ctx, schoolId, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStudentFields", reflect.TypeOf((*MockSchools)(nil).SetStudentFields), ctx, schoolId, fields)
+}
+
 // UpdateSettings mocks base method.
 func (m *MockSchools) UpdateSettings(ctx context.Context, schoolId primitive.ObjectID, input domain.UpdateSchoolSettingsInput) error {
 	m.ctrl.T.Helper()
@@ -243,6 +604,49 @@ func (m *MockStudents) EXPECT() *MockStudentsMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockStudents) ChangePassword(ctx context.Context, input service.StudentChangePasswordInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockStudentsMockRecorder) ChangePassword(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockStudents)(nil).ChangePassword), ctx, input)
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockStudents) ConfirmEmailChange(ctx context.Context, input service.StudentConfirmEmailChangeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockStudentsMockRecorder) ConfirmEmailChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockStudents)(nil).ConfirmEmailChange), ctx, input)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStudents) DeleteAccount(ctx context.Context, input service.StudentDeleteAccountInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStudentsMockRecorder) DeleteAccount(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStudents)(nil).DeleteAccount), ctx, input)
+}
+
 // GetById mocks base method.
 func (m *MockStudents) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.Student, error) {
 	m.ctrl.T.Helper()
@@ -304,78 +708,221 @@ func (mr *MockStudentsMockRecorder) GetModuleContent(ctx, schoolId, studentId, m
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModuleContent", reflect.TypeOf((*MockStudents)(nil).GetModuleContent), ctx, schoolId, studentId, moduleId)
 }
 
+// GetOIDCAuthURL mocks base method.
+func (m *MockStudents) GetOIDCAuthURL(ctx context.Context, input service.StudentOIDCAuthURLInput) (service.OIDCAuthURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOIDCAuthURL", ctx, input)
+	ret0, _ := ret[0].(service.OIDCAuthURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOIDCAuthURL indicates an expected call of GetOIDCAuthURL.
+func (mr *MockStudentsMockRecorder) GetOIDCAuthURL(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOIDCAuthURL", reflect.TypeOf((*MockStudents)(nil).GetOIDCAuthURL), ctx, input)
+}
+
 // GiveAccessToOffer mocks base method.
-func (m *MockStudents) GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error {
+func (m *MockStudents) GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer, adminId primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GiveAccessToOffer", ctx, studentId, offer)
+	ret := m.ctrl.Call(m, "GiveAccessToOffer", ctx, studentId, offer, adminId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GiveAccessToOffer indicates an expected call of GiveAccessToOffer.
-func (mr *MockStudentsMockRecorder) GiveAccessToOffer(ctx, studentId, offer interface{}) *gomock.Call {
+func (mr *MockStudentsMockRecorder) GiveAccessToOffer(ctx, studentId, offer, adminId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveAccessToOffer", reflect.TypeOf((*MockStudents)(nil).GiveAccessToOffer), ctx, studentId, offer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveAccessToOffer", reflect.TypeOf((*MockStudents)(nil).GiveAccessToOffer), ctx, studentId, offer, adminId)
 }
 
 // RefreshTokens mocks base method.
-func (m *MockStudents) RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (service.Tokens, error) {
+func (m *MockStudents) RefreshTokens(ctx context.Context, input service.SchoolRefreshTokensInput) (service.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshTokens", ctx, schoolId, refreshToken)
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, input)
 	ret0, _ := ret[0].(service.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshTokens indicates an expected call of RefreshTokens.
-func (mr *MockStudentsMockRecorder) RefreshTokens(ctx, schoolId, refreshToken interface{}) *gomock.Call {
+func (mr *MockStudentsMockRecorder) RefreshTokens(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockStudents)(nil).RefreshTokens), ctx, schoolId, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockStudents)(nil).RefreshTokens), ctx, input)
 }
 
 // RemoveAccessToOffer mocks base method.
-func (m *MockStudents) RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error {
+func (m *MockStudents) RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer, adminId primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveAccessToOffer", ctx, studentId, offer)
+	ret := m.ctrl.Call(m, "RemoveAccessToOffer", ctx, studentId, offer, adminId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveAccessToOffer indicates an expected call of RemoveAccessToOffer.
-func (mr *MockStudentsMockRecorder) RemoveAccessToOffer(ctx, studentId, offer interface{}) *gomock.Call {
+func (mr *MockStudentsMockRecorder) RemoveAccessToOffer(ctx, studentId, offer, adminId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccessToOffer", reflect.TypeOf((*MockStudents)(nil).RemoveAccessToOffer), ctx, studentId, offer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccessToOffer", reflect.TypeOf((*MockStudents)(nil).RemoveAccessToOffer), ctx, studentId, offer, adminId)
 }
 
-// SetLessonFinished mocks base method.
-func (m *MockStudents) SetLessonFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+// RequestConfirmationCode mocks base method.
+func (m *MockStudents) RequestConfirmationCode(ctx context.Context, input service.StudentRequestConfirmationCodeInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetLessonFinished", ctx, studentId, lessonId)
+	ret := m.ctrl.Call(m, "RequestConfirmationCode", ctx, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetLessonFinished indicates an expected call of SetLessonFinished.
-func (mr *MockStudentsMockRecorder) SetLessonFinished(ctx, studentId, lessonId interface{}) *gomock.Call {
+// RequestConfirmationCode indicates an expected call of RequestConfirmationCode.
+func (mr *MockStudentsMockRecorder) RequestConfirmationCode(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLessonFinished", reflect.TypeOf((*MockStudents)(nil).SetLessonFinished), ctx, studentId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestConfirmationCode", reflect.TypeOf((*MockStudents)(nil).RequestConfirmationCode), ctx, input)
 }
 
-// SignIn mocks base method.
-func (m *MockStudents) SignIn(ctx context.Context, input service.SchoolSignInInput) (service.Tokens, error) {
+// RequestEmailChange mocks base method.
+func (m *MockStudents) RequestEmailChange(ctx context.Context, input service.StudentChangeEmailInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignIn", ctx, input)
-	ret0, _ := ret[0].(service.Tokens)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SignIn indicates an expected call of SignIn.
-func (mr *MockStudentsMockRecorder) SignIn(ctx, input interface{}) *gomock.Call {
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockStudentsMockRecorder) RequestEmailChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockStudents)(nil).RequestEmailChange), ctx, input)
+}
+
+// RequestMagicLink mocks base method.
+func (m *MockStudents) RequestMagicLink(ctx context.Context, input service.StudentRequestMagicLinkInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestMagicLink", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestMagicLink indicates an expected call of RequestMagicLink.
+func (mr *MockStudentsMockRecorder) RequestMagicLink(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestMagicLink", reflect.TypeOf((*MockStudents)(nil).RequestMagicLink), ctx, input)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockStudents) RequestPasswordReset(ctx context.Context, input service.RequestPasswordResetInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockStudentsMockRecorder) RequestPasswordReset(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockStudents)(nil).RequestPasswordReset), ctx, input)
+}
+
+// ResendVerification mocks base method.
+func (m *MockStudents) ResendVerification(ctx context.Context, input service.ResendVerificationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockStudentsMockRecorder) ResendVerification(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockStudents)(nil).ResendVerification), ctx, input)
+}
+
+// ResendVerificationById mocks base method.
+func (m *MockStudents) ResendVerificationById(ctx context.Context, schoolId, studentId primitive.ObjectID, schoolDomain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationById", ctx, schoolId, studentId, schoolDomain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationById indicates an expected call of ResendVerificationById.
+func (mr *MockStudentsMockRecorder) ResendVerificationById(ctx, schoolId, studentId, schoolDomain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationById", reflect.TypeOf((*MockStudents)(nil).ResendVerificationById), ctx, schoolId, studentId, schoolDomain)
+}
+
+// ResetPassword mocks base method.
+func (m *MockStudents) ResetPassword(ctx context.Context, input service.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockStudentsMockRecorder) ResetPassword(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockStudents)(nil).ResetPassword), ctx, input)
+}
+
+// SetLessonFinished mocks base method.
+func (m *MockStudents) SetLessonFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLessonFinished", ctx, studentId, lessonId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLessonFinished indicates an expected call of SetLessonFinished.
+func (mr *MockStudentsMockRecorder) SetLessonFinished(ctx, studentId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLessonFinished", reflect.TypeOf((*MockStudents)(nil).SetLessonFinished), ctx, studentId, lessonId)
+}
+
+// SignIn mocks base method.
+func (m *MockStudents) SignIn(ctx context.Context, input service.SchoolSignInInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockStudentsMockRecorder) SignIn(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockStudents)(nil).SignIn), ctx, input)
 }
 
+// SignInMagicLink mocks base method.
+func (m *MockStudents) SignInMagicLink(ctx context.Context, input service.StudentMagicLinkSignInInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInMagicLink", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInMagicLink indicates an expected call of SignInMagicLink.
+func (mr *MockStudentsMockRecorder) SignInMagicLink(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInMagicLink", reflect.TypeOf((*MockStudents)(nil).SignInMagicLink), ctx, input)
+}
+
+// SignInOIDC mocks base method.
+func (m *MockStudents) SignInOIDC(ctx context.Context, input service.StudentOIDCSignInInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInOIDC", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInOIDC indicates an expected call of SignInOIDC.
+func (mr *MockStudentsMockRecorder) SignInOIDC(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInOIDC", reflect.TypeOf((*MockStudents)(nil).SignInOIDC), ctx, input)
+}
+
 // SignUp mocks base method.
 func (m *MockStudents) SignUp(ctx context.Context, input service.StudentSignUpInput) error {
 	m.ctrl.T.Helper()
@@ -390,6 +937,20 @@ func (mr *MockStudentsMockRecorder) SignUp(ctx, input interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockStudents)(nil).SignUp), ctx, input)
 }
 
+// UpdateAccount mocks base method.
+func (m *MockStudents) UpdateAccount(ctx context.Context, input service.UpdateStudentAccountInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockStudentsMockRecorder) UpdateAccount(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStudents)(nil).UpdateAccount), ctx, input)
+}
+
 // Verify mocks base method.
 func (m *MockStudents) Verify(ctx context.Context, hash string) error {
 	m.ctrl.T.Helper()
@@ -404,6 +965,291 @@ func (mr *MockStudentsMockRecorder) Verify(ctx, hash interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockStudents)(nil).Verify), ctx, hash)
 }
 
+// MockStudentExports is a mock of StudentExports interface.
+type MockStudentExports struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentExportsMockRecorder
+}
+
+// MockStudentExportsMockRecorder is the mock recorder for MockStudentExports.
+type MockStudentExportsMockRecorder struct {
+	mock *MockStudentExports
+}
+
+// NewMockStudentExports creates a new mock instance.
+func NewMockStudentExports(ctrl *gomock.Controller) *MockStudentExports {
+	mock := &MockStudentExports{ctrl: ctrl}
+	mock.recorder = &MockStudentExportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentExports) EXPECT() *MockStudentExportsMockRecorder {
+	return m.recorder
+}
+
+// DeleteByStudent mocks base method.
+func (m *MockStudentExports) DeleteByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByStudent", ctx, schoolId, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByStudent indicates an expected call of DeleteByStudent.
+func (mr *MockStudentExportsMockRecorder) DeleteByStudent(ctx, schoolId, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByStudent", reflect.TypeOf((*MockStudentExports)(nil).DeleteByStudent), ctx, schoolId, studentId)
+}
+
+// Export mocks base method.
+func (m *MockStudentExports) Export(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, schoolId, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockStudentExportsMockRecorder) Export(ctx, schoolId, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockStudentExports)(nil).Export), ctx, schoolId, studentId)
+}
+
+// InitExpiredExportsCleaner mocks base method.
+func (m *MockStudentExports) InitExpiredExportsCleaner(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InitExpiredExportsCleaner", ctx)
+}
+
+// InitExpiredExportsCleaner indicates an expected call of InitExpiredExportsCleaner.
+func (mr *MockStudentExportsMockRecorder) InitExpiredExportsCleaner(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitExpiredExportsCleaner", reflect.TypeOf((*MockStudentExports)(nil).InitExpiredExportsCleaner), ctx)
+}
+
+// MockStudentImports is a mock of StudentImports interface.
+type MockStudentImports struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentImportsMockRecorder
+}
+
+// MockStudentImportsMockRecorder is the mock recorder for MockStudentImports.
+type MockStudentImportsMockRecorder struct {
+	mock *MockStudentImports
+}
+
+// NewMockStudentImports creates a new mock instance.
+func NewMockStudentImports(ctrl *gomock.Controller) *MockStudentImports {
+	mock := &MockStudentImports{ctrl: ctrl}
+	mock.recorder = &MockStudentImportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentImports) EXPECT() *MockStudentImportsMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockStudentImports) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, schoolId, id)
+	ret0, _ := ret[0].(domain.StudentImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockStudentImportsMockRecorder) GetById(ctx, schoolId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockStudentImports)(nil).GetById), ctx, schoolId, id)
+}
+
+// Start mocks base method.
+func (m *MockStudentImports) Start(ctx context.Context, input service.StartStudentImportInput) (domain.StudentImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, input)
+	ret0, _ := ret[0].(domain.StudentImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockStudentImportsMockRecorder) Start(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockStudentImports)(nil).Start), ctx, input)
+}
+
+// MockStudentListExports is a mock of StudentListExports interface.
+type MockStudentListExports struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentListExportsMockRecorder
+}
+
+// MockStudentListExportsMockRecorder is the mock recorder for MockStudentListExports.
+type MockStudentListExportsMockRecorder struct {
+	mock *MockStudentListExports
+}
+
+// NewMockStudentListExports creates a new mock instance.
+func NewMockStudentListExports(ctrl *gomock.Controller) *MockStudentListExports {
+	mock := &MockStudentListExports{ctrl: ctrl}
+	mock.recorder = &MockStudentListExportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentListExports) EXPECT() *MockStudentListExportsMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockStudentListExports) Export(ctx context.Context, input service.ExportStudentsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockStudentListExportsMockRecorder) Export(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockStudentListExports)(nil).Export), ctx, input)
+}
+
+// MockStudentAttributes is a mock of StudentAttributes interface.
+type MockStudentAttributes struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentAttributesMockRecorder
+}
+
+// MockStudentAttributesMockRecorder is the mock recorder for MockStudentAttributes.
+type MockStudentAttributesMockRecorder struct {
+	mock *MockStudentAttributes
+}
+
+// NewMockStudentAttributes creates a new mock instance.
+func NewMockStudentAttributes(ctrl *gomock.Controller) *MockStudentAttributes {
+	mock := &MockStudentAttributes{ctrl: ctrl}
+	mock.recorder = &MockStudentAttributesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentAttributes) EXPECT() *MockStudentAttributesMockRecorder {
+	return m.recorder
+}
+
+// SetFields mocks base method.
+func (m *MockStudentAttributes) SetFields(ctx context.Context, input service.SetStudentFieldsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFields", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFields indicates an expected call of SetFields.
+func (mr *MockStudentAttributesMockRecorder) SetFields(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFields", reflect.TypeOf((*MockStudentAttributes)(nil).SetFields), ctx, input)
+}
+
+// UpdateTags mocks base method.
+func (m *MockStudentAttributes) UpdateTags(ctx context.Context, input service.UpdateStudentTagsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTags", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTags indicates an expected call of UpdateTags.
+func (mr *MockStudentAttributesMockRecorder) UpdateTags(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTags", reflect.TypeOf((*MockStudentAttributes)(nil).UpdateTags), ctx, input)
+}
+
+// MockStudentSegments is a mock of StudentSegments interface.
+type MockStudentSegments struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentSegmentsMockRecorder
+}
+
+// MockStudentSegmentsMockRecorder is the mock recorder for MockStudentSegments.
+type MockStudentSegmentsMockRecorder struct {
+	mock *MockStudentSegments
+}
+
+// NewMockStudentSegments creates a new mock instance.
+func NewMockStudentSegments(ctrl *gomock.Controller) *MockStudentSegments {
+	mock := &MockStudentSegments{ctrl: ctrl}
+	mock.recorder = &MockStudentSegmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentSegments) EXPECT() *MockStudentSegmentsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStudentSegments) Create(ctx context.Context, input service.CreateStudentSegmentInput) (domain.StudentSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(domain.StudentSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStudentSegmentsMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStudentSegments)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockStudentSegments) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, schoolId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStudentSegmentsMockRecorder) Delete(ctx, schoolId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentSegments)(nil).Delete), ctx, schoolId, id)
+}
+
+// GetBySchool mocks base method.
+func (m *MockStudentSegments) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySchool", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.StudentSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySchool indicates an expected call of GetBySchool.
+func (mr *MockStudentSegmentsMockRecorder) GetBySchool(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockStudentSegments)(nil).GetBySchool), ctx, schoolId)
+}
+
+// Update mocks base method.
+func (m *MockStudentSegments) Update(ctx context.Context, input service.UpdateStudentSegmentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStudentSegmentsMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentSegments)(nil).Update), ctx, input)
+}
+
 // MockStudentLessons is a mock of StudentLessons interface.
 type MockStudentLessons struct {
 	ctrl     *gomock.Controller
@@ -428,31 +1274,216 @@ func (m *MockStudentLessons) EXPECT() *MockStudentLessonsMockRecorder {
 }
 
 // AddFinished mocks base method.
-func (m *MockStudentLessons) AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+func (m *MockStudentLessons) AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID, module domain.Module) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFinished", ctx, studentId, lessonId)
+	ret := m.ctrl.Call(m, "AddFinished", ctx, studentId, lessonId, module)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddFinished indicates an expected call of AddFinished.
-func (mr *MockStudentLessonsMockRecorder) AddFinished(ctx, studentId, lessonId interface{}) *gomock.Call {
+func (mr *MockStudentLessonsMockRecorder) AddFinished(ctx, studentId, lessonId, module interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFinished", reflect.TypeOf((*MockStudentLessons)(nil).AddFinished), ctx, studentId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFinished", reflect.TypeOf((*MockStudentLessons)(nil).AddFinished), ctx, studentId, lessonId, module)
 }
 
 // SetLastOpened mocks base method.
-func (m *MockStudentLessons) SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
+func (m *MockStudentLessons) SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID, module domain.Module) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetLastOpened", ctx, studentId, lessonId)
+	ret := m.ctrl.Call(m, "SetLastOpened", ctx, studentId, lessonId, module)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetLastOpened indicates an expected call of SetLastOpened.
-func (mr *MockStudentLessonsMockRecorder) SetLastOpened(ctx, studentId, lessonId interface{}) *gomock.Call {
+func (mr *MockStudentLessonsMockRecorder) SetLastOpened(ctx, studentId, lessonId, module interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastOpened", reflect.TypeOf((*MockStudentLessons)(nil).SetLastOpened), ctx, studentId, lessonId, module)
+}
+
+// MockCourseProgress is a mock of CourseProgress interface.
+type MockCourseProgress struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseProgressMockRecorder
+}
+
+// MockCourseProgressMockRecorder is the mock recorder for MockCourseProgress.
+type MockCourseProgressMockRecorder struct {
+	mock *MockCourseProgress
+}
+
+// NewMockCourseProgress creates a new mock instance.
+func NewMockCourseProgress(ctrl *gomock.Controller) *MockCourseProgress {
+	mock := &MockCourseProgress{ctrl: ctrl}
+	mock.recorder = &MockCourseProgressMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseProgress) EXPECT() *MockCourseProgressMockRecorder {
+	return m.recorder
+}
+
+// CheckCompletion mocks base method.
+func (m *MockCourseProgress) CheckCompletion(ctx context.Context, studentId primitive.ObjectID, module domain.Module) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCompletion", ctx, studentId, module)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCompletion indicates an expected call of CheckCompletion.
+func (mr *MockCourseProgressMockRecorder) CheckCompletion(ctx, studentId, module interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastOpened", reflect.TypeOf((*MockStudentLessons)(nil).SetLastOpened), ctx, studentId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCompletion", reflect.TypeOf((*MockCourseProgress)(nil).CheckCompletion), ctx, studentId, module)
+}
+
+// GetByCourse mocks base method.
+func (m *MockCourseProgress) GetByCourse(ctx context.Context, schoolId, studentId, courseId primitive.ObjectID) (domain.CourseProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", ctx, schoolId, studentId, courseId)
+	ret0, _ := ret[0].(domain.CourseProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockCourseProgressMockRecorder) GetByCourse(ctx, schoolId, studentId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockCourseProgress)(nil).GetByCourse), ctx, schoolId, studentId, courseId)
+}
+
+// GetByStudent mocks base method.
+func (m *MockCourseProgress) GetByStudent(ctx context.Context, student domain.Student) ([]domain.CourseProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, student)
+	ret0, _ := ret[0].([]domain.CourseProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockCourseProgressMockRecorder) GetByStudent(ctx, student interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockCourseProgress)(nil).GetByStudent), ctx, student)
+}
+
+// GetStats mocks base method.
+func (m *MockCourseProgress) GetStats(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.CourseProgressStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, schoolId, courseId)
+	ret0, _ := ret[0].(domain.CourseProgressStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockCourseProgressMockRecorder) GetStats(ctx, schoolId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockCourseProgress)(nil).GetStats), ctx, schoolId, courseId)
+}
+
+// MockLessonGating is a mock of LessonGating interface.
+type MockLessonGating struct {
+	ctrl     *gomock.Controller
+	recorder *MockLessonGatingMockRecorder
+}
+
+// MockLessonGatingMockRecorder is the mock recorder for MockLessonGating.
+type MockLessonGatingMockRecorder struct {
+	mock *MockLessonGating
+}
+
+// NewMockLessonGating creates a new mock instance.
+func NewMockLessonGating(ctrl *gomock.Controller) *MockLessonGating {
+	mock := &MockLessonGating{ctrl: ctrl}
+	mock.recorder = &MockLessonGatingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLessonGating) EXPECT() *MockLessonGatingMockRecorder {
+	return m.recorder
+}
+
+// GetPrerequisites mocks base method.
+func (m *MockLessonGating) GetPrerequisites(ctx context.Context, studentId primitive.ObjectID, module domain.Module) (map[primitive.ObjectID]domain.Prerequisite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrerequisites", ctx, studentId, module)
+	ret0, _ := ret[0].(map[primitive.ObjectID]domain.Prerequisite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrerequisites indicates an expected call of GetPrerequisites.
+func (mr *MockLessonGatingMockRecorder) GetPrerequisites(ctx, studentId, module interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrerequisites", reflect.TypeOf((*MockLessonGating)(nil).GetPrerequisites), ctx, studentId, module)
+}
+
+// MockStudentActivities is a mock of StudentActivities interface.
+type MockStudentActivities struct {
+	ctrl     *gomock.Controller
+	recorder *MockStudentActivitiesMockRecorder
+}
+
+// MockStudentActivitiesMockRecorder is the mock recorder for MockStudentActivities.
+type MockStudentActivitiesMockRecorder struct {
+	mock *MockStudentActivities
+}
+
+// NewMockStudentActivities creates a new mock instance.
+func NewMockStudentActivities(ctrl *gomock.Controller) *MockStudentActivities {
+	mock := &MockStudentActivities{ctrl: ctrl}
+	mock.recorder = &MockStudentActivitiesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStudentActivities) EXPECT() *MockStudentActivitiesMockRecorder {
+	return m.recorder
+}
+
+// DeleteByStudent mocks base method.
+func (m *MockStudentActivities) DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByStudent", ctx, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByStudent indicates an expected call of DeleteByStudent.
+func (mr *MockStudentActivitiesMockRecorder) DeleteByStudent(ctx, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByStudent", reflect.TypeOf((*MockStudentActivities)(nil).DeleteByStudent), ctx, studentId)
+}
+
+// GetByStudent mocks base method.
+func (m *MockStudentActivities) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID, query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, schoolId, studentId, query)
+	ret0, _ := ret[0].([]domain.StudentActivity)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockStudentActivitiesMockRecorder) GetByStudent(ctx, schoolId, studentId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockStudentActivities)(nil).GetByStudent), ctx, schoolId, studentId, query)
+}
+
+// Log mocks base method.
+func (m *MockStudentActivities) Log(ctx context.Context, activity domain.StudentActivity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Log", ctx, activity)
+}
+
+// Log indicates an expected call of Log.
+func (mr *MockStudentActivitiesMockRecorder) Log(ctx, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockStudentActivities)(nil).Log), ctx, activity)
 }
 
 // MockAdmins is a mock of Admins interface.
@@ -478,6 +1509,35 @@ func (m *MockAdmins) EXPECT() *MockAdminsMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockAdmins) AcceptInvitation(ctx context.Context, input service.AcceptAdminInvitationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockAdminsMockRecorder) AcceptInvitation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockAdmins)(nil).AcceptInvitation), ctx, input)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockAdmins) ConfirmTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", ctx, adminId, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockAdminsMockRecorder) ConfirmTwoFactor(ctx, adminId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockAdmins)(nil).ConfirmTwoFactor), ctx, adminId, code)
+}
+
 // CreateStudent mocks base method.
 func (m *MockAdmins) CreateStudent(ctx context.Context, inp domain.CreateStudentInput) (domain.Student, error) {
 	m.ctrl.T.Helper()
@@ -496,15 +1556,59 @@ func (mr *MockAdminsMockRecorder) CreateStudent(ctx, inp interface{}) *gomock.Ca
 // DeleteStudent mocks base method.
 func (m *MockAdmins) DeleteStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteStudent", ctx, schoolId, studentId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteStudent", ctx, schoolId, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStudent indicates an expected call of DeleteStudent.
+func (mr *MockAdminsMockRecorder) DeleteStudent(ctx, schoolId, studentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStudent", reflect.TypeOf((*MockAdmins)(nil).DeleteStudent), ctx, schoolId, studentId)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockAdmins) DisableTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", ctx, adminId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockAdminsMockRecorder) DisableTwoFactor(ctx, adminId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockAdmins)(nil).DisableTwoFactor), ctx, adminId, code)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockAdmins) EnrollTwoFactor(ctx context.Context, adminId primitive.ObjectID, issuer string) (service.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", ctx, adminId, issuer)
+	ret0, _ := ret[0].(service.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockAdminsMockRecorder) EnrollTwoFactor(ctx, adminId, issuer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockAdmins)(nil).EnrollTwoFactor), ctx, adminId, issuer)
+}
+
+// GetById mocks base method.
+func (m *MockAdmins) GetById(ctx context.Context, schoolId, adminId primitive.ObjectID) (domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, schoolId, adminId)
+	ret0, _ := ret[0].(domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteStudent indicates an expected call of DeleteStudent.
-func (mr *MockAdminsMockRecorder) DeleteStudent(ctx, schoolId, studentId interface{}) *gomock.Call {
+// GetById indicates an expected call of GetById.
+func (mr *MockAdminsMockRecorder) GetById(ctx, schoolId, adminId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStudent", reflect.TypeOf((*MockAdmins)(nil).DeleteStudent), ctx, schoolId, studentId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAdmins)(nil).GetById), ctx, schoolId, adminId)
 }
 
 // GetCourseById mocks base method.
@@ -537,26 +1641,111 @@ func (mr *MockAdminsMockRecorder) GetCourses(ctx, schoolId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourses", reflect.TypeOf((*MockAdmins)(nil).GetCourses), ctx, schoolId)
 }
 
+// GetTeam mocks base method.
+func (m *MockAdmins) GetTeam(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockAdminsMockRecorder) GetTeam(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockAdmins)(nil).GetTeam), ctx, schoolId)
+}
+
+// InviteAdmin mocks base method.
+func (m *MockAdmins) InviteAdmin(ctx context.Context, input service.InviteAdminInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteAdmin", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteAdmin indicates an expected call of InviteAdmin.
+func (mr *MockAdminsMockRecorder) InviteAdmin(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteAdmin", reflect.TypeOf((*MockAdmins)(nil).InviteAdmin), ctx, input)
+}
+
 // RefreshTokens mocks base method.
-func (m *MockAdmins) RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (service.Tokens, error) {
+func (m *MockAdmins) RefreshTokens(ctx context.Context, input service.SchoolRefreshTokensInput) (service.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshTokens", ctx, schoolId, refreshToken)
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, input)
 	ret0, _ := ret[0].(service.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshTokens indicates an expected call of RefreshTokens.
-func (mr *MockAdminsMockRecorder) RefreshTokens(ctx, schoolId, refreshToken interface{}) *gomock.Call {
+func (mr *MockAdminsMockRecorder) RefreshTokens(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockAdmins)(nil).RefreshTokens), ctx, input)
+}
+
+// RemoveFromTeam mocks base method.
+func (m *MockAdmins) RemoveFromTeam(ctx context.Context, schoolId, currentAdminId, adminId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromTeam", ctx, schoolId, currentAdminId, adminId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromTeam indicates an expected call of RemoveFromTeam.
+func (mr *MockAdminsMockRecorder) RemoveFromTeam(ctx, schoolId, currentAdminId, adminId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromTeam", reflect.TypeOf((*MockAdmins)(nil).RemoveFromTeam), ctx, schoolId, currentAdminId, adminId)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAdmins) RequestPasswordReset(ctx context.Context, input service.RequestPasswordResetInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAdminsMockRecorder) RequestPasswordReset(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAdmins)(nil).RequestPasswordReset), ctx, input)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAdmins) ResetPassword(ctx context.Context, input service.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAdminsMockRecorder) ResetPassword(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAdmins)(nil).ResetPassword), ctx, input)
+}
+
+// SetRole mocks base method.
+func (m *MockAdmins) SetRole(ctx context.Context, schoolId, adminId primitive.ObjectID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, schoolId, adminId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminsMockRecorder) SetRole(ctx, schoolId, adminId, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockAdmins)(nil).RefreshTokens), ctx, schoolId, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdmins)(nil).SetRole), ctx, schoolId, adminId, role)
 }
 
 // SignIn mocks base method.
-func (m *MockAdmins) SignIn(ctx context.Context, input service.SchoolSignInInput) (service.Tokens, error) {
+func (m *MockAdmins) SignIn(ctx context.Context, input service.SchoolSignInInput) (service.AdminSignInResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignIn", ctx, input)
-	ret0, _ := ret[0].(service.Tokens)
+	ret0, _ := ret[0].(service.AdminSignInResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -567,6 +1756,21 @@ func (mr *MockAdminsMockRecorder) SignIn(ctx, input interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockAdmins)(nil).SignIn), ctx, input)
 }
 
+// SignInTwoFactor mocks base method.
+func (m *MockAdmins) SignInTwoFactor(ctx context.Context, input service.AdminTwoFactorSignInInput) (service.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInTwoFactor", ctx, input)
+	ret0, _ := ret[0].(service.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInTwoFactor indicates an expected call of SignInTwoFactor.
+func (mr *MockAdminsMockRecorder) SignInTwoFactor(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInTwoFactor", reflect.TypeOf((*MockAdmins)(nil).SignInTwoFactor), ctx, input)
+}
+
 // UpdateStudent mocks base method.
 func (m *MockAdmins) UpdateStudent(ctx context.Context, inp domain.UpdateStudentInput) error {
 	m.ctrl.T.Helper()
@@ -712,6 +1916,104 @@ func (mr *MockEmailsMockRecorder) AddStudentToList(ctx, email, name, schoolID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStudentToList", reflect.TypeOf((*MockEmails)(nil).AddStudentToList), ctx, email, name, schoolID)
 }
 
+// SendAccountLockedEmail mocks base method.
+func (m *MockEmails) SendAccountLockedEmail(arg0 service.AccountLockedEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAccountLockedEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAccountLockedEmail indicates an expected call of SendAccountLockedEmail.
+func (mr *MockEmailsMockRecorder) SendAccountLockedEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAccountLockedEmail", reflect.TypeOf((*MockEmails)(nil).SendAccountLockedEmail), arg0)
+}
+
+// SendAdminInvitationEmail mocks base method.
+func (m *MockEmails) SendAdminInvitationEmail(arg0 service.AdminInvitationEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAdminInvitationEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAdminInvitationEmail indicates an expected call of SendAdminInvitationEmail.
+func (mr *MockEmailsMockRecorder) SendAdminInvitationEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAdminInvitationEmail", reflect.TypeOf((*MockEmails)(nil).SendAdminInvitationEmail), arg0)
+}
+
+// SendConfirmationCodeEmail mocks base method.
+func (m *MockEmails) SendConfirmationCodeEmail(arg0 service.ConfirmationCodeEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConfirmationCodeEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConfirmationCodeEmail indicates an expected call of SendConfirmationCodeEmail.
+func (mr *MockEmailsMockRecorder) SendConfirmationCodeEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConfirmationCodeEmail", reflect.TypeOf((*MockEmails)(nil).SendConfirmationCodeEmail), arg0)
+}
+
+// SendDataExportEmail mocks base method.
+func (m *MockEmails) SendDataExportEmail(arg0 service.DataExportEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDataExportEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDataExportEmail indicates an expected call of SendDataExportEmail.
+func (mr *MockEmailsMockRecorder) SendDataExportEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDataExportEmail", reflect.TypeOf((*MockEmails)(nil).SendDataExportEmail), arg0)
+}
+
+// SendEmailChangeEmail mocks base method.
+func (m *MockEmails) SendEmailChangeEmail(arg0 service.EmailChangeEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangeEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangeEmail indicates an expected call of SendEmailChangeEmail.
+func (mr *MockEmailsMockRecorder) SendEmailChangeEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangeEmail", reflect.TypeOf((*MockEmails)(nil).SendEmailChangeEmail), arg0)
+}
+
+// SendMagicLinkEmail mocks base method.
+func (m *MockEmails) SendMagicLinkEmail(arg0 service.MagicLinkEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMagicLinkEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMagicLinkEmail indicates an expected call of SendMagicLinkEmail.
+func (mr *MockEmailsMockRecorder) SendMagicLinkEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMagicLinkEmail", reflect.TypeOf((*MockEmails)(nil).SendMagicLinkEmail), arg0)
+}
+
+// SendPasswordResetEmail mocks base method.
+func (m *MockEmails) SendPasswordResetEmail(arg0 service.PasswordResetEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordResetEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordResetEmail indicates an expected call of SendPasswordResetEmail.
+func (mr *MockEmailsMockRecorder) SendPasswordResetEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetEmail", reflect.TypeOf((*MockEmails)(nil).SendPasswordResetEmail), arg0)
+}
+
 // SendStudentPurchaseSuccessfulEmail mocks base method.
 func (m *MockEmails) SendStudentPurchaseSuccessfulEmail(arg0 service.StudentPurchaseSuccessfulEmailInput) error {
 	m.ctrl.T.Helper()
@@ -740,6 +2042,20 @@ func (mr *MockEmailsMockRecorder) SendStudentVerificationEmail(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStudentVerificationEmail", reflect.TypeOf((*MockEmails)(nil).SendStudentVerificationEmail), arg0)
 }
 
+// SendStudentWelcomeEmail mocks base method.
+func (m *MockEmails) SendStudentWelcomeEmail(arg0 service.StudentWelcomeEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendStudentWelcomeEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendStudentWelcomeEmail indicates an expected call of SendStudentWelcomeEmail.
+func (mr *MockEmailsMockRecorder) SendStudentWelcomeEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStudentWelcomeEmail", reflect.TypeOf((*MockEmails)(nil).SendStudentWelcomeEmail), arg0)
+}
+
 // SendUserVerificationEmail mocks base method.
 func (m *MockEmails) SendUserVerificationEmail(arg0 service.VerificationEmailInput) error {
 	m.ctrl.T.Helper()
@@ -1168,6 +2484,21 @@ func (mr *MockModulesMockRecorder) GetById(ctx, moduleId interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockModules)(nil).GetById), ctx, moduleId)
 }
 
+// GetByIds mocks base method.
+func (m *MockModules) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockModulesMockRecorder) GetByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockModules)(nil).GetByIds), ctx, ids)
+}
+
 // GetByLesson mocks base method.
 func (m *MockModules) GetByLesson(ctx context.Context, lessonId primitive.ObjectID) (domain.Module, error) {
 	m.ctrl.T.Helper()
@@ -1213,6 +2544,21 @@ func (mr *MockModulesMockRecorder) GetPublishedByCourseId(ctx, courseId interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedByCourseId", reflect.TypeOf((*MockModules)(nil).GetPublishedByCourseId), ctx, courseId)
 }
 
+// GetScheduledByCourseId mocks base method.
+func (m *MockModules) GetScheduledByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledByCourseId", ctx, courseId)
+	ret0, _ := ret[0].([]domain.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledByCourseId indicates an expected call of GetScheduledByCourseId.
+func (mr *MockModulesMockRecorder) GetScheduledByCourseId(ctx, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledByCourseId", reflect.TypeOf((*MockModules)(nil).GetScheduledByCourseId), ctx, courseId)
+}
+
 // GetWithContent mocks base method.
 func (m *MockModules) GetWithContent(ctx context.Context, moduleId primitive.ObjectID) (domain.Module, error) {
 	m.ctrl.T.Helper()
@@ -1228,6 +2574,18 @@ func (mr *MockModulesMockRecorder) GetWithContent(ctx, moduleId interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContent", reflect.TypeOf((*MockModules)(nil).GetWithContent), ctx, moduleId)
 }
 
+// InitPublishScheduler mocks base method.
+func (m *MockModules) InitPublishScheduler(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InitPublishScheduler", ctx)
+}
+
+// InitPublishScheduler indicates an expected call of InitPublishScheduler.
+func (mr *MockModulesMockRecorder) InitPublishScheduler(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitPublishScheduler", reflect.TypeOf((*MockModules)(nil).InitPublishScheduler), ctx)
+}
+
 // Update mocks base method.
 func (m *MockModules) Update(ctx context.Context, inp service.UpdateModuleInput) error {
 	m.ctrl.T.Helper()
@@ -1308,6 +2666,21 @@ func (mr *MockLessonsMockRecorder) DeleteContent(ctx, schoolId, lessonIds interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContent", reflect.TypeOf((*MockLessons)(nil).DeleteContent), ctx, schoolId, lessonIds)
 }
 
+// DiffVersions mocks base method.
+func (m *MockLessons) DiffVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID, from, to int) (domain.LessonContentDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffVersions", ctx, schoolId, lessonId, from, to)
+	ret0, _ := ret[0].(domain.LessonContentDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffVersions indicates an expected call of DiffVersions.
+func (mr *MockLessonsMockRecorder) DiffVersions(ctx, schoolId, lessonId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffVersions", reflect.TypeOf((*MockLessons)(nil).DiffVersions), ctx, schoolId, lessonId, from, to)
+}
+
 // GetById mocks base method.
 func (m *MockLessons) GetById(ctx context.Context, lessonId primitive.ObjectID) (domain.Lesson, error) {
 	m.ctrl.T.Helper()
@@ -1323,6 +2696,80 @@ func (mr *MockLessonsMockRecorder) GetById(ctx, lessonId interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockLessons)(nil).GetById), ctx, lessonId)
 }
 
+// GetDraft mocks base method.
+func (m *MockLessons) GetDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) (*domain.LessonDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, schoolId, lessonId)
+	ret0, _ := ret[0].(*domain.LessonDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockLessonsMockRecorder) GetDraft(ctx, schoolId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockLessons)(nil).GetDraft), ctx, schoolId, lessonId)
+}
+
+// GetVersion mocks base method.
+func (m *MockLessons) GetVersion(ctx context.Context, schoolId, lessonId primitive.ObjectID, version int) (domain.LessonContentVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, schoolId, lessonId, version)
+	ret0, _ := ret[0].(domain.LessonContentVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockLessonsMockRecorder) GetVersion(ctx, schoolId, lessonId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockLessons)(nil).GetVersion), ctx, schoolId, lessonId, version)
+}
+
+// GetVersions mocks base method.
+func (m *MockLessons) GetVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID, query domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, schoolId, lessonId, query)
+	ret0, _ := ret[0].([]domain.LessonContentVersion)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockLessonsMockRecorder) GetVersions(ctx, schoolId, lessonId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockLessons)(nil).GetVersions), ctx, schoolId, lessonId, query)
+}
+
+// PublishDraft mocks base method.
+func (m *MockLessons) PublishDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDraft", ctx, schoolId, lessonId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishDraft indicates an expected call of PublishDraft.
+func (mr *MockLessonsMockRecorder) PublishDraft(ctx, schoolId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDraft", reflect.TypeOf((*MockLessons)(nil).PublishDraft), ctx, schoolId, lessonId)
+}
+
+// RestoreVersion mocks base method.
+func (m *MockLessons) RestoreVersion(ctx context.Context, inp service.RestoreLessonVersionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVersion", ctx, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreVersion indicates an expected call of RestoreVersion.
+func (mr *MockLessonsMockRecorder) RestoreVersion(ctx, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVersion", reflect.TypeOf((*MockLessons)(nil).RestoreVersion), ctx, inp)
+}
+
 // Update mocks base method.
 func (m *MockLessons) Update(ctx context.Context, inp service.UpdateLessonInput) error {
 	m.ctrl.T.Helper()
@@ -1693,3 +3140,85 @@ func (mr *MockSurveysMockRecorder) SaveStudentAnswers(ctx, inp interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStudentAnswers", reflect.TypeOf((*MockSurveys)(nil).SaveStudentAnswers), ctx, inp)
 }
+
+// MockAPIKeys is a mock of APIKeys interface.
+type MockAPIKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysMockRecorder
+}
+
+// MockAPIKeysMockRecorder is the mock recorder for MockAPIKeys.
+type MockAPIKeysMockRecorder struct {
+	mock *MockAPIKeys
+}
+
+// NewMockAPIKeys creates a new mock instance.
+func NewMockAPIKeys(ctrl *gomock.Controller) *MockAPIKeys {
+	mock := &MockAPIKeys{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeys) EXPECT() *MockAPIKeysMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeys) Authenticate(ctx context.Context, schoolId primitive.ObjectID, key string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, schoolId, key)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeysMockRecorder) Authenticate(ctx, schoolId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeys)(nil).Authenticate), ctx, schoolId, key)
+}
+
+// Create mocks base method.
+func (m *MockAPIKeys) Create(ctx context.Context, input service.CreateAPIKeyInput) (service.CreateAPIKeyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(service.CreateAPIKeyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeysMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeys)(nil).Create), ctx, input)
+}
+
+// GetBySchool mocks base method.
+func (m *MockAPIKeys) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySchool", ctx, schoolId)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySchool indicates an expected call of GetBySchool.
+func (mr *MockAPIKeysMockRecorder) GetBySchool(ctx, schoolId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySchool", reflect.TypeOf((*MockAPIKeys)(nil).GetBySchool), ctx, schoolId)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeys) Revoke(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, schoolId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeysMockRecorder) Revoke(ctx, schoolId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeys)(nil).Revoke), ctx, schoolId, id)
+}
@@ -3,19 +3,25 @@ package service
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const _publishSchedulerInterval = time.Minute
+
 type ModulesService struct {
-	repo        repository.Modules
-	contentRepo repository.LessonContent
+	repo         repository.Modules
+	contentRepo  repository.LessonContent
+	versionsRepo repository.LessonContentVersions
 }
 
-func NewModulesService(repo repository.Modules, contentRepo repository.LessonContent) *ModulesService {
-	return &ModulesService{repo: repo, contentRepo: contentRepo}
+func NewModulesService(repo repository.Modules, contentRepo repository.LessonContent,
+	versionsRepo repository.LessonContentVersions) *ModulesService {
+	return &ModulesService{repo: repo, contentRepo: contentRepo, versionsRepo: versionsRepo}
 }
 
 func (s *ModulesService) GetPublishedByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error) {
@@ -44,6 +50,38 @@ func (s *ModulesService) GetByCourseId(ctx context.Context, courseId primitive.O
 	return modules, nil
 }
 
+// GetScheduledByCourseId returns published and scheduled modules with published and scheduled lessons,
+// AvailableAt is set for the scheduled ones.
+func (s *ModulesService) GetScheduledByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error) {
+	modules, err := s.GetByCourseId(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Module, 0, len(modules))
+
+	for _, module := range modules {
+		if !module.IsPublishedOrScheduled() {
+			continue
+		}
+
+		lessons := make([]domain.Lesson, 0, len(module.Lessons))
+
+		for _, lesson := range module.Lessons {
+			if lesson.IsPublishedOrScheduled() {
+				lesson.AvailableAt = lesson.ScheduledAt(module)
+				lessons = append(lessons, lesson)
+			}
+		}
+
+		module.AvailableAt = module.PublishAt
+		module.Lessons = lessons
+		res = append(res, module)
+	}
+
+	return res, nil
+}
+
 func (s *ModulesService) GetById(ctx context.Context, moduleId primitive.ObjectID) (domain.Module, error) {
 	module, err := s.repo.GetPublishedById(ctx, moduleId)
 	if err != nil {
@@ -65,13 +103,13 @@ func (s *ModulesService) GetWithContent(ctx context.Context, moduleID primitive.
 	publishedLessons := make([]domain.Lesson, 0)
 
 	for _, lesson := range module.Lessons {
-		if lesson.Published {
+		if lesson.IsPublishedOrScheduled() {
 			publishedLessons = append(publishedLessons, lesson)
 			lessonIds = append(lessonIds, lesson.ID)
 		}
 	}
 
-	module.Lessons = publishedLessons // remove unpublished lessons from final result
+	module.Lessons = publishedLessons // remove unpublished lessons from final result, scheduled ones are shown as locked
 
 	content, err := s.contentRepo.GetByLessons(ctx, lessonIds)
 	if err != nil {
@@ -81,7 +119,7 @@ func (s *ModulesService) GetWithContent(ctx context.Context, moduleID primitive.
 	for i := range module.Lessons {
 		for _, lessonContent := range content {
 			if module.Lessons[i].ID == lessonContent.LessonID {
-				module.Lessons[i].Content = lessonContent.Content
+				module.Lessons[i].Blocks = lessonContent.GetBlocks()
 			}
 		}
 	}
@@ -104,6 +142,19 @@ func (s *ModulesService) GetByPackages(ctx context.Context, packageIds []primiti
 	return modules, nil
 }
 
+func (s *ModulesService) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error) {
+	modules, err := s.repo.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range modules {
+		sortLessons(modules[i].Lessons)
+	}
+
+	return modules, nil
+}
+
 func (s *ModulesService) GetByLesson(ctx context.Context, lessonID primitive.ObjectID) (domain.Module, error) {
 	return s.repo.GetByLesson(ctx, lessonID)
 }
@@ -140,17 +191,41 @@ func (s *ModulesService) Update(ctx context.Context, inp UpdateModuleInput) erro
 		return err
 	}
 
+	if err := validateSchedule(inp.Published, inp.PublishAt); err != nil {
+		return err
+	}
+
+	if inp.Gating != nil && *inp.Gating != "" && !domain.IsValidGatingMode(*inp.Gating) {
+		return domain.ErrGatingModeInvalid
+	}
+
 	updateInput := repository.UpdateModuleInput{
 		ID:        id,
 		SchoolID:  schoolID,
 		Name:      inp.Name,
 		Position:  inp.Position,
 		Published: inp.Published,
+		PublishAt: inp.PublishAt,
+		DripDays:  inp.DripDays,
+		Gating:    inp.Gating,
 	}
 
 	return s.repo.Update(ctx, updateInput)
 }
 
+// InitPublishScheduler starts worker, that publishes scheduled modules and lessons.
+func (s *ModulesService) InitPublishScheduler(ctx context.Context) {
+	go func() {
+		for {
+			if err := s.repo.PublishScheduled(ctx); err != nil {
+				logger.Error("PublishScheduled(): ", err)
+			}
+
+			time.Sleep(_publishSchedulerInterval)
+		}
+	}()
+}
+
 func (s *ModulesService) Delete(ctx context.Context, schoolId, moduleId primitive.ObjectID) error {
 	module, err := s.repo.GetById(ctx, moduleId)
 	if err != nil {
@@ -166,7 +241,7 @@ func (s *ModulesService) Delete(ctx context.Context, schoolId, moduleId primitiv
 		lessonIds = append(lessonIds, lesson.ID)
 	}
 
-	return s.contentRepo.DeleteContent(ctx, schoolId, lessonIds)
+	return s.deleteLessonsContent(ctx, schoolId, lessonIds)
 }
 
 func (s *ModulesService) DeleteByCourse(ctx context.Context, schoolId, courseId primitive.ObjectID) error {
@@ -187,7 +262,29 @@ func (s *ModulesService) DeleteByCourse(ctx context.Context, schoolId, courseId
 		}
 	}
 
-	return s.contentRepo.DeleteContent(ctx, schoolId, lessonIds)
+	return s.deleteLessonsContent(ctx, schoolId, lessonIds)
+}
+
+func (s *ModulesService) deleteLessonsContent(ctx context.Context, schoolId primitive.ObjectID, lessonIds []primitive.ObjectID) error {
+	if err := s.contentRepo.DeleteContent(ctx, schoolId, lessonIds); err != nil {
+		return err
+	}
+
+	return s.versionsRepo.DeleteByLessons(ctx, schoolId, lessonIds)
+}
+
+// validateSchedule checks that publish time is in the future and content isn't published manually at the same time.
+// Zero publish time cancels the schedule.
+func validateSchedule(published *bool, publishAt *time.Time) error {
+	if publishAt == nil || publishAt.IsZero() {
+		return nil
+	}
+
+	if !publishAt.After(time.Now()) || (published != nil && *published) {
+		return domain.ErrContentScheduleInvalid
+	}
+
+	return nil
 }
 
 func sortLessons(lessons []domain.Lesson) {
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestModulesService_GetScheduledByCourseId(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	modules := mock_repository.NewMockModules(mockCtl)
+	modulesService := service.NewModulesService(modules, mock_repository.NewMockLessonContent(mockCtl),
+		mock_repository.NewMockLessonContentVersions(mockCtl))
+
+	courseId := primitive.NewObjectID()
+	modulePublishAt := time.Now().Add(time.Hour)
+	lessonPublishAt := time.Now().Add(2 * time.Hour)
+
+	modules.EXPECT().GetByCourseId(gomock.Any(), courseId).Return([]domain.Module{
+		{ID: primitive.NewObjectID(), Published: true, Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true},
+			{ID: primitive.NewObjectID(), PublishAt: &lessonPublishAt},
+			{ID: primitive.NewObjectID()},
+		}},
+		{ID: primitive.NewObjectID(), PublishAt: &modulePublishAt, Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true},
+		}},
+		{ID: primitive.NewObjectID()},
+	}, nil)
+
+	res, err := modulesService.GetScheduledByCourseId(context.Background(), courseId)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	require.Nil(t, res[0].AvailableAt)
+	require.Len(t, res[0].Lessons, 2)
+	require.Nil(t, res[0].Lessons[0].AvailableAt)
+	require.Equal(t, lessonPublishAt, *res[0].Lessons[1].AvailableAt)
+
+	require.Equal(t, modulePublishAt, *res[1].AvailableAt)
+	require.Equal(t, modulePublishAt, *res[1].Lessons[0].AvailableAt)
+}
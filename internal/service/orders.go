@@ -13,16 +13,19 @@ type OrdersService struct {
 	offersService     Offers
 	promoCodesService PromoCodes
 	studentsService   Students
+	activitiesService StudentActivities
 
 	repo repository.Orders
 }
 
-func NewOrdersService(repo repository.Orders, offersService Offers, promoCodesService PromoCodes, studentsService Students) *OrdersService {
+func NewOrdersService(repo repository.Orders, offersService Offers, promoCodesService PromoCodes, studentsService Students,
+	activitiesService StudentActivities) *OrdersService {
 	return &OrdersService{
 		repo:              repo,
 		offersService:     offersService,
 		promoCodesService: promoCodesService,
 		studentsService:   studentsService,
+		activitiesService: activitiesService,
 	}
 }
 
@@ -72,9 +75,19 @@ func (s *OrdersService) Create(ctx context.Context, studentId, offerId, promocod
 		}
 	}
 
-	err = s.repo.Create(ctx, order)
+	if err := s.repo.Create(ctx, order); err != nil {
+		return id, err
+	}
+
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  order.SchoolID,
+		StudentID: student.ID,
+		Type:      domain.StudentActivityOrderCreated,
+		OfferID:   offer.ID,
+		OrderID:   order.ID,
+	})
 
-	return id, err
+	return id, nil
 }
 
 func (s *OrdersService) AddTransaction(ctx context.Context, id primitive.ObjectID, transaction domain.Transaction) (domain.Order, error) {
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const passwordResetTokenLength = 32
+
+type PasswordResetsService struct {
+	repo         repository.OneTimeTokens
+	otpGenerator otp.Generator
+
+	tokenTTL time.Duration
+}
+
+func NewPasswordResetsService(repo repository.OneTimeTokens, otpGenerator otp.Generator, tokenTTL time.Duration) *PasswordResetsService {
+	return &PasswordResetsService{
+		repo:         repo,
+		otpGenerator: otpGenerator,
+		tokenTTL:     tokenTTL,
+	}
+}
+
+func (s *PasswordResetsService) CreateToken(ctx context.Context, ownerId primitive.ObjectID, role string, schoolId primitive.ObjectID) (string, error) {
+	token := s.otpGenerator.RandomSecret(passwordResetTokenLength)
+
+	if err := s.repo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposePasswordReset,
+		Hash:      auth.HashToken(token),
+		OwnerID:   ownerId,
+		Role:      role,
+		SchoolID:  schoolId,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(s.tokenTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *PasswordResetsService) ConsumeToken(ctx context.Context, token, role string, schoolId primitive.ObjectID) (primitive.ObjectID, error) {
+	res, err := s.repo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(token),
+		Purpose:  domain.TokenPurposePasswordReset,
+		Role:     role,
+		SchoolID: schoolId,
+	})
+	if err != nil {
+		return primitive.ObjectID{}, err
+	}
+
+	return res.OwnerID, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// verifyPassword checks password against the stored hash. Hashes produced by legacy algorithms
+// (or with outdated parameters) are upgraded using rehash, failing to do so doesn't fail the sign in.
+func verifyPassword(hasher hash.PasswordHasher, password, passwordHash string, rehash func(passwordHash string) error) error {
+	ok, err := hasher.Verify(password, passwordHash)
+	if err != nil {
+		if errors.Is(err, hash.ErrUnknownAlgorithm) {
+			return domain.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+
+	if !hasher.NeedsRehash(passwordHash) {
+		return nil
+	}
+
+	newHash, err := hasher.Hash(password)
+	if err == nil {
+		err = rehash(newHash)
+	}
+
+	if err != nil {
+		logger.Errorf("failed to rehash password: %s", err.Error())
+	}
+
+	return nil
+}
+
+// resetPassword consumes reset token, sets new password for the token owner and revokes all owner's sessions.
+func resetPassword(ctx context.Context, hasher hash.PasswordHasher, passwordResets PasswordResets, sessions Sessions,
+	role string, input ResetPasswordInput, setPassword func(ownerId primitive.ObjectID, passwordHash string) error) error {
+	ownerId, err := passwordResets.ConsumeToken(ctx, input.Token, role, input.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := hasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+
+	if err := setPassword(ownerId, passwordHash); err != nil {
+		return err
+	}
+
+	return sessions.RevokeAll(ctx, ownerId)
+}
+
+// dummyPassword spends the same time as verification of the real password, so the response time
+// doesn't reveal whether the account exists. Hash is created on the first use with the current algorithm.
+type dummyPassword struct {
+	hasher hash.PasswordHasher
+	once   sync.Once
+	hash   string
+}
+
+func newDummyPassword(hasher hash.PasswordHasher) *dummyPassword {
+	return &dummyPassword{hasher: hasher}
+}
+
+func (d *dummyPassword) Verify(password string) {
+	d.once.Do(func() {
+		passwordHash, err := d.hasher.Hash("dummy password")
+		if err != nil {
+			logger.Errorf("failed to hash dummy password: %s", err.Error())
+		}
+
+		d.hash = passwordHash
+	})
+
+	if d.hash != "" {
+		_, _ = d.hasher.Verify(password, d.hash)
+	}
+}
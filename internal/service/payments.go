@@ -23,18 +23,20 @@ type PaymentsService struct {
 	studentsService Students
 	emailService    Emails
 	schoolsService  Schools
+	activities      StudentActivities
 
 	fondyCallbackURL string
 }
 
 func NewPaymentsService(ordersService Orders, offersService Offers, studentsService Students,
-	emailService Emails, schoolsService Schools, fondyCallbackURL string) *PaymentsService {
+	emailService Emails, schoolsService Schools, activities StudentActivities, fondyCallbackURL string) *PaymentsService {
 	return &PaymentsService{
 		ordersService:    ordersService,
 		offersService:    offersService,
 		studentsService:  studentsService,
 		emailService:     emailService,
 		schoolsService:   schoolsService,
+		activities:       activities,
 		fondyCallbackURL: fondyCallbackURL,
 	}
 }
@@ -117,6 +119,14 @@ func (s *PaymentsService) processFondyCallback(ctx context.Context, callback fon
 		return nil
 	}
 
+	s.activities.Log(ctx, domain.StudentActivity{
+		SchoolID:  order.SchoolID,
+		StudentID: order.Student.ID,
+		Type:      domain.StudentActivityOrderPaid,
+		OfferID:   order.Offer.ID,
+		OrderID:   order.ID,
+	})
+
 	offer, err := s.offersService.GetById(ctx, order.Offer.ID)
 	if err != nil {
 		return err
@@ -130,7 +140,7 @@ func (s *PaymentsService) processFondyCallback(ctx context.Context, callback fon
 		logger.Errorf("failed to send email after purchase: %s", err.Error())
 	}
 
-	return s.studentsService.GiveAccessToOffer(ctx, order.Student.ID, offer)
+	return s.studentsService.GiveAccessToOffer(ctx, order.Student.ID, offer, primitive.NilObjectID)
 }
 
 func (s *PaymentsService) generateFondyPaymentLink(ctx context.Context, schoolId primitive.ObjectID,
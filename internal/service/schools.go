@@ -2,17 +2,21 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/zhashkevych/creatly-backend/pkg/payment"
 	"github.com/zhashkevych/creatly-backend/pkg/payment/fondy"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
 	"github.com/zhashkevych/creatly-backend/pkg/cache"
 )
 
+const studentFieldsMaxCount = 50
+
 type SchoolsService struct {
 	repo  repository.Schools
 	cache cache.Cache
@@ -46,7 +50,27 @@ func (s *SchoolsService) GetById(ctx context.Context, id primitive.ObjectID) (do
 	return s.repo.GetById(ctx, id)
 }
 
+func (s *SchoolsService) GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error) {
+	if len(ids) == 0 {
+		return []domain.School{}, nil
+	}
+
+	return s.repo.GetByIds(ctx, ids)
+}
+
+// UpdateSettings rejects domains used by another school, because school is found by the request host.
 func (s *SchoolsService) UpdateSettings(ctx context.Context, schoolId primitive.ObjectID, inp domain.UpdateSchoolSettingsInput) error {
+	for _, domainName := range inp.Domains {
+		school, err := s.repo.GetByDomain(ctx, domainName)
+		if err == nil && school.ID != schoolId {
+			return domain.ErrSchoolDomainTaken
+		}
+
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+	}
+
 	return s.repo.UpdateSettings(ctx, schoolId, inp)
 }
 
@@ -74,6 +98,68 @@ func (s *SchoolsService) ConnectFondy(ctx context.Context, input ConnectFondyInp
 	return s.repo.SetFondyCredentials(ctx, input.SchoolID, creds)
 }
 
+// SetOIDCProviders replaces school's sign-in providers. Client secret of the existing provider is kept,
+// when it's not passed again.
+func (s *SchoolsService) SetOIDCProviders(ctx context.Context, schoolId primitive.ObjectID, providers []domain.OIDCProvider) error {
+	school, err := s.repo.GetById(ctx, schoolId)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(providers))
+
+	for i, provider := range providers {
+		if _, ex := names[provider.Name]; ex || !isValidOIDCProvider(provider) {
+			return domain.ErrOIDCProviderInvalid
+		}
+
+		names[provider.Name] = struct{}{}
+
+		if provider.ClientSecret != "" {
+			continue
+		}
+
+		if current, err := school.Settings.GetOIDCProvider(provider.Name); err == nil {
+			providers[i].ClientSecret = current.ClientSecret
+		}
+	}
+
+	return s.repo.SetOIDCProviders(ctx, schoolId, providers)
+}
+
+// SetStudentFields replaces school's custom student fields. Values of the removed fields are kept
+// on students, but are no longer editable.
+func (s *SchoolsService) SetStudentFields(ctx context.Context, schoolId primitive.ObjectID, fields []domain.StudentField) error {
+	if len(fields) > studentFieldsMaxCount {
+		return domain.ErrStudentFieldsInvalid
+	}
+
+	keys := make(map[string]struct{}, len(fields))
+	names := make(map[string]struct{}, len(fields))
+
+	for _, field := range fields {
+		_, keyEx := keys[field.Key]
+		_, nameEx := names[field.Name]
+
+		if keyEx || nameEx || !field.IsValid() {
+			return domain.ErrStudentFieldsInvalid
+		}
+
+		keys[field.Key] = struct{}{}
+		names[field.Name] = struct{}{}
+	}
+
+	return s.repo.SetStudentFields(ctx, schoolId, fields)
+}
+
+func isValidOIDCProvider(provider domain.OIDCProvider) bool {
+	if provider.Name == "" || provider.ClientID == "" {
+		return false
+	}
+
+	return provider.Issuer != "" || (provider.AuthURL != "" && provider.TokenURL != "" && provider.UserInfoURL != "")
+}
+
 func (s *SchoolsService) ConnectSendPulse(ctx context.Context, input ConnectSendPulseInput) error {
 	// todo
 	return nil
@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestSchoolsService_UpdateSettingsDomainTaken(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	schools := mock_repository.NewMockSchools(mockCtl)
+	schoolsService := service.NewSchoolsService(schools, cache.NewMemoryCache(), 60)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+
+	schools.EXPECT().GetByDomain(ctx, "own.creatly.me").Return(domain.School{ID: schoolId}, nil)
+	schools.EXPECT().GetByDomain(ctx, "new.creatly.me").Return(domain.School{}, mongo.ErrNoDocuments)
+	schools.EXPECT().GetByDomain(ctx, "other.creatly.me").Return(domain.School{ID: primitive.NewObjectID()}, nil)
+
+	err := schoolsService.UpdateSettings(ctx, schoolId, domain.UpdateSchoolSettingsInput{
+		Domains: []string{"own.creatly.me", "new.creatly.me", "other.creatly.me"},
+	})
+
+	require.ErrorIs(t, err, domain.ErrSchoolDomainTaken)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/zhashkevych/creatly-backend/pkg/cache"
 	"github.com/zhashkevych/creatly-backend/pkg/email"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
 	"github.com/zhashkevych/creatly-backend/pkg/otp"
 	"github.com/zhashkevych/creatly-backend/pkg/storage"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -33,6 +34,12 @@ type UserSignUpInput struct {
 type UserSignInInput struct {
 	Email    string
 	Password string
+	Device   Device
+}
+
+type UserRefreshTokensInput struct {
+	RefreshToken string
+	Device       Device
 }
 
 type Tokens struct {
@@ -40,15 +47,87 @@ type Tokens struct {
 	RefreshToken string
 }
 
+// Device describes the client a session is created from.
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+type CreateSessionInput struct {
+	OwnerID  primitive.ObjectID
+	Role     string
+	SchoolID primitive.ObjectID
+	Audience string
+	Device   Device
+}
+
+type RefreshSessionInput struct {
+	RefreshToken string
+	Role         string
+	SchoolID     primitive.ObjectID
+	Audience     string
+	Device       Device
+}
+
+type RequestPasswordResetInput struct {
+	Email        string
+	SchoolID     primitive.ObjectID
+	SchoolDomain string
+}
+
+type ResetPasswordInput struct {
+	Token    string
+	Password string
+	SchoolID primitive.ObjectID
+}
+
+type PasswordResets interface {
+	CreateToken(ctx context.Context, ownerId primitive.ObjectID, role string, schoolId primitive.ObjectID) (string, error)
+	ConsumeToken(ctx context.Context, token, role string, schoolId primitive.ObjectID) (primitive.ObjectID, error)
+}
+
+type SignInAttemptsKey struct {
+	Role     string
+	SchoolID primitive.ObjectID
+	Email    string
+}
+
+type SignInAttempts interface {
+	Check(key SignInAttemptsKey) error
+	Failed(key SignInAttemptsKey) (time.Time, error)
+	Succeeded(key SignInAttemptsKey) error
+}
+
+type Sessions interface {
+	Create(ctx context.Context, inp CreateSessionInput) (Tokens, error)
+	Refresh(ctx context.Context, inp RefreshSessionInput) (domain.Session, Tokens, error)
+	GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error)
+	Revoke(ctx context.Context, ownerId, id primitive.ObjectID) error
+	RevokeAll(ctx context.Context, ownerId primitive.ObjectID) error
+}
+
 // 1. Create School in DB
 // 2. Generate Sub Domain
 
+type UserImpersonateAdminInput struct {
+	UserID   primitive.ObjectID
+	SchoolID primitive.ObjectID
+	Device   Device
+}
+
 type Users interface {
 	SignUp(ctx context.Context, input UserSignUpInput) error
 	SignIn(ctx context.Context, input UserSignInInput) (Tokens, error)
-	RefreshTokens(ctx context.Context, refreshToken string) (Tokens, error)
+	RefreshTokens(ctx context.Context, input UserRefreshTokensInput) (Tokens, error)
 	Verify(ctx context.Context, userID primitive.ObjectID, hash string) error
+	ResendVerification(ctx context.Context, userID primitive.ObjectID) error
+	RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
 	CreateSchool(ctx context.Context, userID primitive.ObjectID, schoolName string) (domain.School, error)
+	GetSchools(ctx context.Context, userID primitive.ObjectID) ([]domain.School, error)
+	GetSchoolById(ctx context.Context, userID, schoolID primitive.ObjectID) (domain.School, error)
+	UpdateSchool(ctx context.Context, userID, schoolID primitive.ObjectID, input domain.UpdateSchoolSettingsInput) error
+	ImpersonateAdmin(ctx context.Context, input UserImpersonateAdminInput) (Tokens, error)
 }
 
 type ConnectFondyInput struct {
@@ -68,48 +147,301 @@ type Schools interface {
 	Create(ctx context.Context, name string) (primitive.ObjectID, error)
 	GetByDomain(ctx context.Context, domainName string) (domain.School, error)
 	GetById(ctx context.Context, id primitive.ObjectID) (domain.School, error)
+	GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.School, error)
 	UpdateSettings(ctx context.Context, schoolId primitive.ObjectID, input domain.UpdateSchoolSettingsInput) error
 	ConnectFondy(ctx context.Context, input ConnectFondyInput) error
 	ConnectSendPulse(ctx context.Context, input ConnectSendPulseInput) error
+	SetOIDCProviders(ctx context.Context, schoolId primitive.ObjectID, providers []domain.OIDCProvider) error
+	SetStudentFields(ctx context.Context, schoolId primitive.ObjectID, fields []domain.StudentField) error
 }
 
 type StudentSignUpInput struct {
-	Name         string
+	Name                string
+	Email               string
+	Password            string
+	SchoolID            primitive.ObjectID
+	SchoolDomain        string
+	Verified            bool
+	DisableRegistration bool
+}
+
+type SchoolSignInInput struct {
 	Email        string
 	Password     string
 	SchoolID     primitive.ObjectID
 	SchoolDomain string
-	Verified     bool
+	Device       Device
 }
 
-type SchoolSignInInput struct {
-	Email    string
-	Password string
+type StudentOIDCAuthURLInput struct {
+	Provider     domain.OIDCProvider
+	SchoolID     primitive.ObjectID
+	SchoolDomain string
+}
+
+// OIDCAuthURL is returned with Nonce, that client keeps and sends back with the callback,
+// so the state can't be used in the other browser.
+type OIDCAuthURL struct {
+	URL   string
+	Nonce string
+}
+
+type StudentOIDCSignInInput struct {
+	Provider            domain.OIDCProvider
+	Code                string
+	State               string
+	Nonce               string
+	SchoolID            primitive.ObjectID
+	SchoolDomain        string
+	DisableRegistration bool
+	Device              Device
+}
+
+type StudentRequestMagicLinkInput struct {
+	Email               string
+	SchoolID            primitive.ObjectID
+	SchoolDomain        string
+	DisableRegistration bool
+}
+
+type StudentMagicLinkSignInInput struct {
+	Token               string
+	SchoolID            primitive.ObjectID
+	SchoolDomain        string
+	DisableRegistration bool
+	Device              Device
+}
+
+type SchoolRefreshTokensInput struct {
+	RefreshToken string
+	SchoolID     primitive.ObjectID
+	SchoolDomain string
+	Device       Device
+}
+
+type ResendVerificationInput struct {
+	Email        string
+	SchoolID     primitive.ObjectID
+	SchoolDomain string
+}
+
+type UpdateStudentAccountInput struct {
+	StudentID primitive.ObjectID
+	SchoolID  primitive.ObjectID
+	Name      string
+}
+
+type StudentChangeEmailInput struct {
+	StudentID    primitive.ObjectID
+	SchoolID     primitive.ObjectID
+	SchoolDomain string
+	Email        string
+	Password     string
+	Code         string
+}
+
+type StudentConfirmEmailChangeInput struct {
+	Token    string
 	SchoolID primitive.ObjectID
 }
 
+type StudentChangePasswordInput struct {
+	StudentID       primitive.ObjectID
+	SchoolID        primitive.ObjectID
+	SchoolDomain    string
+	CurrentPassword string
+	NewPassword     string
+	Code            string
+	Device          Device
+}
+
+type StudentDeleteAccountInput struct {
+	StudentID primitive.ObjectID
+	SchoolID  primitive.ObjectID
+	Password  string
+	Code      string
+}
+
+type StudentRequestConfirmationCodeInput struct {
+	StudentID primitive.ObjectID
+	SchoolID  primitive.ObjectID
+}
+
 type Students interface {
 	SignUp(ctx context.Context, input StudentSignUpInput) error
 	SignIn(ctx context.Context, input SchoolSignInInput) (Tokens, error)
-	RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (Tokens, error)
+	GetOIDCAuthURL(ctx context.Context, input StudentOIDCAuthURLInput) (OIDCAuthURL, error)
+	SignInOIDC(ctx context.Context, input StudentOIDCSignInInput) (Tokens, error)
+	RequestMagicLink(ctx context.Context, input StudentRequestMagicLinkInput) error
+	SignInMagicLink(ctx context.Context, input StudentMagicLinkSignInInput) (Tokens, error)
+	RefreshTokens(ctx context.Context, input SchoolRefreshTokensInput) (Tokens, error)
 	Verify(ctx context.Context, hash string) error
+	ResendVerification(ctx context.Context, input ResendVerificationInput) error
+	ResendVerificationById(ctx context.Context, schoolId, studentId primitive.ObjectID, schoolDomain string) error
+	RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
 	GetModuleContent(ctx context.Context, schoolId, studentId, moduleId primitive.ObjectID) (domain.ModuleContent, error)
 	GetLesson(ctx context.Context, studentId, lessonId primitive.ObjectID) (domain.Lesson, error)
 	SetLessonFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error
-	GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error
-	RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error
+	GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer, adminId primitive.ObjectID) error
+	RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer, adminId primitive.ObjectID) error
 	GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.Student, error)
 	GetBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery) ([]domain.Student, int64, error)
+	UpdateAccount(ctx context.Context, input UpdateStudentAccountInput) error
+	RequestConfirmationCode(ctx context.Context, input StudentRequestConfirmationCodeInput) error
+	RequestEmailChange(ctx context.Context, input StudentChangeEmailInput) error
+	ConfirmEmailChange(ctx context.Context, input StudentConfirmEmailChangeInput) error
+	ChangePassword(ctx context.Context, input StudentChangePasswordInput) (Tokens, error)
+	DeleteAccount(ctx context.Context, input StudentDeleteAccountInput) error
+}
+
+type StudentExports interface {
+	Export(ctx context.Context, schoolId, studentId primitive.ObjectID) error
+	DeleteByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) error
+	InitExpiredExportsCleaner(ctx context.Context)
+}
+
+type StartStudentImportInput struct {
+	SchoolID     primitive.ObjectID
+	AdminID      primitive.ObjectID
+	SchoolDomain string
+	File         io.Reader
+	DryRun       bool
+	Emails       string
+}
+
+type StudentImports interface {
+	Start(ctx context.Context, input StartStudentImportInput) (domain.StudentImport, error)
+	GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error)
+}
+
+// ExportStudentsInput describes student list export, Format is one of spreadsheet formats,
+// query pagination is ignored.
+type ExportStudentsInput struct {
+	School domain.School
+	Query  domain.GetStudentsQuery
+	Format string
+	Writer io.Writer
+}
+
+type StudentListExports interface {
+	Export(ctx context.Context, input ExportStudentsInput) error
+}
+
+type UpdateStudentTagsInput struct {
+	SchoolID   primitive.ObjectID
+	StudentIDs []primitive.ObjectID
+	Add        []string
+	Remove     []string
+}
+
+// SetStudentFieldsInput sets custom field values by field key, empty value removes the field.
+type SetStudentFieldsInput struct {
+	School    domain.School
+	StudentID primitive.ObjectID
+	Fields    map[string]string
+}
+
+type StudentAttributes interface {
+	UpdateTags(ctx context.Context, input UpdateStudentTagsInput) error
+	SetFields(ctx context.Context, input SetStudentFieldsInput) error
+}
+
+type CreateStudentSegmentInput struct {
+	SchoolID primitive.ObjectID
+	Name     string
+	Search   string
+	Filters  domain.StudentFiltersQuery
+}
+
+type UpdateStudentSegmentInput struct {
+	ID       primitive.ObjectID
+	SchoolID primitive.ObjectID
+	Name     string
+	Search   string
+	Filters  domain.StudentFiltersQuery
+}
+
+type StudentSegments interface {
+	Create(ctx context.Context, input CreateStudentSegmentInput) (domain.StudentSegment, error)
+	GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error)
+	Update(ctx context.Context, input UpdateStudentSegmentInput) error
+	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
 }
 
 type StudentLessons interface {
-	AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error
-	SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID) error
+	AddFinished(ctx context.Context, studentId, lessonId primitive.ObjectID, module domain.Module) error
+	SetLastOpened(ctx context.Context, studentId, lessonId primitive.ObjectID, module domain.Module) error
+}
+
+type CourseProgress interface {
+	GetByStudent(ctx context.Context, student domain.Student) ([]domain.CourseProgress, error)
+	GetByCourse(ctx context.Context, schoolId, studentId, courseId primitive.ObjectID) (domain.CourseProgress, error)
+	CheckCompletion(ctx context.Context, studentId primitive.ObjectID, module domain.Module) error
+	GetStats(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.CourseProgressStats, error)
+}
+
+type LessonGating interface {
+	GetPrerequisites(ctx context.Context, studentId primitive.ObjectID, module domain.Module) (map[primitive.ObjectID]domain.Prerequisite, error)
+}
+
+type StudentActivities interface {
+	Log(ctx context.Context, activity domain.StudentActivity)
+	GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID, query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error)
+	DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error
+}
+
+// AdminSignInResult contains either Tokens or, when admin has two-factor authentication enabled,
+// a ChallengeToken that should be exchanged for Tokens with a one-time code.
+type AdminSignInResult struct {
+	Tokens         Tokens
+	ChallengeToken string
+}
+
+type AdminTwoFactorSignInInput struct {
+	ChallengeToken string
+	Code           string
+	SchoolID       primitive.ObjectID
+	SchoolDomain   string
+	Device         Device
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type InviteAdminInput struct {
+	Email        string
+	Name         string
+	Role         string
+	SchoolID     primitive.ObjectID
+	SchoolName   string
+	SchoolDomain string
+}
+
+type AcceptAdminInvitationInput struct {
+	Token    string
+	Name     string
+	Password string
+	SchoolID primitive.ObjectID
 }
 
 type Admins interface {
-	SignIn(ctx context.Context, input SchoolSignInInput) (Tokens, error)
-	RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (Tokens, error)
+	SignIn(ctx context.Context, input SchoolSignInInput) (AdminSignInResult, error)
+	SignInTwoFactor(ctx context.Context, input AdminTwoFactorSignInInput) (Tokens, error)
+	RefreshTokens(ctx context.Context, input SchoolRefreshTokensInput) (Tokens, error)
+	EnrollTwoFactor(ctx context.Context, adminId primitive.ObjectID, issuer string) (TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, adminId primitive.ObjectID, code string) error
+	RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	GetById(ctx context.Context, schoolId, adminId primitive.ObjectID) (domain.Admin, error)
+	GetTeam(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Admin, error)
+	InviteAdmin(ctx context.Context, input InviteAdminInput) error
+	AcceptInvitation(ctx context.Context, input AcceptAdminInvitationInput) error
+	SetRole(ctx context.Context, schoolId, adminId primitive.ObjectID, role string) error
+	RemoveFromTeam(ctx context.Context, schoolId, currentAdminId, adminId primitive.ObjectID) error
 	GetCourses(ctx context.Context, schoolId primitive.ObjectID) ([]domain.Course, error)
 	GetCourseById(ctx context.Context, schoolId, courseId primitive.ObjectID) (domain.Course, error)
 	CreateStudent(ctx context.Context, inp domain.CreateStudentInput) (domain.Student, error)
@@ -141,6 +473,60 @@ type VerificationEmailInput struct {
 	Domain           string
 }
 
+type EmailChangeEmailInput struct {
+	Email  string
+	Name   string
+	Token  string
+	Domain string
+}
+
+type ConfirmationCodeEmailInput struct {
+	Email string
+	Name  string
+	Code  string
+}
+
+type StudentWelcomeEmailInput struct {
+	Email  string
+	Name   string
+	Domain string
+}
+
+type DataExportEmailInput struct {
+	Email       string
+	Name        string
+	DownloadURL string
+}
+
+type MagicLinkEmailInput struct {
+	Email  string
+	Token  string
+	Domain string
+}
+
+type AdminInvitationEmailInput struct {
+	Email      string
+	Name       string
+	SchoolName string
+	Role       string
+	Token      string
+	Domain     string
+}
+
+type AccountLockedEmailInput struct {
+	Email       string
+	Name        string
+	LockedUntil time.Time
+	Domain      string
+}
+
+type PasswordResetEmailInput struct {
+	Email  string
+	Name   string
+	Token  string
+	Domain string
+}
+
 type StudentPurchaseSuccessfulEmailInput struct {
 	Email      string
 	Name       string
@@ -151,6 +537,14 @@ type Emails interface {
 	SendStudentVerificationEmail(VerificationEmailInput) error
 	SendUserVerificationEmail(VerificationEmailInput) error
 	SendStudentPurchaseSuccessfulEmail(StudentPurchaseSuccessfulEmailInput) error
+	SendPasswordResetEmail(PasswordResetEmailInput) error
+	SendAccountLockedEmail(AccountLockedEmailInput) error
+	SendAdminInvitationEmail(AdminInvitationEmailInput) error
+	SendMagicLinkEmail(MagicLinkEmailInput) error
+	SendEmailChangeEmail(EmailChangeEmailInput) error
+	SendConfirmationCodeEmail(ConfirmationCodeEmailInput) error
+	SendDataExportEmail(DataExportEmailInput) error
+	SendStudentWelcomeEmail(StudentWelcomeEmailInput) error
 	AddStudentToList(ctx context.Context, email, name string, schoolID primitive.ObjectID) error
 }
 
@@ -162,6 +556,7 @@ type UpdateCourseInput struct {
 	Description *string
 	Color       *string
 	Published   *bool
+	Gating      *string
 }
 
 type Courses interface {
@@ -238,12 +633,17 @@ type CreateModuleInput struct {
 	Position uint
 }
 
+// UpdateModuleInput schedules module publishing with PublishAt, zero time cancels the schedule.
+// DripDays unlocks module N days after student got access to the offer, empty Gating inherits mode of the course.
 type UpdateModuleInput struct {
 	ID        string
 	SchoolID  string
 	Name      string
 	Position  *uint
 	Published *bool
+	PublishAt *time.Time
+	DripDays  *uint
+	Gating    *string
 }
 
 type Modules interface {
@@ -251,10 +651,13 @@ type Modules interface {
 	Update(ctx context.Context, inp UpdateModuleInput) error
 	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
 	DeleteByCourse(ctx context.Context, schoolId, courseId primitive.ObjectID) error
+	InitPublishScheduler(ctx context.Context)
 	GetPublishedByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error)
 	GetByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error)
+	GetScheduledByCourseId(ctx context.Context, courseId primitive.ObjectID) ([]domain.Module, error)
 	GetById(ctx context.Context, moduleId primitive.ObjectID) (domain.Module, error)
 	GetByPackages(ctx context.Context, packageIds []primitive.ObjectID) ([]domain.Module, error)
+	GetByIds(ctx context.Context, ids []primitive.ObjectID) ([]domain.Module, error)
 	GetWithContent(ctx context.Context, moduleId primitive.ObjectID) (domain.Module, error)
 	GetByLesson(ctx context.Context, lessonId primitive.ObjectID) (domain.Module, error)
 }
@@ -266,19 +669,42 @@ type AddLessonInput struct {
 	Position uint
 }
 
+// UpdateLessonInput replaces lesson content with Blocks when they are not nil,
+// raw Content is kept for older clients and is saved as a single text block.
+// Every content save is stored as a new version, Draft versions are not shown to students until published.
+// Lesson is scheduled the same way as module, see UpdateModuleInput.
 type UpdateLessonInput struct {
 	LessonID  string
 	SchoolID  string
+	AdminID   primitive.ObjectID
 	Name      string
 	Content   string
+	Blocks    []domain.LessonBlock
+	Draft     bool
 	Position  *uint
 	Published *bool
+	PublishAt *time.Time
+	DripDays  *uint
+}
+
+type RestoreLessonVersionInput struct {
+	SchoolID primitive.ObjectID
+	LessonID primitive.ObjectID
+	AdminID  primitive.ObjectID
+	Version  int
+	Publish  bool
 }
 
 type Lessons interface {
 	Create(ctx context.Context, inp AddLessonInput) (primitive.ObjectID, error)
 	GetById(ctx context.Context, lessonId primitive.ObjectID) (domain.Lesson, error)
+	GetDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) (*domain.LessonDraft, error)
 	Update(ctx context.Context, inp UpdateLessonInput) error
+	PublishDraft(ctx context.Context, schoolId, lessonId primitive.ObjectID) error
+	GetVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID, query domain.PaginationQuery) ([]domain.LessonContentVersion, int64, error)
+	GetVersion(ctx context.Context, schoolId, lessonId primitive.ObjectID, version int) (domain.LessonContentVersion, error)
+	DiffVersions(ctx context.Context, schoolId, lessonId primitive.ObjectID, from, to int) (domain.LessonContentDiff, error)
+	RestoreVersion(ctx context.Context, inp RestoreLessonVersionInput) error
 	Delete(ctx context.Context, schoolId, id primitive.ObjectID) error
 	DeleteContent(ctx context.Context, schoolId primitive.ObjectID, lessonIds []primitive.ObjectID) error
 }
@@ -341,28 +767,57 @@ type Surveys interface {
 	GetStudentResults(ctx context.Context, moduleId, studentId primitive.ObjectID) (domain.SurveyResult, error)
 }
 
+type CreateAPIKeyInput struct {
+	SchoolID primitive.ObjectID
+	AdminID  primitive.ObjectID
+	Name     string
+	Scopes   []string
+}
+
+type CreateAPIKeyResult struct {
+	APIKey domain.APIKey
+	Key    string
+}
+
+type APIKeys interface {
+	Create(ctx context.Context, input CreateAPIKeyInput) (CreateAPIKeyResult, error)
+	GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, schoolId, id primitive.ObjectID) error
+	Authenticate(ctx context.Context, schoolId primitive.ObjectID, key string) (domain.APIKey, error)
+}
+
 type Services struct {
-	Schools        Schools
-	Students       Students
-	StudentLessons StudentLessons
-	Courses        Courses
-	PromoCodes     PromoCodes
-	Offers         Offers
-	Packages       Packages
-	Modules        Modules
-	Lessons        Lessons
-	Payments       Payments
-	Orders         Orders
-	Admins         Admins
-	Files          Files
-	Users          Users
-	Surveys        Surveys
+	Schools            Schools
+	Students           Students
+	StudentLessons     StudentLessons
+	StudentExports     StudentExports
+	StudentImports     StudentImports
+	StudentListExports StudentListExports
+	StudentAttributes  StudentAttributes
+	StudentSegments    StudentSegments
+	StudentActivities  StudentActivities
+	CourseProgress     CourseProgress
+	Courses            Courses
+	PromoCodes         PromoCodes
+	Offers             Offers
+	Packages           Packages
+	Modules            Modules
+	Lessons            Lessons
+	Payments           Payments
+	Orders             Orders
+	Admins             Admins
+	Files              Files
+	Users              Users
+	Surveys            Surveys
+	Sessions           Sessions
+	APIKeys            APIKeys
 }
 
 type Deps struct {
 	Repos                  *repository.Repositories
 	Cache                  cache.Cache
 	Hasher                 hash.PasswordHasher
+	AdminHasher            hash.PasswordHasher
 	TokenManager           auth.TokenManager
 	EmailSender            email.Sender
 	EmailConfig            config.EmailConfig
@@ -372,7 +827,13 @@ type Deps struct {
 	FondyCallbackURL       string
 	CacheTTL               int64
 	OtpGenerator           otp.Generator
+	TOTP                   otp.TOTP
+	OIDCProvider           oidc.Provider
 	VerificationCodeLength int
+	VerificationCodeTTL    time.Duration
+	SignInAttempts         config.SignInAttemptsConfig
+	PasswordResetTokenTTL  time.Duration
+	MagicLinkTTL           time.Duration
 	Environment            string
 	Domain                 string
 	DNS                    dns.DomainManager
@@ -381,36 +842,59 @@ type Deps struct {
 func NewServices(deps Deps) *Services {
 	schoolsService := NewSchoolsService(deps.Repos.Schools, deps.Cache, deps.CacheTTL)
 	emailsService := NewEmailsService(deps.EmailSender, deps.EmailConfig, *schoolsService, deps.Cache)
-	modulesService := NewModulesService(deps.Repos.Modules, deps.Repos.LessonContent)
+	modulesService := NewModulesService(deps.Repos.Modules, deps.Repos.LessonContent, deps.Repos.LessonVersions)
 	coursesService := NewCoursesService(deps.Repos.Courses, modulesService)
 	packagesService := NewPackagesService(deps.Repos.Packages, deps.Repos.Modules)
 	offersService := NewOffersService(deps.Repos.Offers, modulesService, packagesService)
 	promoCodesService := NewPromoCodeService(deps.Repos.PromoCodes)
-	lessonsService := NewLessonsService(deps.Repos.Modules, deps.Repos.LessonContent)
-	studentLessonsService := NewStudentLessonsService(deps.Repos.StudentLessons)
-	studentsService := NewStudentsService(deps.Repos.Students, modulesService, offersService, lessonsService, deps.Hasher,
-		deps.TokenManager, emailsService, studentLessonsService, deps.AccessTokenTTL, deps.RefreshTokenTTL, deps.OtpGenerator, deps.VerificationCodeLength)
-	ordersService := NewOrdersService(deps.Repos.Orders, offersService, promoCodesService, studentsService)
-	usersService := NewUsersService(deps.Repos.Users, deps.Hasher, deps.TokenManager, emailsService, schoolsService, deps.DNS,
-		deps.AccessTokenTTL, deps.RefreshTokenTTL, deps.OtpGenerator, deps.VerificationCodeLength, deps.Domain)
+	lessonsService := NewLessonsService(deps.Repos.Modules, deps.Repos.LessonContent, deps.Repos.LessonVersions, deps.Repos.Files)
+	studentActivitiesService := NewStudentActivitiesService(deps.Repos.StudentActivities)
+	courseProgressService := NewCourseProgressService(deps.Repos.StudentLessons, deps.Repos.Courses, modulesService, studentActivitiesService)
+	studentLessonsService := NewStudentLessonsService(deps.Repos.StudentLessons, studentActivitiesService, courseProgressService)
+	sessionsService := NewSessionsService(deps.Repos.Sessions, deps.TokenManager, deps.AccessTokenTTL, deps.RefreshTokenTTL)
+	passwordResetsService := NewPasswordResetsService(deps.Repos.OneTimeTokens, deps.OtpGenerator, deps.PasswordResetTokenTTL)
+	signInAttemptsService := NewSignInAttemptsService(deps.Cache, deps.SignInAttempts)
+	lessonGatingService := NewLessonGatingService(deps.Repos.Courses, deps.Repos.StudentLessons, deps.Repos.SurveyResults, modulesService)
+	studentExportsService := NewStudentExportsService(deps.Repos.Students, deps.Repos.StudentLessons, deps.Repos.Orders, deps.Repos.SurveyResults,
+		deps.StorageProvider, emailsService, deps.Cache, deps.Environment)
+	studentsService := NewStudentsService(deps.Repos.Students, deps.Repos.OneTimeTokens, deps.Repos.Orders, deps.Repos.SurveyResults, deps.Repos.StudentSegments, modulesService, offersService, lessonsService, deps.Hasher,
+		sessionsService, passwordResetsService, signInAttemptsService, emailsService, studentLessonsService, studentActivitiesService, studentExportsService, lessonGatingService,
+		deps.OtpGenerator, deps.OIDCProvider,
+		deps.VerificationCodeLength, deps.VerificationCodeTTL, deps.MagicLinkTTL)
+	studentImportsService := NewStudentImportsService(deps.Repos.StudentImports, deps.Repos.Students, offersService, studentsService, emailsService,
+		deps.Hasher, deps.OtpGenerator, deps.VerificationCodeLength, deps.VerificationCodeTTL)
+	studentListExportsService := NewStudentListExportsService(deps.Repos.Students, deps.Repos.StudentLessons, deps.Repos.StudentSegments,
+		offersService, modulesService)
+	ordersService := NewOrdersService(deps.Repos.Orders, offersService, promoCodesService, studentsService, studentActivitiesService)
+	usersService := NewUsersService(deps.Repos.Users, deps.Repos.Admins, deps.Hasher, sessionsService, passwordResetsService, signInAttemptsService, emailsService, schoolsService,
+		deps.DNS, deps.OtpGenerator, deps.VerificationCodeLength, deps.VerificationCodeTTL, deps.Domain)
 
 	return &Services{
-		Schools:        schoolsService,
-		Students:       studentsService,
-		StudentLessons: studentLessonsService,
-		Courses:        coursesService,
-		PromoCodes:     promoCodesService,
-		Offers:         offersService,
-		Modules:        modulesService,
+		Schools:            schoolsService,
+		Students:           studentsService,
+		StudentLessons:     studentLessonsService,
+		StudentExports:     studentExportsService,
+		StudentImports:     studentImportsService,
+		StudentListExports: studentListExportsService,
+		StudentAttributes:  NewStudentAttributesService(deps.Repos.Students),
+		StudentSegments:    NewStudentSegmentsService(deps.Repos.StudentSegments),
+		StudentActivities:  studentActivitiesService,
+		CourseProgress:     courseProgressService,
+		Courses:            coursesService,
+		PromoCodes:         promoCodesService,
+		Offers:             offersService,
+		Modules:            modulesService,
 		Payments: NewPaymentsService(ordersService, offersService, studentsService, emailsService, schoolsService,
-			deps.FondyCallbackURL),
+			studentActivitiesService, deps.FondyCallbackURL),
 		Orders: ordersService,
-		Admins: NewAdminsService(deps.Hasher, deps.TokenManager, deps.Repos.Admins, deps.Repos.Schools, deps.Repos.Students,
-			deps.AccessTokenTTL, deps.RefreshTokenTTL),
+		Admins: NewAdminsService(deps.AdminHasher, deps.OtpGenerator, deps.TOTP, sessionsService, passwordResetsService, signInAttemptsService, emailsService,
+			deps.Repos.Admins, deps.Repos.Schools, deps.Repos.Students, deps.Repos.OneTimeTokens),
 		Packages: packagesService,
 		Lessons:  lessonsService,
 		Files:    NewFilesService(deps.Repos.Files, deps.StorageProvider, deps.Environment),
 		Users:    usersService,
-		Surveys:  NewSurveysService(deps.Repos.Modules, deps.Repos.SurveyResults, deps.Repos.Students),
+		Surveys:  NewSurveysService(deps.Repos.Modules, deps.Repos.SurveyResults, deps.Repos.Students, studentActivitiesService),
+		Sessions: sessionsService,
+		APIKeys:  NewAPIKeysService(deps.Repos.APIKeys, deps.OtpGenerator),
 	}
 }
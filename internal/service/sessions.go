@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionsService struct {
+	repo         repository.Sessions
+	tokenManager auth.TokenManager
+
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewSessionsService(repo repository.Sessions, tokenManager auth.TokenManager, accessTokenTTL, refreshTokenTTL time.Duration) *SessionsService {
+	return &SessionsService{
+		repo:            repo,
+		tokenManager:    tokenManager,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+func (s *SessionsService) Create(ctx context.Context, inp CreateSessionInput) (Tokens, error) {
+	refreshToken, err := s.tokenManager.NewRefreshToken()
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	session := domain.Session{
+		OwnerID:      inp.OwnerID,
+		Role:         inp.Role,
+		SchoolID:     inp.SchoolID,
+		RefreshToken: auth.HashToken(refreshToken),
+		UserAgent:    inp.Device.UserAgent,
+		IP:           inp.Device.IP,
+		CreatedAt:    time.Now(),
+		LastUsedAt:   time.Now(),
+		ExpiresAt:    time.Now().Add(s.refreshTokenTTL),
+	}
+
+	if _, err := s.repo.Create(ctx, session); err != nil {
+		return Tokens{}, err
+	}
+
+	accessToken, err := s.newAccessToken(session, inp.Audience)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Refresh rotates session's refresh token. If an already rotated token is presented,
+// it has probably leaked, so the session it belongs to is revoked.
+func (s *SessionsService) Refresh(ctx context.Context, inp RefreshSessionInput) (domain.Session, Tokens, error) {
+	refreshToken, err := s.tokenManager.NewRefreshToken()
+	if err != nil {
+		return domain.Session{}, Tokens{}, err
+	}
+
+	session, err := s.repo.Rotate(ctx, repository.RotateSessionInput{
+		Role:            inp.Role,
+		SchoolID:        inp.SchoolID,
+		RefreshToken:    auth.HashToken(inp.RefreshToken),
+		NewRefreshToken: auth.HashToken(refreshToken),
+		UserAgent:       inp.Device.UserAgent,
+		IP:              inp.Device.IP,
+		ExpiresAt:       time.Now().Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.Session{}, Tokens{}, s.checkReuse(ctx, inp.Role, inp.RefreshToken)
+		}
+
+		return domain.Session{}, Tokens{}, err
+	}
+
+	accessToken, err := s.newAccessToken(session, inp.Audience)
+	if err != nil {
+		return domain.Session{}, Tokens{}, err
+	}
+
+	return session, Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (s *SessionsService) GetByOwner(ctx context.Context, ownerId primitive.ObjectID) ([]domain.Session, error) {
+	return s.repo.GetByOwner(ctx, ownerId)
+}
+
+func (s *SessionsService) Revoke(ctx context.Context, ownerId, id primitive.ObjectID) error {
+	return s.repo.Delete(ctx, ownerId, id)
+}
+
+func (s *SessionsService) RevokeAll(ctx context.Context, ownerId primitive.ObjectID) error {
+	return s.repo.DeleteByOwner(ctx, ownerId)
+}
+
+func (s *SessionsService) checkReuse(ctx context.Context, role, refreshToken string) error {
+	err := s.repo.DeleteByUsedToken(ctx, role, auth.HashToken(refreshToken))
+	if err == nil {
+		logger.Warn("refresh token reuse detected, session is revoked")
+
+		return domain.ErrRefreshTokenReused
+	}
+
+	return err
+}
+
+func (s *SessionsService) newAccessToken(session domain.Session, audience string) (string, error) {
+	claims := auth.Claims{
+		UserID:   session.OwnerID.Hex(),
+		Role:     session.Role,
+		Audience: audience,
+	}
+
+	if !session.SchoolID.IsZero() {
+		claims.SchoolID = session.SchoolID.Hex()
+	}
+
+	return s.tokenManager.NewJWT(claims, s.accessTokenTTL)
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockSessionsService(t *testing.T) (*service.SessionsService, *mock_repository.MockSessions) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	sessionsRepo := mock_repository.NewMockSessions(mockCtl)
+
+	tokenManager, err := auth.NewManager("signing_key")
+	require.NoError(t, err)
+
+	return service.NewSessionsService(sessionsRepo, tokenManager, time.Minute, time.Minute), sessionsRepo
+}
+
+func TestSessionsService_Refresh(t *testing.T) {
+	sessionsService, sessionsRepo := mockSessionsService(t)
+
+	ctx := context.Background()
+	session := domain.Session{ID: primitive.NewObjectID(), OwnerID: primitive.NewObjectID(), Role: domain.RoleStudent}
+
+	sessionsRepo.EXPECT().Rotate(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, inp repository.RotateSessionInput) (domain.Session, error) {
+			require.Equal(t, auth.HashToken("token"), inp.RefreshToken)
+
+			return session, nil
+		})
+
+	res, tokens, err := sessionsService.Refresh(ctx, service.RefreshSessionInput{
+		RefreshToken: "token", Role: domain.RoleStudent,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, session, res)
+	require.NotEqual(t, "token", tokens.RefreshToken)
+	require.NotEmpty(t, tokens.AccessToken)
+}
+
+func TestSessionsService_RefreshReusedToken(t *testing.T) {
+	sessionsService, sessionsRepo := mockSessionsService(t)
+
+	ctx := context.Background()
+
+	sessionsRepo.EXPECT().Rotate(ctx, gomock.Any()).Return(domain.Session{}, domain.ErrSessionNotFound)
+	sessionsRepo.EXPECT().DeleteByUsedToken(ctx, domain.RoleStudent, auth.HashToken("token")).Return(nil)
+
+	_, tokens, err := sessionsService.Refresh(ctx, service.RefreshSessionInput{
+		RefreshToken: "token", Role: domain.RoleStudent,
+	})
+
+	require.True(t, errors.Is(err, domain.ErrRefreshTokenReused))
+	require.Equal(t, service.Tokens{}, tokens)
+}
+
+func TestSessionsService_RefreshUnknownToken(t *testing.T) {
+	sessionsService, sessionsRepo := mockSessionsService(t)
+
+	ctx := context.Background()
+
+	sessionsRepo.EXPECT().Rotate(ctx, gomock.Any()).Return(domain.Session{}, domain.ErrSessionNotFound)
+	sessionsRepo.EXPECT().DeleteByUsedToken(ctx, domain.RoleStudent, auth.HashToken("token")).Return(domain.ErrSessionNotFound)
+
+	_, tokens, err := sessionsService.Refresh(ctx, service.RefreshSessionInput{
+		RefreshToken: "token", Role: domain.RoleStudent,
+	})
+
+	require.True(t, errors.Is(err, domain.ErrSessionNotFound))
+	require.Equal(t, service.Tokens{}, tokens)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/config"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+)
+
+type SignInAttemptsService struct {
+	cache  cache.Cache
+	config config.SignInAttemptsConfig
+}
+
+// NewSignInAttemptsService keeps attempts in cache. Failures are counted with atomic increments and only integers
+// are stored, so parallel attempts can't overwrite each other and instances sharing the cache see all failures.
+func NewSignInAttemptsService(cache cache.Cache, config config.SignInAttemptsConfig) *SignInAttemptsService {
+	return &SignInAttemptsService{
+		cache:  cache,
+		config: config,
+	}
+}
+
+// Check returns domain.ErrTooManyAttempts while account is in backoff or locked.
+func (s *SignInAttemptsService) Check(key SignInAttemptsKey) error {
+	failures, err := s.getInt(key.String())
+	if err != nil {
+		return err
+	}
+
+	if failures >= int64(s.config.MaxAttempts) {
+		return domain.ErrTooManyAttempts
+	}
+
+	blockedUntil, err := s.getInt(key.blockedUntilKey())
+	if err != nil {
+		return err
+	}
+
+	if time.Now().Before(time.Unix(0, blockedUntil)) {
+		return domain.ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// Failed registers failed sign-in. Non-zero lockedUntil is returned when this failure has locked the account.
+// Locked account is kept locked by the failures counter, which expires after the lockout duration.
+func (s *SignInAttemptsService) Failed(key SignInAttemptsKey) (time.Time, error) {
+	failures, err := s.cache.Increment(key.String(), ttlSeconds(s.config.LockoutDuration))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch {
+	case failures >= int64(s.config.MaxAttempts):
+		return time.Now().Add(s.config.LockoutDuration), nil
+	case failures > int64(s.config.FreeAttempts):
+		delay := s.backoff(int(failures) - s.config.FreeAttempts)
+
+		return time.Time{}, s.cache.Set(key.blockedUntilKey(), time.Now().Add(delay).UnixNano(), ttlSeconds(delay))
+	default:
+		return time.Time{}, nil
+	}
+}
+
+func (s *SignInAttemptsService) Succeeded(key SignInAttemptsKey) error {
+	if err := s.cache.Delete(key.String()); err != nil {
+		return err
+	}
+
+	return s.cache.Delete(key.blockedUntilKey())
+}
+
+func (s *SignInAttemptsService) getInt(key string) (int64, error) {
+	value, err := s.cache.Get(key)
+	if err != nil {
+		if errors.Is(err, cache.ErrItemNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	number, ok := value.(int64)
+	if !ok {
+		return 0, nil
+	}
+
+	return number, nil
+}
+
+// backoff doubles the delay with every failure, but never exceeds lockout duration.
+func (s *SignInAttemptsService) backoff(failures int) time.Duration {
+	delay := s.config.BackoffBase
+	for i := 1; i < failures && delay < s.config.LockoutDuration; i++ {
+		delay *= 2
+	}
+
+	if delay > s.config.LockoutDuration {
+		return s.config.LockoutDuration
+	}
+
+	return delay
+}
+
+func (k SignInAttemptsKey) String() string {
+	return fmt.Sprintf("sign-in-attempts:%s:%s:%s", k.Role, k.SchoolID.Hex(), strings.ToLower(k.Email))
+}
+
+func (k SignInAttemptsKey) blockedUntilKey() string {
+	return k.String() + ":blocked-until"
+}
+
+// ttlSeconds rounds duration up to the whole seconds, cache TTL is set in seconds.
+func ttlSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+// withSignInAttempts runs authenticate unless account is blocked and tracks its failures.
+// onLock is called when failed attempt has locked the account.
+func withSignInAttempts(attempts SignInAttempts, key SignInAttemptsKey, authenticate func() error,
+	onLock func(lockedUntil time.Time)) error {
+	if err := attempts.Check(key); err != nil {
+		return err
+	}
+
+	if err := authenticate(); err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+
+		lockedUntil, failErr := attempts.Failed(key)
+		if failErr != nil {
+			return failErr
+		}
+
+		if lockedUntil.IsZero() {
+			return err
+		}
+
+		if onLock != nil {
+			onLock(lockedUntil)
+		}
+
+		return domain.ErrTooManyAttempts
+	}
+
+	return attempts.Succeeded(key)
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/config"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+)
+
+func TestSignInAttemptsService_Backoff(t *testing.T) {
+	attempts := service.NewSignInAttemptsService(cache.NewMemoryCache(), config.SignInAttemptsConfig{
+		FreeAttempts:    1,
+		MaxAttempts:     3,
+		BackoffBase:     time.Minute,
+		LockoutDuration: time.Hour,
+	})
+	key := service.SignInAttemptsKey{Role: domain.RoleStudent, Email: "student@test.com"}
+
+	lockedUntil, err := attempts.Failed(key)
+	require.NoError(t, err)
+	require.True(t, lockedUntil.IsZero())
+	require.NoError(t, attempts.Check(key))
+
+	lockedUntil, err = attempts.Failed(key)
+	require.NoError(t, err)
+	require.True(t, lockedUntil.IsZero())
+	require.True(t, errors.Is(attempts.Check(key), domain.ErrTooManyAttempts))
+
+	// other accounts are not affected
+	require.NoError(t, attempts.Check(service.SignInAttemptsKey{Role: domain.RoleStudent, Email: "other@test.com"}))
+
+	lockedUntil, err = attempts.Failed(key)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), lockedUntil, time.Second)
+}
+
+func TestSignInAttemptsService_Succeeded(t *testing.T) {
+	attempts := service.NewSignInAttemptsService(cache.NewMemoryCache(), config.SignInAttemptsConfig{
+		MaxAttempts:     3,
+		BackoffBase:     time.Minute,
+		LockoutDuration: time.Hour,
+	})
+	key := service.SignInAttemptsKey{Role: domain.RoleUser, Email: "User@Test.com"}
+
+	_, err := attempts.Failed(key)
+	require.NoError(t, err)
+	require.True(t, errors.Is(attempts.Check(service.SignInAttemptsKey{Role: domain.RoleUser, Email: "user@test.com"}),
+		domain.ErrTooManyAttempts))
+
+	require.NoError(t, attempts.Succeeded(key))
+	require.NoError(t, attempts.Check(key))
+}
+
+func TestSignInAttemptsService_ParallelFailures(t *testing.T) {
+	attempts := service.NewSignInAttemptsService(cache.NewMemoryCache(), config.SignInAttemptsConfig{
+		FreeAttempts:    100,
+		MaxAttempts:     20,
+		BackoffBase:     time.Minute,
+		LockoutDuration: time.Hour,
+	})
+	key := service.SignInAttemptsKey{Role: domain.RoleAdmin, Email: "admin@test.com"}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := attempts.Failed(key)
+			require.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	require.True(t, errors.Is(attempts.Check(key), domain.ErrTooManyAttempts))
+}
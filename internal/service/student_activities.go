@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StudentActivitiesService struct {
+	repo repository.StudentActivities
+}
+
+func NewStudentActivitiesService(repo repository.StudentActivities) *StudentActivitiesService {
+	return &StudentActivitiesService{repo: repo}
+}
+
+// Log appends activity to the student's log. Failure is only logged, so it never breaks the action itself.
+func (s *StudentActivitiesService) Log(ctx context.Context, activity domain.StudentActivity) {
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+
+	if err := s.repo.Create(ctx, activity); err != nil {
+		logger.Errorf("failed to log %s activity of student %s: %s", activity.Type, activity.StudentID.Hex(), err.Error())
+	}
+}
+
+func (s *StudentActivitiesService) GetByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID,
+	query domain.GetStudentActivityQuery) ([]domain.StudentActivity, int64, error) {
+	for _, activityType := range query.Types {
+		if !domain.IsValidStudentActivityType(activityType) {
+			return nil, 0, domain.ErrStudentActivityTypeInvalid
+		}
+	}
+
+	return s.repo.GetByStudent(ctx, schoolId, studentId, query)
+}
+
+func (s *StudentActivitiesService) DeleteByStudent(ctx context.Context, studentId primitive.ObjectID) error {
+	return s.repo.DeleteByStudent(ctx, studentId)
+}
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentLessonsService_LogsActivity(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	studentLessons := mock_repository.NewMockStudentLessons(mockCtl)
+	activities := mock_repository.NewMockStudentActivities(mockCtl)
+	progress := mock_service.NewMockCourseProgress(mockCtl)
+
+	studentLessonsService := service.NewStudentLessonsService(studentLessons, service.NewStudentActivitiesService(activities), progress)
+
+	ctx := context.Background()
+	studentId, lessonId := primitive.NewObjectID(), primitive.NewObjectID()
+	module := domain.Module{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	studentLessons.EXPECT().AddFinished(ctx, studentId, lessonId).Return(nil)
+	activities.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, activity domain.StudentActivity) error {
+		require.Equal(t, domain.StudentActivityLessonFinished, activity.Type)
+		require.Equal(t, module.SchoolID, activity.SchoolID)
+		require.Equal(t, studentId, activity.StudentID)
+		require.Equal(t, module.ID, activity.ModuleID)
+		require.Equal(t, lessonId, activity.LessonID)
+		require.False(t, activity.CreatedAt.IsZero())
+
+		return nil
+	})
+	progress.EXPECT().CheckCompletion(ctx, studentId, module).Return(nil)
+
+	require.NoError(t, studentLessonsService.AddFinished(ctx, studentId, lessonId, module))
+}
+
+func TestStudentActivitiesService_GetByStudent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	activities := mock_repository.NewMockStudentActivities(mockCtl)
+	activitiesService := service.NewStudentActivitiesService(activities)
+
+	ctx := context.Background()
+	schoolId, studentId := primitive.NewObjectID(), primitive.NewObjectID()
+	query := domain.GetStudentActivityQuery{
+		PaginationQuery: domain.PaginationQuery{Limit: 20},
+		Types:           []string{domain.StudentActivityLessonOpened, domain.StudentActivityLessonFinished},
+	}
+
+	activities.EXPECT().GetByStudent(ctx, schoolId, studentId, query).Return([]domain.StudentActivity{{}}, int64(1), nil)
+
+	res, count, err := activitiesService.GetByStudent(ctx, schoolId, studentId, query)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, int64(1), count)
+
+	_, _, err = activitiesService.GetByStudent(ctx, schoolId, studentId, domain.GetStudentActivityQuery{Types: []string{"watched"}})
+	require.ErrorIs(t, err, domain.ErrStudentActivityTypeInvalid)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+)
+
+const (
+	studentTagsMaxCount     = 20
+	studentTagsMaxBulkCount = 1000
+)
+
+type StudentAttributesService struct {
+	repo repository.Students
+}
+
+func NewStudentAttributesService(repo repository.Students) *StudentAttributesService {
+	return &StudentAttributesService{repo: repo}
+}
+
+// UpdateTags adds and removes tags of the students, tags are normalized with domain.NormalizeStudentTag.
+func (s *StudentAttributesService) UpdateTags(ctx context.Context, input UpdateStudentTagsInput) error {
+	if len(input.StudentIDs) == 0 || len(input.StudentIDs) > studentTagsMaxBulkCount ||
+		len(input.Add)+len(input.Remove) > studentTagsMaxCount {
+		return domain.ErrStudentTagInvalid
+	}
+
+	add, err := normalizeStudentTags(input.Add)
+	if err != nil {
+		return err
+	}
+
+	remove, err := normalizeStudentTags(input.Remove)
+	if err != nil {
+		return err
+	}
+
+	if len(add) > 0 {
+		if err := s.repo.AddTags(ctx, input.SchoolID, input.StudentIDs, add); err != nil {
+			return err
+		}
+	}
+
+	if len(remove) > 0 {
+		return s.repo.RemoveTags(ctx, input.SchoolID, input.StudentIDs, remove)
+	}
+
+	return nil
+}
+
+// SetFields sets values of the school's custom fields, empty value removes the field from student.
+func (s *StudentAttributesService) SetFields(ctx context.Context, input SetStudentFieldsInput) error {
+	inp := repository.SetStudentFieldsInput{
+		StudentID: input.StudentID,
+		SchoolID:  input.School.ID,
+		Set:       make(map[string]string, len(input.Fields)),
+	}
+
+	for key, value := range input.Fields {
+		field, ex := input.School.Settings.GetStudentField(key)
+		if !ex {
+			return domain.ErrStudentFieldInvalid
+		}
+
+		if value == "" {
+			inp.Unset = append(inp.Unset, key)
+
+			continue
+		}
+
+		if !field.IsValidValue(value) {
+			return domain.ErrStudentFieldInvalid
+		}
+
+		inp.Set[key] = value
+	}
+
+	return s.repo.SetFields(ctx, inp)
+}
+
+func normalizeStudentTags(tags []string) ([]string, error) {
+	var res []string
+
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag, err := domain.NormalizeStudentTag(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ex := seen[tag]; ex {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentAttributesService_UpdateTags(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	students := mock_repository.NewMockStudents(mockCtl)
+	attributesService := service.NewStudentAttributesService(students)
+
+	schoolId := primitive.NewObjectID()
+	studentIds := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+
+	students.EXPECT().AddTags(gomock.Any(), schoolId, studentIds, []string{"vip", "cohort-2026"}).Return(nil)
+	students.EXPECT().RemoveTags(gomock.Any(), schoolId, studentIds, []string{"trial"}).Return(nil)
+
+	err := attributesService.UpdateTags(context.Background(), service.UpdateStudentTagsInput{
+		SchoolID:   schoolId,
+		StudentIDs: studentIds,
+		Add:        []string{" VIP", "cohort-2026", "vip"},
+		Remove:     []string{"Trial"},
+	})
+	require.NoError(t, err)
+
+	err = attributesService.UpdateTags(context.Background(), service.UpdateStudentTagsInput{
+		SchoolID:   schoolId,
+		StudentIDs: studentIds,
+		Add:        []string{"two words"},
+	})
+	require.ErrorIs(t, err, domain.ErrStudentTagInvalid)
+}
+
+func TestStudentAttributesService_SetFields(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	students := mock_repository.NewMockStudents(mockCtl)
+	attributesService := service.NewStudentAttributesService(students)
+
+	school := domain.School{
+		ID: primitive.NewObjectID(),
+		Settings: domain.Settings{StudentFields: []domain.StudentField{
+			{Key: "company", Name: "Company", Type: domain.StudentFieldText},
+			{Key: "employees", Name: "Employees", Type: domain.StudentFieldNumber},
+			{Key: "birthday", Name: "Birthday", Type: domain.StudentFieldDate},
+		}},
+	}
+	studentId := primitive.NewObjectID()
+
+	students.EXPECT().SetFields(gomock.Any(), repository.SetStudentFieldsInput{
+		StudentID: studentId,
+		SchoolID:  school.ID,
+		Set:       map[string]string{"company": "Creatly", "employees": "12"},
+		Unset:     []string{"birthday"},
+	}).Return(nil)
+
+	err := attributesService.SetFields(context.Background(), service.SetStudentFieldsInput{
+		School:    school,
+		StudentID: studentId,
+		Fields:    map[string]string{"company": "Creatly", "employees": "12", "birthday": ""},
+	})
+	require.NoError(t, err)
+
+	for name, fields := range map[string]map[string]string{
+		"unknown field":  {"phone": "+380001112233"},
+		"invalid number": {"employees": "many"},
+		"invalid date":   {"birthday": "01.01.2000"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := attributesService.SetFields(context.Background(), service.SetStudentFieldsInput{
+				School:    school,
+				StudentID: studentId,
+				Fields:    fields,
+			})
+			require.ErrorIs(t, err, domain.ErrStudentFieldInvalid)
+		})
+	}
+}
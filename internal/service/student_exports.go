@@ -0,0 +1,210 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	studentExportContentType = "application/zip"
+	studentExportTimeout     = time.Minute * 10
+	studentExportInterval    = time.Hour      // student can request one export per interval
+	studentExportTTL         = time.Hour * 24 // archive and its download link expire after TTL
+
+	_exportsCleanerInterval = time.Hour
+)
+
+// StudentExportsService builds archive with all personal data of the student (GDPR "right of access").
+type StudentExportsService struct {
+	studentsRepo       repository.Students
+	studentLessonsRepo repository.StudentLessons
+	ordersRepo         repository.Orders
+	surveyResultsRepo  repository.SurveyResults
+	storage            storage.Provider
+	emailService       Emails
+	cache              cache.Cache
+
+	env string
+}
+
+func NewStudentExportsService(studentsRepo repository.Students, studentLessonsRepo repository.StudentLessons, ordersRepo repository.Orders,
+	surveyResultsRepo repository.SurveyResults, storage storage.Provider, emailService Emails, cache cache.Cache, env string) *StudentExportsService {
+	return &StudentExportsService{
+		studentsRepo:       studentsRepo,
+		studentLessonsRepo: studentLessonsRepo,
+		ordersRepo:         ordersRepo,
+		surveyResultsRepo:  surveyResultsRepo,
+		storage:            storage,
+		emailService:       emailService,
+		cache:              cache,
+		env:                env,
+	}
+}
+
+// Export starts building the archive in background, download link is sent to the student when it's uploaded.
+// Student can request one export per interval, repeated requests prolong the limit.
+func (s *StudentExportsService) Export(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
+	student, err := s.studentsRepo.GetById(ctx, schoolId, studentId)
+	if err != nil {
+		return err
+	}
+
+	requests, err := s.cache.Increment(studentExportLimitKey(studentId), ttlSeconds(studentExportInterval))
+	if err != nil {
+		return err
+	}
+
+	if requests > 1 {
+		return domain.ErrStudentExportTooFrequent
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), studentExportTimeout)
+		defer cancel()
+
+		if err := s.export(ctx, student); err != nil {
+			logger.Errorf("failed to export data of student %s: %s", student.ID.Hex(), err.Error())
+		}
+	}()
+
+	return nil
+}
+
+// DeleteByStudent removes all uploaded archives of the student.
+func (s *StudentExportsService) DeleteByStudent(ctx context.Context, schoolId, studentId primitive.ObjectID) error {
+	return s.storage.Remove(ctx, storage.RemoveInput{Prefix: s.studentExportsPrefix(schoolId, studentId)})
+}
+
+// InitExpiredExportsCleaner starts worker, that removes archives uploaded more than TTL ago.
+func (s *StudentExportsService) InitExpiredExportsCleaner(ctx context.Context) {
+	go func() {
+		for {
+			if err := s.storage.Remove(ctx, storage.RemoveInput{
+				Prefix:         fmt.Sprintf("%s/exports/", s.env),
+				ModifiedBefore: time.Now().Add(-studentExportTTL),
+			}); err != nil {
+				logger.Error("failed to remove expired student exports: ", err)
+			}
+
+			time.Sleep(_exportsCleanerInterval)
+		}
+	}()
+}
+
+// export keeps only the latest archive of the student, it's private and available by signed link only.
+func (s *StudentExportsService) export(ctx context.Context, student domain.Student) error {
+	archive, err := s.buildArchive(ctx, student)
+	if err != nil {
+		return err
+	}
+
+	prefix := s.studentExportsPrefix(student.SchoolID, student.ID)
+
+	if err := s.storage.Remove(ctx, storage.RemoveInput{Prefix: prefix}); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s%s.zip", prefix, uuid.New().String())
+
+	if _, err := s.storage.Upload(ctx, storage.UploadInput{
+		File:        bytes.NewReader(archive),
+		Name:        name,
+		Size:        int64(len(archive)),
+		ContentType: studentExportContentType,
+		Private:     true,
+	}); err != nil {
+		return err
+	}
+
+	// account could be deleted while the archive was building
+	if _, err := s.studentsRepo.GetById(ctx, student.SchoolID, student.ID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return s.storage.Remove(ctx, storage.RemoveInput{Prefix: prefix})
+		}
+
+		return err
+	}
+
+	url, err := s.storage.GetSignedURL(ctx, name, studentExportTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendDataExportEmail(DataExportEmailInput{
+		Email:       student.Email,
+		Name:        student.Name,
+		DownloadURL: url,
+	})
+}
+
+func (s *StudentExportsService) studentExportsPrefix(schoolId, studentId primitive.ObjectID) string {
+	return fmt.Sprintf("%s/exports/%s/%s/", s.env, schoolId.Hex(), studentId.Hex())
+}
+
+func studentExportLimitKey(studentId primitive.ObjectID) string {
+	return "studentExport:" + studentId.Hex()
+}
+
+// buildArchive returns ZIP with JSON files of the student record, lessons progress, orders and survey results.
+func (s *StudentExportsService) buildArchive(ctx context.Context, student domain.Student) ([]byte, error) {
+	progress, err := s.studentLessonsRepo.GetByStudent(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.ordersRepo.GetByStudent(ctx, student.SchoolID, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	surveyResults, err := s.surveyResultsRepo.GetAllByStudent(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// secrets are not personal data
+	student.Password = ""
+	student.Verification.Code = ""
+
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+
+	for name, data := range map[string]interface{}{
+		"student.json":        student,
+		"lessons.json":        progress,
+		"orders.json":         orders,
+		"survey_results.json": surveyResults,
+	} {
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
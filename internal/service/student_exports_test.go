@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+	"github.com/zhashkevych/creatly-backend/pkg/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testStorage struct {
+	uploaded chan []byte
+	removed  chan string
+}
+
+func (s testStorage) Upload(_ context.Context, input storage.UploadInput) (string, error) {
+	if !input.Private {
+		return "", errors.New("export must be private")
+	}
+
+	data, err := io.ReadAll(input.File)
+	if err != nil {
+		return "", err
+	}
+
+	s.uploaded <- data
+
+	return "https://storage.test/" + input.Name, nil
+}
+
+func (s testStorage) GetSignedURL(_ context.Context, name string, _ time.Duration) (string, error) {
+	return "https://storage.test/" + name + "?signature=test", nil
+}
+
+func (s testStorage) Remove(_ context.Context, input storage.RemoveInput) error {
+	s.removed <- input.Prefix
+
+	return nil
+}
+
+func TestStudentExportsService_Export(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	students := mock_repository.NewMockStudents(mockCtl)
+	studentLessons := mock_repository.NewMockStudentLessons(mockCtl)
+	orders := mock_repository.NewMockOrders(mockCtl)
+	surveyResults := mock_repository.NewMockSurveyResults(mockCtl)
+	emails := mock_service.NewMockEmails(mockCtl)
+	fileStorage := testStorage{uploaded: make(chan []byte, 1), removed: make(chan string, 1)}
+
+	exportsService := service.NewStudentExportsService(students, studentLessons, orders, surveyResults, fileStorage, emails,
+		cache.NewMemoryCache(), "test")
+
+	student := domain.Student{
+		ID:           primitive.NewObjectID(),
+		SchoolID:     primitive.NewObjectID(),
+		Name:         "Student",
+		Email:        "student@test.com",
+		Password:     "hash",
+		Verification: domain.Verification{Code: "code", Verified: true},
+	}
+
+	ctx := context.Background()
+	emailSent := make(chan struct{})
+
+	students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil).Times(2)
+	students.EXPECT().GetById(gomock.Any(), student.SchoolID, student.ID).Return(student, nil)
+	studentLessons.EXPECT().GetByStudent(gomock.Any(), student.ID).Return(domain.StudentLessons{StudentID: student.ID}, nil)
+	orders.EXPECT().GetByStudent(gomock.Any(), student.SchoolID, student.ID).Return([]domain.Order{{Amount: 100}}, nil)
+	surveyResults.EXPECT().GetAllByStudent(gomock.Any(), student.ID).Return(nil, nil)
+	emails.EXPECT().SendDataExportEmail(gomock.Any()).DoAndReturn(func(input service.DataExportEmailInput) error {
+		require.Equal(t, student.Email, input.Email)
+		require.Contains(t, input.DownloadURL, "signature")
+		close(emailSent)
+
+		return nil
+	})
+
+	require.NoError(t, exportsService.Export(ctx, student.SchoolID, student.ID))
+	require.ErrorIs(t, exportsService.Export(ctx, student.SchoolID, student.ID), domain.ErrStudentExportTooFrequent)
+
+	select {
+	case prefix := <-fileStorage.removed:
+		require.Equal(t, "test/exports/"+student.SchoolID.Hex()+"/"+student.ID.Hex()+"/", prefix)
+	case <-time.After(time.Second):
+		t.Fatal("previous exports weren't removed")
+	}
+
+	var archive []byte
+
+	select {
+	case archive = <-fileStorage.uploaded:
+	case <-time.After(time.Second):
+		t.Fatal("archive wasn't uploaded")
+	}
+
+	select {
+	case <-emailSent:
+	case <-time.After(time.Second):
+		t.Fatal("email wasn't sent")
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 4)
+
+	for _, file := range reader.File {
+		if file.Name != "student.json" {
+			continue
+		}
+
+		f, err := file.Open()
+		require.NoError(t, err)
+
+		var exported domain.Student
+		require.NoError(t, json.NewDecoder(f).Decode(&exported))
+		require.Equal(t, student.Email, exported.Email)
+		require.Empty(t, exported.Password)
+		require.Empty(t, exported.Verification.Code)
+	}
+}
+
+func TestStudentExportsService_ExportUnknownStudent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	students := mock_repository.NewMockStudents(mockCtl)
+	exportsService := service.NewStudentExportsService(students, nil, nil, nil, nil, nil, cache.NewMemoryCache(), "test")
+
+	students.EXPECT().GetById(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+
+	err := exportsService.Export(context.Background(), primitive.NewObjectID(), primitive.NewObjectID())
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
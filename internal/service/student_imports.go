@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	studentImportMaxRows          = 10000
+	studentImportProgressInterval = 100
+
+	studentImportMinNameLength     = 2
+	studentImportMaxNameLength     = 64
+	studentImportMinPasswordLength = 8
+)
+
+// studentImportRecord is a parsed CSV row, columns: name, email, password (optional),
+// offers (optional, ids separated by ";" or spaces).
+type studentImportRecord struct {
+	Line     int
+	Name     string
+	Email    string
+	Password string
+	Offers   []string
+}
+
+type StudentImportsService struct {
+	repo          repository.StudentImports
+	studentsRepo  repository.Students
+	offersService Offers
+	students      Students
+	emailService  Emails
+	hasher        hash.PasswordHasher
+	otpGenerator  otp.Generator
+
+	verificationCodeLength int
+	verificationCodeTTL    time.Duration
+}
+
+func NewStudentImportsService(repo repository.StudentImports, studentsRepo repository.Students, offersService Offers, students Students,
+	emailService Emails, hasher hash.PasswordHasher, otpGenerator otp.Generator, verificationCodeLength int,
+	verificationCodeTTL time.Duration) *StudentImportsService {
+	return &StudentImportsService{
+		repo:                   repo,
+		studentsRepo:           studentsRepo,
+		offersService:          offersService,
+		students:               students,
+		emailService:           emailService,
+		hasher:                 hasher,
+		otpGenerator:           otpGenerator,
+		verificationCodeLength: verificationCodeLength,
+		verificationCodeTTL:    verificationCodeTTL,
+	}
+}
+
+// Start parses CSV file and processes its rows in background, progress and per-row report
+// are available by import id.
+func (s *StudentImportsService) Start(ctx context.Context, input StartStudentImportInput) (domain.StudentImport, error) {
+	records, err := parseStudentImportCSV(input.File)
+	if err != nil {
+		return domain.StudentImport{}, err
+	}
+
+	studentImport := domain.StudentImport{
+		SchoolID:  input.SchoolID,
+		CreatedBy: input.AdminID,
+		DryRun:    input.DryRun,
+		Emails:    input.Emails,
+		Status:    domain.StudentImportRunning,
+		Total:     len(records),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, &studentImport); err != nil {
+		return domain.StudentImport{}, err
+	}
+
+	go s.process(context.Background(), studentImport, records, input.SchoolDomain)
+
+	return studentImport, nil
+}
+
+func (s *StudentImportsService) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.StudentImport, error) {
+	return s.repo.GetById(ctx, schoolId, id)
+}
+
+func (s *StudentImportsService) process(ctx context.Context, studentImport domain.StudentImport, records []studentImportRecord,
+	schoolDomain string) {
+	studentImport.Rows = make([]domain.StudentImportRow, 0, len(records))
+
+	offers, err := s.schoolOffers(ctx, studentImport.SchoolID)
+	if err != nil {
+		s.finish(ctx, studentImport, err)
+
+		return
+	}
+
+	emails := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		row := s.processRecord(ctx, studentImport, record, offers, emails, schoolDomain)
+
+		studentImport.Rows = append(studentImport.Rows, row)
+		studentImport.Processed++
+
+		if row.Status == domain.StudentImportRowFailed {
+			studentImport.Failed++
+		} else {
+			studentImport.Succeeded++
+		}
+
+		if studentImport.Processed%studentImportProgressInterval == 0 {
+			if err := s.repo.SetProgress(ctx, studentImport.ID, repository.StudentImportProgressInput{
+				Processed: studentImport.Processed,
+				Succeeded: studentImport.Succeeded,
+				Failed:    studentImport.Failed,
+			}); err != nil {
+				logger.Errorf("failed to save progress of students import %s: %s", studentImport.ID.Hex(), err.Error())
+			}
+		}
+	}
+
+	s.finish(ctx, studentImport, nil)
+}
+
+func (s *StudentImportsService) finish(ctx context.Context, studentImport domain.StudentImport, err error) {
+	studentImport.Status = domain.StudentImportFinished
+
+	if err != nil {
+		studentImport.Status = domain.StudentImportFailed
+		studentImport.Error = err.Error()
+	}
+
+	if err := s.repo.Finish(ctx, studentImport); err != nil {
+		logger.Errorf("failed to finish students import %s: %s", studentImport.ID.Hex(), err.Error())
+	}
+}
+
+func (s *StudentImportsService) processRecord(ctx context.Context, studentImport domain.StudentImport, record studentImportRecord,
+	offers map[string]domain.Offer, emails map[string]struct{}, schoolDomain string) domain.StudentImportRow {
+	row := domain.StudentImportRow{Line: record.Line, Email: record.Email, Status: domain.StudentImportRowFailed}
+
+	recordOffers, err := s.validateRecord(ctx, studentImport.SchoolID, record, offers, emails)
+	if err != nil {
+		row.Error = err.Error()
+
+		return row
+	}
+
+	if studentImport.DryRun {
+		row.Status = domain.StudentImportRowValid
+
+		return row
+	}
+
+	student, err := s.createStudent(ctx, studentImport, record, recordOffers, schoolDomain)
+	if err != nil {
+		row.Error = err.Error()
+
+		return row
+	}
+
+	row.Status = domain.StudentImportRowCreated
+	row.StudentID = student.ID
+
+	return row
+}
+
+func (s *StudentImportsService) validateRecord(ctx context.Context, schoolId primitive.ObjectID, record studentImportRecord,
+	offers map[string]domain.Offer, emails map[string]struct{}) ([]domain.Offer, error) {
+	if len(record.Name) < studentImportMinNameLength || len(record.Name) > studentImportMaxNameLength {
+		return nil, fmt.Errorf("name must be from %d to %d characters", studentImportMinNameLength, studentImportMaxNameLength)
+	}
+
+	if address, err := mail.ParseAddress(record.Email); err != nil || address.Address != record.Email {
+		return nil, errors.New("email is invalid")
+	}
+
+	if _, ex := emails[record.Email]; ex {
+		return nil, errors.New("email is duplicated in the file")
+	}
+
+	emails[record.Email] = struct{}{}
+
+	if record.Password != "" && len(record.Password) < studentImportMinPasswordLength {
+		return nil, fmt.Errorf("password must be at least %d characters", studentImportMinPasswordLength)
+	}
+
+	recordOffers := make([]domain.Offer, len(record.Offers))
+
+	for i, id := range record.Offers {
+		offer, ex := offers[id]
+		if !ex {
+			return nil, fmt.Errorf("offer %s doesn't exists", id)
+		}
+
+		recordOffers[i] = offer
+	}
+
+	_, err := s.studentsRepo.GetByEmail(ctx, schoolId, record.Email)
+	if err == nil {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	return recordOffers, nil
+}
+
+// createStudent creates verified student, unless verification emails are requested.
+// Student without password can set it using password reset.
+func (s *StudentImportsService) createStudent(ctx context.Context, studentImport domain.StudentImport, record studentImportRecord,
+	offers []domain.Offer, schoolDomain string) (domain.Student, error) {
+	student := domain.Student{
+		Name:         record.Name,
+		Email:        record.Email,
+		RegisteredAt: time.Now(),
+		SchoolID:     studentImport.SchoolID,
+		Verification: domain.Verification{Verified: true},
+	}
+
+	if record.Password != "" {
+		passwordHash, err := s.hasher.Hash(record.Password)
+		if err != nil {
+			return domain.Student{}, err
+		}
+
+		student.Password = passwordHash
+	}
+
+	if studentImport.Emails == domain.StudentImportEmailsVerification {
+		student.Verification = newVerification(s.otpGenerator, s.verificationCodeLength, s.verificationCodeTTL)
+	}
+
+	if err := s.studentsRepo.Create(ctx, &student); err != nil {
+		return domain.Student{}, err
+	}
+
+	for _, offer := range offers {
+		if err := s.students.GiveAccessToOffer(ctx, student.ID, offer, studentImport.CreatedBy); err != nil {
+			return student, err
+		}
+	}
+
+	if err := s.sendEmail(studentImport.Emails, student, schoolDomain); err != nil {
+		logger.Errorf("failed to send email to imported student %s: %s", student.ID.Hex(), err.Error())
+	}
+
+	return student, nil
+}
+
+func (s *StudentImportsService) sendEmail(emails string, student domain.Student, schoolDomain string) error {
+	switch emails {
+	case domain.StudentImportEmailsWelcome:
+		return s.emailService.SendStudentWelcomeEmail(StudentWelcomeEmailInput{
+			Email:  student.Email,
+			Name:   student.Name,
+			Domain: schoolDomain,
+		})
+	case domain.StudentImportEmailsVerification:
+		return s.emailService.SendStudentVerificationEmail(VerificationEmailInput{
+			Email:            student.Email,
+			Name:             student.Name,
+			VerificationCode: student.Verification.Code,
+			Domain:           schoolDomain,
+		})
+	default:
+		return nil
+	}
+}
+
+func (s *StudentImportsService) schoolOffers(ctx context.Context, schoolId primitive.ObjectID) (map[string]domain.Offer, error) {
+	offers, err := s.offersService.GetAll(ctx, schoolId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]domain.Offer, len(offers))
+	for _, offer := range offers {
+		res[offer.ID.Hex()] = offer
+	}
+
+	return res, nil
+}
+
+func parseStudentImportCSV(file io.Reader) ([]studentImportRecord, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrStudentImportInvalid
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ex := columns["name"]; !ex {
+		return nil, domain.ErrStudentImportInvalid
+	}
+
+	if _, ex := columns["email"]; !ex {
+		return nil, domain.ErrStudentImportInvalid
+	}
+
+	var records []studentImportRecord
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrStudentImportInvalid, err.Error())
+		}
+
+		if len(records) == studentImportMaxRows {
+			return nil, domain.ErrStudentImportTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		column := func(name string) string {
+			i, ex := columns[name]
+			if !ex || i >= len(fields) {
+				return ""
+			}
+
+			return strings.TrimSpace(fields[i])
+		}
+
+		records = append(records, studentImportRecord{
+			Line:     line,
+			Name:     column("name"),
+			Email:    column("email"),
+			Password: column("password"),
+			Offers: strings.FieldsFunc(column("offers"), func(r rune) bool {
+				return r == ';' || r == ' '
+			}),
+		})
+	}
+
+	if len(records) == 0 {
+		return nil, domain.ErrStudentImportInvalid
+	}
+
+	return records, nil
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type studentImportsMocks struct {
+	repo     *mock_repository.MockStudentImports
+	students *mock_repository.MockStudents
+	offers   *mock_service.MockOffers
+}
+
+func mockStudentImportsService(t *testing.T) (*service.StudentImportsService, studentImportsMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := studentImportsMocks{
+		repo:     mock_repository.NewMockStudentImports(mockCtl),
+		students: mock_repository.NewMockStudents(mockCtl),
+		offers:   mock_service.NewMockOffers(mockCtl),
+	}
+
+	importsService := service.NewStudentImportsService(mocks.repo, mocks.students, mocks.offers,
+		mock_service.NewMockStudents(mockCtl), mock_service.NewMockEmails(mockCtl), testHasher,
+		otp.NewGOTPGenerator(), 8, time.Hour)
+
+	return importsService, mocks
+}
+
+func TestStudentImportsService_StartDryRun(t *testing.T) {
+	importsService, mocks := mockStudentImportsService(t)
+
+	schoolId := primitive.NewObjectID()
+	offer := domain.Offer{ID: primitive.NewObjectID(), SchoolID: schoolId}
+	finished := make(chan domain.StudentImport, 1)
+
+	mocks.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, imp *domain.StudentImport) error {
+		imp.ID = primitive.NewObjectID()
+
+		return nil
+	})
+	mocks.offers.EXPECT().GetAll(gomock.Any(), schoolId).Return([]domain.Offer{offer}, nil)
+	mocks.students.EXPECT().GetByEmail(gomock.Any(), schoolId, "first@test.com").Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().GetByEmail(gomock.Any(), schoolId, "existing@test.com").Return(domain.Student{}, nil)
+	mocks.repo.EXPECT().Finish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, imp domain.StudentImport) error {
+		finished <- imp
+
+		return nil
+	})
+
+	file := strings.Join([]string{
+		"name,email,password,offers",
+		"First,first@test.com,password," + offer.ID.Hex(),
+		"Duplicate,first@test.com,,",
+		"Invalid,not-an-email,,",
+		"Short,short@test.com,123,",
+		"Unknown,unknown@test.com,," + primitive.NewObjectID().Hex(),
+		"Existing,existing@test.com,,",
+	}, "\n")
+
+	res, err := importsService.Start(context.Background(), service.StartStudentImportInput{
+		SchoolID: schoolId,
+		File:     strings.NewReader(file),
+		DryRun:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 6, res.Total)
+	require.Equal(t, domain.StudentImportRunning, res.Status)
+
+	var imp domain.StudentImport
+
+	select {
+	case imp = <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("import wasn't finished")
+	}
+
+	require.Equal(t, domain.StudentImportFinished, imp.Status)
+	require.Equal(t, 6, imp.Processed)
+	require.Equal(t, 1, imp.Succeeded)
+	require.Equal(t, 5, imp.Failed)
+	require.Len(t, imp.Rows, 6)
+
+	require.Equal(t, domain.StudentImportRowValid, imp.Rows[0].Status)
+	require.Equal(t, 2, imp.Rows[0].Line)
+
+	for _, row := range imp.Rows[1:] {
+		require.Equal(t, domain.StudentImportRowFailed, row.Status)
+		require.NotEmpty(t, row.Error)
+	}
+
+	require.Equal(t, domain.ErrUserAlreadyExists.Error(), imp.Rows[5].Error)
+}
+
+func TestStudentImportsService_StartInvalidFile(t *testing.T) {
+	importsService, _ := mockStudentImportsService(t)
+
+	for name, file := range map[string]string{
+		"empty":          "",
+		"missing header": "name,password\nStudent,password",
+		"no rows":        "name,email",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := importsService.Start(context.Background(), service.StartStudentImportInput{
+				SchoolID: primitive.NewObjectID(),
+				File:     strings.NewReader(file),
+			})
+			require.True(t, errors.Is(err, domain.ErrStudentImportInvalid))
+		})
+	}
+}
@@ -3,24 +3,53 @@ package service
 import (
 	"context"
 
+	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type StudentLessonsService struct {
-	repo repository.StudentLessons
+	repo              repository.StudentLessons
+	activitiesService StudentActivities
+	progressService   CourseProgress
 }
 
-func NewStudentLessonsService(repo repository.StudentLessons) *StudentLessonsService {
+func NewStudentLessonsService(repo repository.StudentLessons, activitiesService StudentActivities,
+	progressService CourseProgress) *StudentLessonsService {
 	return &StudentLessonsService{
-		repo: repo,
+		repo:              repo,
+		activitiesService: activitiesService,
+		progressService:   progressService,
 	}
 }
 
-func (s *StudentLessonsService) AddFinished(ctx context.Context, studentID, lessonID primitive.ObjectID) error {
-	return s.repo.AddFinished(ctx, studentID, lessonID)
+func (s *StudentLessonsService) AddFinished(ctx context.Context, studentID, lessonID primitive.ObjectID, module domain.Module) error {
+	if err := s.repo.AddFinished(ctx, studentID, lessonID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, domain.StudentActivityLessonFinished, studentID, lessonID, module)
+
+	return s.progressService.CheckCompletion(ctx, studentID, module)
+}
+
+func (s *StudentLessonsService) SetLastOpened(ctx context.Context, studentID, lessonID primitive.ObjectID, module domain.Module) error {
+	if err := s.repo.SetLastOpened(ctx, studentID, lessonID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, domain.StudentActivityLessonOpened, studentID, lessonID, module)
+
+	return nil
 }
 
-func (s *StudentLessonsService) SetLastOpened(ctx context.Context, studentID, lessonID primitive.ObjectID) error {
-	return s.repo.SetLastOpened(ctx, studentID, lessonID)
+func (s *StudentLessonsService) logActivity(ctx context.Context, activityType string, studentID, lessonID primitive.ObjectID,
+	module domain.Module) {
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  module.SchoolID,
+		StudentID: studentID,
+		Type:      activityType,
+		ModuleID:  module.ID,
+		LessonID:  lessonID,
+	})
 }
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/spreadsheet"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// studentListExportBatchSize is the number of students whose lessons progress is loaded with one query.
+const studentListExportBatchSize = 100
+
+type StudentListExportsService struct {
+	studentsRepo       repository.Students
+	studentLessonsRepo repository.StudentLessons
+	segmentsRepo       repository.StudentSegments
+	offersService      Offers
+	modulesService     Modules
+}
+
+func NewStudentListExportsService(studentsRepo repository.Students, studentLessonsRepo repository.StudentLessons,
+	segmentsRepo repository.StudentSegments, offersService Offers, modulesService Modules) *StudentListExportsService {
+	return &StudentListExportsService{
+		studentsRepo:       studentsRepo,
+		studentLessonsRepo: studentLessonsRepo,
+		segmentsRepo:       segmentsRepo,
+		offersService:      offersService,
+		modulesService:     modulesService,
+	}
+}
+
+// studentListColumns are school's data used to build student rows.
+type studentListColumns struct {
+	offers  map[primitive.ObjectID]string
+	fields  []domain.StudentField
+	courses []courseLessons
+}
+
+// courseLessons contains published lessons of the course, progress is a share of them finished by student.
+type courseLessons struct {
+	name    string
+	lessons []primitive.ObjectID
+}
+
+// Export writes every student matching query filters to the spreadsheet, one row per student
+// with granted offers and progress of each course.
+func (s *StudentListExportsService) Export(ctx context.Context, input ExportStudentsInput) error {
+	writer, err := spreadsheet.NewWriter(input.Format, input.Writer)
+	if err != nil {
+		return err
+	}
+
+	query, err := prepareStudentsQuery(ctx, s.segmentsRepo, input.School.ID, input.Query)
+	if err != nil {
+		return err
+	}
+
+	offers, err := s.offerNames(ctx, input.School.ID)
+	if err != nil {
+		return err
+	}
+
+	courses, err := s.courseLessons(ctx, input.School.Courses)
+	if err != nil {
+		return err
+	}
+
+	columns := studentListColumns{offers: offers, fields: input.School.Settings.StudentFields, courses: courses}
+
+	header := []string{"ID", "Name", "Email", "Registered At", "Last Visit At", "Verified", "Blocked", "Offers", "Tags"}
+	for _, field := range columns.fields {
+		header = append(header, field.Name)
+	}
+
+	for _, course := range courses {
+		header = append(header, course.name+" progress, %")
+	}
+
+	if err := writer.WriteRow(header); err != nil {
+		return err
+	}
+
+	batch := make([]domain.Student, 0, studentListExportBatchSize)
+
+	if err := s.studentsRepo.IterateBySchool(ctx, input.School.ID, query, func(student domain.Student) error {
+		batch = append(batch, student)
+		if len(batch) < studentListExportBatchSize {
+			return nil
+		}
+
+		err := s.writeBatch(ctx, writer, batch, columns)
+		batch = batch[:0]
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := s.writeBatch(ctx, writer, batch, columns); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (s *StudentListExportsService) writeBatch(ctx context.Context, writer spreadsheet.Writer, students []domain.Student,
+	columns studentListColumns) error {
+	if len(students) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, len(students))
+	for i := range students {
+		ids[i] = students[i].ID
+	}
+
+	studentLessons, err := s.studentLessonsRepo.GetByStudents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	finished := make(map[primitive.ObjectID]domain.StudentLessons, len(studentLessons))
+	for _, lessons := range studentLessons {
+		finished[lessons.StudentID] = lessons
+	}
+
+	for _, student := range students {
+		if err := writer.WriteRow(studentListRow(student, columns, finished[student.ID])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func studentListRow(student domain.Student, columns studentListColumns, lessons domain.StudentLessons) []string {
+	offerNames := make([]string, 0, len(student.AvailableOffers))
+
+	for _, id := range student.AvailableOffers {
+		if name, ex := columns.offers[id]; ex {
+			offerNames = append(offerNames, name)
+		}
+	}
+
+	row := []string{
+		student.ID.Hex(),
+		student.Name,
+		student.Email,
+		formatExportTime(student.RegisteredAt),
+		formatExportTime(student.LastVisitAt),
+		strconv.FormatBool(student.Verification.Verified),
+		strconv.FormatBool(student.Blocked),
+		strings.Join(offerNames, "; "),
+		strings.Join(student.Tags, "; "),
+	}
+
+	for _, field := range columns.fields {
+		row = append(row, student.Fields[field.Key])
+	}
+
+	for _, course := range columns.courses {
+		row = append(row, strconv.Itoa(lessons.PercentFinished(course.lessons)))
+	}
+
+	return row
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func (s *StudentListExportsService) offerNames(ctx context.Context, schoolId primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	offers, err := s.offersService.GetAll(ctx, schoolId)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[primitive.ObjectID]string, len(offers))
+	for _, offer := range offers {
+		names[offer.ID] = offer.Name
+	}
+
+	return names, nil
+}
+
+// courseLessons returns published lessons of the courses, courses without them are skipped.
+func (s *StudentListExportsService) courseLessons(ctx context.Context, courses []domain.Course) ([]courseLessons, error) {
+	res := make([]courseLessons, 0, len(courses))
+
+	for _, course := range courses {
+		modules, err := s.modulesService.GetPublishedByCourseId(ctx, course.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		lessons := make([]primitive.ObjectID, 0)
+
+		for _, module := range modules {
+			for _, lesson := range module.Lessons {
+				if lesson.Published {
+					lessons = append(lessons, lesson.ID)
+				}
+			}
+		}
+
+		if len(lessons) > 0 {
+			res = append(res, courseLessons{name: course.Name, lessons: lessons})
+		}
+	}
+
+	return res, nil
+}
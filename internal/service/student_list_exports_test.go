@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/spreadsheet"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentListExportsService_Export(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	students := mock_repository.NewMockStudents(mockCtl)
+	studentLessons := mock_repository.NewMockStudentLessons(mockCtl)
+	offers := mock_service.NewMockOffers(mockCtl)
+	modules := mock_service.NewMockModules(mockCtl)
+
+	exportsService := service.NewStudentListExportsService(students, studentLessons, mock_repository.NewMockStudentSegments(mockCtl),
+		offers, modules)
+
+	offer := domain.Offer{ID: primitive.NewObjectID(), Name: "Basic"}
+	lessons := []domain.Lesson{
+		{ID: primitive.NewObjectID(), Published: true},
+		{ID: primitive.NewObjectID(), Published: true},
+		{ID: primitive.NewObjectID()},
+	}
+	school := domain.School{
+		ID: primitive.NewObjectID(),
+		Courses: []domain.Course{
+			{ID: primitive.NewObjectID(), Name: "Go"},
+			{ID: primitive.NewObjectID(), Name: "Empty"},
+		},
+		Settings: domain.Settings{StudentFields: []domain.StudentField{{Key: "phone", Name: "Phone", Type: domain.StudentFieldText}}},
+	}
+	first := domain.Student{
+		ID:              primitive.NewObjectID(),
+		Name:            "First",
+		Email:           "first@test.com",
+		AvailableOffers: []primitive.ObjectID{offer.ID},
+		Tags:            []string{"vip", "cohort-2026"},
+		Fields:          map[string]string{"phone": "+380001112233"},
+		Verification:    domain.Verification{Verified: true},
+	}
+	second := domain.Student{ID: primitive.NewObjectID(), Name: "=Second", Email: "second@test.com"}
+
+	verified := true
+	query := domain.GetStudentsQuery{StudentFiltersQuery: domain.StudentFiltersQuery{Verified: &verified}}
+
+	offers.EXPECT().GetAll(gomock.Any(), school.ID).Return([]domain.Offer{offer}, nil)
+	modules.EXPECT().GetPublishedByCourseId(gomock.Any(), school.Courses[0].ID).Return([]domain.Module{{Lessons: lessons}}, nil)
+	modules.EXPECT().GetPublishedByCourseId(gomock.Any(), school.Courses[1].ID).Return(nil, nil)
+	students.EXPECT().IterateBySchool(gomock.Any(), school.ID, query, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ primitive.ObjectID, _ domain.GetStudentsQuery, fn func(domain.Student) error) error {
+			require.NoError(t, fn(first))
+
+			return fn(second)
+		})
+	studentLessons.EXPECT().GetByStudents(gomock.Any(), []primitive.ObjectID{first.ID, second.ID}).Return([]domain.StudentLessons{
+		{StudentID: first.ID, Finished: []primitive.ObjectID{lessons[0].ID, lessons[2].ID}},
+	}, nil)
+
+	var buf bytes.Buffer
+
+	err := exportsService.Export(context.Background(), service.ExportStudentsInput{
+		School: school,
+		Query:  query,
+		Format: spreadsheet.FormatCSV,
+		Writer: &buf,
+	})
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"ID", "Name", "Email", "Registered At", "Last Visit At", "Verified", "Blocked", "Offers", "Tags", "Phone", "Go progress, %"},
+		{first.ID.Hex(), "First", "first@test.com", "", "", "true", "false", "Basic", "vip; cohort-2026", "'+380001112233", "50"},
+		{second.ID.Hex(), "'=Second", "second@test.com", "", "", "false", "false", "", "", "", "0"},
+	}, rows)
+}
+
+func TestStudentListExportsService_ExportUnknownFormat(t *testing.T) {
+	exportsService := service.NewStudentListExportsService(nil, nil, nil, nil, nil)
+
+	err := exportsService.Export(context.Background(), service.ExportStudentsInput{Format: "pdf", Writer: &bytes.Buffer{}})
+	require.ErrorIs(t, err, spreadsheet.ErrUnknownFormat)
+}
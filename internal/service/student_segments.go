@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StudentSegmentsService struct {
+	repo repository.StudentSegments
+}
+
+func NewStudentSegmentsService(repo repository.StudentSegments) *StudentSegmentsService {
+	return &StudentSegmentsService{repo: repo}
+}
+
+func (s *StudentSegmentsService) Create(ctx context.Context, input CreateStudentSegmentInput) (domain.StudentSegment, error) {
+	filters, err := normalizeStudentFilters(input.Filters)
+	if err != nil {
+		return domain.StudentSegment{}, err
+	}
+
+	segment := domain.StudentSegment{
+		SchoolID:  input.SchoolID,
+		Name:      input.Name,
+		Search:    input.Search,
+		Filters:   filters,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, &segment); err != nil {
+		return domain.StudentSegment{}, err
+	}
+
+	return segment, nil
+}
+
+func (s *StudentSegmentsService) GetBySchool(ctx context.Context, schoolId primitive.ObjectID) ([]domain.StudentSegment, error) {
+	return s.repo.GetBySchool(ctx, schoolId)
+}
+
+func (s *StudentSegmentsService) Update(ctx context.Context, input UpdateStudentSegmentInput) error {
+	filters, err := normalizeStudentFilters(input.Filters)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, repository.UpdateStudentSegmentInput{
+		ID:       input.ID,
+		SchoolID: input.SchoolID,
+		Name:     input.Name,
+		Search:   input.Search,
+		Filters:  filters,
+	})
+}
+
+func (s *StudentSegmentsService) Delete(ctx context.Context, schoolId, id primitive.ObjectID) error {
+	return s.repo.Delete(ctx, schoolId, id)
+}
+
+// prepareStudentsQuery validates query filters and loads filters of the requested segment.
+func prepareStudentsQuery(ctx context.Context, segmentsRepo repository.StudentSegments, schoolId primitive.ObjectID,
+	query domain.GetStudentsQuery) (domain.GetStudentsQuery, error) {
+	filters, err := normalizeStudentFilters(query.StudentFiltersQuery)
+	if err != nil {
+		return query, err
+	}
+
+	query.StudentFiltersQuery = filters
+	query.SegmentFilters = nil
+
+	if query.Segment == "" {
+		return query, nil
+	}
+
+	segmentId, err := primitive.ObjectIDFromHex(query.Segment)
+	if err != nil {
+		return query, domain.ErrStudentSegmentNotFound
+	}
+
+	segment, err := segmentsRepo.GetById(ctx, schoolId, segmentId)
+	if err != nil {
+		return query, err
+	}
+
+	query.SegmentFilters = &segment
+
+	return query, nil
+}
+
+func normalizeStudentFilters(filters domain.StudentFiltersQuery) (domain.StudentFiltersQuery, error) {
+	for _, date := range []string{filters.RegisterDateFrom, filters.RegisterDateTo, filters.LastVisitDateFrom, filters.LastVisitDateTo} {
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, date); err != nil {
+			return filters, domain.ErrStudentFilterInvalid
+		}
+	}
+
+	if _, err := filters.FieldFilters(); err != nil {
+		return filters, err
+	}
+
+	tags, err := normalizeStudentTags(filters.Tags)
+	if err != nil {
+		return filters, domain.ErrStudentFilterInvalid
+	}
+
+	filters.Tags = tags
+
+	return filters, nil
+}
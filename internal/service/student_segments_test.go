@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentSegmentsService_Create(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	segments := mock_repository.NewMockStudentSegments(mockCtl)
+	segmentsService := service.NewStudentSegmentsService(segments)
+
+	schoolId := primitive.NewObjectID()
+
+	segments.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, segment *domain.StudentSegment) error {
+		require.Equal(t, []string{"vip"}, segment.Filters.Tags)
+
+		segment.ID = primitive.NewObjectID()
+
+		return nil
+	})
+
+	segment, err := segmentsService.Create(context.Background(), service.CreateStudentSegmentInput{
+		SchoolID: schoolId,
+		Name:     "VIP",
+		Filters:  domain.StudentFiltersQuery{Tags: []string{"VIP"}, Fields: []string{"company:Creatly"}},
+	})
+	require.NoError(t, err)
+	require.False(t, segment.ID.IsZero())
+
+	for name, filters := range map[string]domain.StudentFiltersQuery{
+		"invalid field":  {Fields: []string{"$where:1"}},
+		"field no value": {Fields: []string{"company"}},
+		"invalid tag":    {Tags: []string{"#vip"}},
+		"invalid date":   {RegisterDateFrom: "yesterday"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := segmentsService.Create(context.Background(), service.CreateStudentSegmentInput{
+				SchoolID: schoolId,
+				Name:     "Invalid",
+				Filters:  filters,
+			})
+			require.ErrorIs(t, err, domain.ErrStudentFilterInvalid)
+		})
+	}
+}
+
+func TestStudentsService_GetBySchoolWithSegment(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	schoolId := primitive.NewObjectID()
+	segment := domain.StudentSegment{
+		ID:       primitive.NewObjectID(),
+		SchoolID: schoolId,
+		Filters:  domain.StudentFiltersQuery{Tags: []string{"vip"}},
+	}
+
+	mocks.segments.EXPECT().GetById(gomock.Any(), schoolId, segment.ID).Return(segment, nil)
+	mocks.students.EXPECT().GetBySchool(gomock.Any(), schoolId, domain.GetStudentsQuery{
+		StudentFiltersQuery: domain.StudentFiltersQuery{Tags: []string{"cohort-2026"}},
+		Segment:             segment.ID.Hex(),
+		SegmentFilters:      &segment,
+	}).Return(nil, int64(0), nil)
+
+	_, _, err := studentService.GetBySchool(context.Background(), schoolId, domain.GetStudentsQuery{
+		StudentFiltersQuery: domain.StudentFiltersQuery{Tags: []string{"Cohort-2026"}},
+		Segment:             segment.ID.Hex(),
+	})
+	require.NoError(t, err)
+
+	mocks.segments.EXPECT().GetById(gomock.Any(), schoolId, gomock.Any()).Return(domain.StudentSegment{}, domain.ErrStudentSegmentNotFound)
+
+	_, _, err = studentService.GetBySchool(context.Background(), schoolId, domain.GetStudentsQuery{Segment: primitive.NewObjectID().Hex()})
+	require.ErrorIs(t, err, domain.ErrStudentSegmentNotFound)
+}
@@ -7,49 +7,79 @@ import (
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
-	"github.com/zhashkevych/creatly-backend/pkg/auth"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
 	"github.com/zhashkevych/creatly-backend/pkg/logger"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
 	"github.com/zhashkevych/creatly-backend/pkg/otp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type StudentsService struct {
-	repo         repository.Students
-	hasher       hash.PasswordHasher
-	tokenManager auth.TokenManager
-	otpGenerator otp.Generator
+	repo              repository.Students
+	oneTimeTokensRepo repository.OneTimeTokens
+	ordersRepo        repository.Orders
+	surveyResultsRepo repository.SurveyResults
+	segmentsRepo      repository.StudentSegments
+	hasher            hash.PasswordHasher
+	otpGenerator      otp.Generator
+	oidcProvider      oidc.Provider
 
 	modulesService        Modules
 	offersService         Offers
 	emailService          Emails
 	lessonsService        Lessons
 	studentLessonsService StudentLessons
+	activitiesService     StudentActivities
+	exportsService        StudentExports
+	gatingService         LessonGating
+	sessionsService       Sessions
+	passwordResetsService PasswordResets
+	signInAttemptsService SignInAttempts
+
+	dummyPassword *dummyPassword
 
-	accessTokenTTL         time.Duration
-	refreshTokenTTL        time.Duration
 	verificationCodeLength int
+	verificationCodeTTL    time.Duration
+	magicLinkTTL           time.Duration
 }
 
-func NewStudentsService(repo repository.Students, modulesService Modules, offersService Offers, lessonsService Lessons, hasher hash.PasswordHasher, tokenManager auth.TokenManager,
-	emailService Emails, studentLessonsService StudentLessons, accessTTL, refreshTTL time.Duration, otpGenerator otp.Generator, verificationCodeLength int) *StudentsService {
+func NewStudentsService(repo repository.Students, oneTimeTokensRepo repository.OneTimeTokens, ordersRepo repository.Orders,
+	surveyResultsRepo repository.SurveyResults, segmentsRepo repository.StudentSegments, modulesService Modules, offersService Offers, lessonsService Lessons,
+	hasher hash.PasswordHasher, sessionsService Sessions, passwordResetsService PasswordResets, signInAttemptsService SignInAttempts, emailService Emails,
+	studentLessonsService StudentLessons, activitiesService StudentActivities, exportsService StudentExports, gatingService LessonGating, otpGenerator otp.Generator, oidcProvider oidc.Provider, verificationCodeLength int,
+	verificationCodeTTL, magicLinkTTL time.Duration) *StudentsService {
 	return &StudentsService{
 		repo:                   repo,
+		oneTimeTokensRepo:      oneTimeTokensRepo,
+		ordersRepo:             ordersRepo,
+		surveyResultsRepo:      surveyResultsRepo,
+		segmentsRepo:           segmentsRepo,
 		modulesService:         modulesService,
 		offersService:          offersService,
 		hasher:                 hasher,
+		dummyPassword:          newDummyPassword(hasher),
 		emailService:           emailService,
 		lessonsService:         lessonsService,
 		studentLessonsService:  studentLessonsService,
-		tokenManager:           tokenManager,
-		accessTokenTTL:         accessTTL,
-		refreshTokenTTL:        refreshTTL,
+		activitiesService:      activitiesService,
+		exportsService:         exportsService,
+		gatingService:          gatingService,
+		sessionsService:        sessionsService,
+		passwordResetsService:  passwordResetsService,
+		signInAttemptsService:  signInAttemptsService,
 		otpGenerator:           otpGenerator,
+		oidcProvider:           oidcProvider,
 		verificationCodeLength: verificationCodeLength,
+		verificationCodeTTL:    verificationCodeTTL,
+		magicLinkTTL:           magicLinkTTL,
 	}
 }
 
 func (s *StudentsService) SignUp(ctx context.Context, input StudentSignUpInput) error {
+	if input.DisableRegistration {
+		return domain.ErrRegistrationDisabled
+	}
+
 	passwordHash, err := s.hasher.Hash(input.Password)
 	if err != nil {
 		return err
@@ -73,34 +103,47 @@ func (s *StudentsService) SignUp(ctx context.Context, input StudentSignUpInput)
 	}
 
 	// it's possible to use OTP apps (Google Authenticator, Authy) compatibility mode here, in the future
-	verificationCode := s.otpGenerator.RandomSecret(s.verificationCodeLength)
-	student.Verification.Code = verificationCode
+	student.Verification = newVerification(s.otpGenerator, s.verificationCodeLength, s.verificationCodeTTL)
 
 	if err := s.repo.Create(ctx, &student); err != nil {
 		return err
 	}
 
-	// TODO: If it fails, what then?
-	return s.emailService.SendStudentVerificationEmail(VerificationEmailInput{
-		Email:            student.Email,
-		Name:             student.Name,
-		VerificationCode: verificationCode,
-		Domain:           input.SchoolDomain,
-	})
+	// account is already created, if the email is lost student can request a new code with ResendVerification
+	if err := s.sendVerificationEmail(student, input.SchoolDomain); err != nil {
+		logger.Errorf("failed to send verification email to student %s: %s", student.ID.Hex(), err.Error())
+	}
+
+	return nil
 }
 
 func (s *StudentsService) SignIn(ctx context.Context, input SchoolSignInInput) (Tokens, error) {
-	passwordHash, err := s.hasher.Hash(input.Password)
-	if err != nil {
-		return Tokens{}, err
-	}
+	var student domain.Student
 
-	student, err := s.repo.GetByCredentials(ctx, input.SchoolID, input.Email, passwordHash)
-	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return Tokens{}, err
+	attemptsKey := SignInAttemptsKey{Role: domain.RoleStudent, SchoolID: input.SchoolID, Email: input.Email}
+
+	if err := withSignInAttempts(s.signInAttemptsService, attemptsKey, func() error {
+		var err error
+
+		student, err = s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				s.dummyPassword.Verify(input.Password)
+			}
+
+			return err
+		}
+
+		if !student.Verification.Verified {
+			s.dummyPassword.Verify(input.Password)
+
+			return domain.ErrUserNotFound
 		}
 
+		return verifyPassword(s.hasher, input.Password, student.Password, func(passwordHash string) error {
+			return s.repo.SetPassword(ctx, student.ID, passwordHash)
+		})
+	}, nil); err != nil {
 		return Tokens{}, err
 	}
 
@@ -108,29 +151,74 @@ func (s *StudentsService) SignIn(ctx context.Context, input SchoolSignInInput) (
 		return Tokens{}, domain.ErrStudentBlocked
 	}
 
-	return s.createSession(ctx, student.ID)
+	return s.signIn(ctx, student, domain.SignInMethodPassword, input.SchoolDomain, input.Device)
+}
+
+// signIn creates session of the authenticated student and records it in the activity log.
+func (s *StudentsService) signIn(ctx context.Context, student domain.Student, method, schoolDomain string, device Device) (Tokens, error) {
+	tokens, err := s.createSession(ctx, student, schoolDomain, device)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  student.SchoolID,
+		StudentID: student.ID,
+		Type:      domain.StudentActivitySignIn,
+		Method:    method,
+		IP:        device.IP,
+		UserAgent: device.UserAgent,
+	})
+
+	return tokens, nil
+}
+
+func (s *StudentsService) createSession(ctx context.Context, student domain.Student, schoolDomain string, device Device) (Tokens, error) {
+	tokens, err := s.sessionsService.Create(ctx, CreateSessionInput{
+		OwnerID:  student.ID,
+		Role:     domain.RoleStudent,
+		SchoolID: student.SchoolID,
+		Audience: schoolDomain,
+		Device:   device,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return tokens, s.repo.SetLastVisit(ctx, student.ID)
 }
 
-func (s *StudentsService) RefreshTokens(ctx context.Context, schoolId primitive.ObjectID, refreshToken string) (Tokens, error) {
-	student, err := s.repo.GetByRefreshToken(ctx, schoolId, refreshToken)
+func (s *StudentsService) RefreshTokens(ctx context.Context, input SchoolRefreshTokensInput) (Tokens, error) {
+	session, tokens, err := s.sessionsService.Refresh(ctx, RefreshSessionInput{
+		RefreshToken: input.RefreshToken,
+		Role:         domain.RoleStudent,
+		SchoolID:     input.SchoolID,
+		Audience:     input.SchoolDomain,
+		Device:       input.Device,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	student, err := s.repo.GetById(ctx, input.SchoolID, session.OwnerID)
 	if err != nil {
 		return Tokens{}, err
 	}
 
 	if student.Blocked {
+		if err := s.sessionsService.Revoke(ctx, student.ID, session.ID); err != nil {
+			return Tokens{}, err
+		}
+
 		return Tokens{}, domain.ErrStudentBlocked
 	}
 
-	return s.createSession(ctx, student.ID)
+	return tokens, s.repo.SetLastVisit(ctx, student.ID)
 }
 
 func (s *StudentsService) Verify(ctx context.Context, hash string) error {
 	student, err := s.repo.Verify(ctx, hash)
 	if err != nil {
-		if errors.Is(err, domain.ErrVerificationCodeInvalid) {
-			return domain.ErrVerificationCodeInvalid
-		}
-
 		return err
 	}
 
@@ -141,6 +229,93 @@ func (s *StudentsService) Verify(ctx context.Context, hash string) error {
 	return nil
 }
 
+// ResendVerification issues a new verification code. Unknown and already verified emails are ignored
+// and the code sent recently isn't resent silently to not reveal whether account exists.
+func (s *StudentsService) ResendVerification(ctx context.Context, input ResendVerificationInput) error {
+	student, err := s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if student.Verification.Verified {
+		return nil
+	}
+
+	err = s.resendVerification(ctx, student, input.SchoolDomain)
+	if errors.Is(err, domain.ErrVerificationSentRecently) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *StudentsService) ResendVerificationById(ctx context.Context, schoolId, studentId primitive.ObjectID, schoolDomain string) error {
+	student, err := s.repo.GetById(ctx, schoolId, studentId)
+	if err != nil {
+		return err
+	}
+
+	if student.Verification.Verified {
+		return domain.ErrAlreadyVerified
+	}
+
+	return s.resendVerification(ctx, student, schoolDomain)
+}
+
+func (s *StudentsService) resendVerification(ctx context.Context, student domain.Student, schoolDomain string) error {
+	student.Verification = newVerification(s.otpGenerator, s.verificationCodeLength, s.verificationCodeTTL)
+
+	if err := s.repo.SetVerificationCode(ctx, student.ID, toSetVerificationCodeInput(student.Verification)); err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(student, schoolDomain)
+}
+
+func (s *StudentsService) sendVerificationEmail(student domain.Student, schoolDomain string) error {
+	return s.emailService.SendStudentVerificationEmail(VerificationEmailInput{
+		Email:            student.Email,
+		Name:             student.Name,
+		VerificationCode: student.Verification.Code,
+		Domain:           schoolDomain,
+	})
+}
+
+func (s *StudentsService) RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error {
+	student, err := s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+	if err != nil {
+		// don't reveal whether account with such email exists
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	token, err := s.passwordResetsService.CreateToken(ctx, student.ID, domain.RoleStudent, input.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendPasswordResetEmail(PasswordResetEmailInput{
+		Email:  student.Email,
+		Name:   student.Name,
+		Token:  token,
+		Domain: input.SchoolDomain,
+	})
+}
+
+func (s *StudentsService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	return resetPassword(ctx, s.hasher, s.passwordResetsService, s.sessionsService, domain.RoleStudent, input,
+		func(studentId primitive.ObjectID, passwordHash string) error {
+			return s.repo.SetPassword(ctx, studentId, passwordHash)
+		})
+}
+
 func (s *StudentsService) GetModuleContent(ctx context.Context, schoolId, studentId, moduleId primitive.ObjectID) (domain.ModuleContent, error) {
 	// Get module with lessons content, check if it is available for student
 	module, err := s.modulesService.GetWithContent(ctx, moduleId)
@@ -153,36 +328,89 @@ func (s *StudentsService) GetModuleContent(ctx context.Context, schoolId, studen
 		return domain.ModuleContent{}, err
 	}
 
-	if student.IsModuleAvailable(module) {
-		return domain.ModuleContent{
-			Lessons: module.Lessons,
-			Survey:  module.Survey,
-		}, nil
+	for i := range module.Lessons {
+		module.Lessons[i].Blocks = domain.WithoutAnswers(module.Lessons[i].Blocks)
 	}
 
-	// Find module offers
-	offers, err := s.offersService.GetByModule(ctx, schoolId, module.ID)
+	if !student.IsModuleAvailable(module) {
+		// Find module offers
+		offers, err := s.offersService.GetByModule(ctx, schoolId, module.ID)
+		if err != nil {
+			return domain.ModuleContent{}, err
+		}
+
+		if len(offers) != 0 {
+			return domain.ModuleContent{}, domain.ErrModuleIsNotAvailable
+		}
+
+		// If module has no offers - it's free and available to everyone
+		if err := s.repo.GiveAccessToModule(ctx, studentId, moduleId); err != nil {
+			return domain.ModuleContent{}, err
+		}
+	}
+
+	return s.lockContent(ctx, student, module)
+}
+
+// lockContent hides module survey and lessons content, which are not available for student yet
+// because of schedule or missing prerequisites.
+func (s *StudentsService) lockContent(ctx context.Context, student domain.Student, module domain.Module) (domain.ModuleContent, error) {
+	accessStartedAt, err := s.accessStartedAt(ctx, student, module)
 	if err != nil {
 		return domain.ModuleContent{}, err
 	}
 
-	if len(offers) != 0 {
-		return domain.ModuleContent{}, domain.ErrModuleIsNotAvailable
+	now := time.Now()
+	moduleUnlocksAt := module.UnlocksAt(accessStartedAt)
+
+	content := domain.ModuleContent{
+		Lessons: module.Lessons,
+		Survey:  module.Survey,
 	}
 
-	// If module has no offers - it's free and available to everyone
-	if err := s.repo.GiveAccessToModule(ctx, studentId, moduleId); err != nil {
+	if moduleUnlocksAt.After(now) {
+		content.AvailableAt = &moduleUnlocksAt
+		content.Survey = domain.Survey{}
+	}
+
+	prerequisites, err := s.gatingService.GetPrerequisites(ctx, student.ID, module)
+	if err != nil {
 		return domain.ModuleContent{}, err
 	}
 
-	return domain.ModuleContent{
-		Lessons: module.Lessons,
-		Survey:  module.Survey,
-	}, nil
+	for i := range content.Lessons {
+		unlocksAt := content.Lessons[i].UnlocksAt(moduleUnlocksAt, accessStartedAt)
+		if unlocksAt.After(now) {
+			content.Lessons[i].AvailableAt = &unlocksAt
+			content.Lessons[i].Blocks = nil
+		}
+
+		if prerequisite, ok := prerequisites[content.Lessons[i].ID]; ok {
+			content.Lessons[i].Prerequisite = &prerequisite
+			content.Lessons[i].Blocks = nil
+		}
+	}
+
+	return content, nil
+}
+
+// accessStartedAt returns start of drip schedule, offers are fetched only when module has drip content.
+func (s *StudentsService) accessStartedAt(ctx context.Context, student domain.Student, module domain.Module) (time.Time, error) {
+	if !module.HasDrip() {
+		return student.RegisteredAt, nil
+	}
+
+	offers, err := s.offersService.GetByModule(ctx, module.SchoolID, module.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return student.AccessStartedAt(offers), nil
 }
 
 func (s *StudentsService) GetLesson(ctx context.Context, studentId, lessonId primitive.ObjectID) (domain.Lesson, error) {
-	if err := s.isLessonAvailable(ctx, studentId, lessonId); err != nil {
+	module, err := s.isLessonAvailable(ctx, studentId, lessonId)
+	if err != nil {
 		return domain.Lesson{}, err
 	}
 
@@ -191,40 +419,62 @@ func (s *StudentsService) GetLesson(ctx context.Context, studentId, lessonId pri
 		return domain.Lesson{}, err
 	}
 
-	if err := s.studentLessonsService.SetLastOpened(ctx, studentId, lessonId); err != nil {
+	if err := s.studentLessonsService.SetLastOpened(ctx, studentId, lessonId, module); err != nil {
 		return domain.Lesson{}, err
 	}
 
+	lesson.Blocks = domain.WithoutAnswers(lesson.Blocks)
+
 	return lesson, nil
 }
 
 func (s *StudentsService) SetLessonFinished(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
-	if err := s.isLessonAvailable(ctx, studentId, lessonId); err != nil {
+	module, err := s.isLessonAvailable(ctx, studentId, lessonId)
+	if err != nil {
 		return err
 	}
 
-	return s.studentLessonsService.AddFinished(ctx, studentId, lessonId)
+	return s.studentLessonsService.AddFinished(ctx, studentId, lessonId, module)
 }
 
-func (s *StudentsService) GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error {
-	modules, err := s.modulesService.GetByPackages(ctx, offer.PackageIDs)
+// GiveAccessToOffer opens offer modules to the student and records it in the activity log.
+// adminId is empty, when access is granted by purchase or by request authenticated with API key.
+func (s *StudentsService) GiveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer,
+	adminId primitive.ObjectID) error {
+	moduleIds, err := s.offerModuleIds(ctx, offer)
 	if err != nil {
 		return err
 	}
 
-	moduleIds := make([]primitive.ObjectID, len(modules))
+	if err := s.repo.AttachOffer(ctx, studentId, offer.ID, moduleIds); err != nil {
+		return err
+	}
 
-	for i := range modules {
-		moduleIds[i] = modules[i].ID
+	s.logOfferAccess(ctx, domain.StudentActivityAccessGranted, studentId, offer, adminId)
+
+	return nil
+}
+
+func (s *StudentsService) RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer,
+	adminId primitive.ObjectID) error {
+	moduleIds, err := s.offerModuleIds(ctx, offer)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DetachOffer(ctx, studentId, offer.ID, moduleIds); err != nil {
+		return err
 	}
 
-	return s.repo.AttachOffer(ctx, studentId, offer.ID, moduleIds)
+	s.logOfferAccess(ctx, domain.StudentActivityAccessRevoked, studentId, offer, adminId)
+
+	return nil
 }
 
-func (s *StudentsService) RemoveAccessToOffer(ctx context.Context, studentId primitive.ObjectID, offer domain.Offer) error {
+func (s *StudentsService) offerModuleIds(ctx context.Context, offer domain.Offer) ([]primitive.ObjectID, error) {
 	modules, err := s.modulesService.GetByPackages(ctx, offer.PackageIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	moduleIds := make([]primitive.ObjectID, len(modules))
@@ -233,7 +483,18 @@ func (s *StudentsService) RemoveAccessToOffer(ctx context.Context, studentId pri
 		moduleIds[i] = modules[i].ID
 	}
 
-	return s.repo.DetachOffer(ctx, studentId, offer.ID, moduleIds)
+	return moduleIds, nil
+}
+
+func (s *StudentsService) logOfferAccess(ctx context.Context, activityType string, studentId primitive.ObjectID,
+	offer domain.Offer, adminId primitive.ObjectID) {
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  offer.SchoolID,
+		StudentID: studentId,
+		Type:      activityType,
+		OfferID:   offer.ID,
+		AdminID:   adminId,
+	})
 }
 
 func (s *StudentsService) GetById(ctx context.Context, schoolId, id primitive.ObjectID) (domain.Student, error) {
@@ -241,51 +502,57 @@ func (s *StudentsService) GetById(ctx context.Context, schoolId, id primitive.Ob
 }
 
 func (s *StudentsService) GetBySchool(ctx context.Context, schoolId primitive.ObjectID, query domain.GetStudentsQuery) ([]domain.Student, int64, error) {
+	query, err := prepareStudentsQuery(ctx, s.segmentsRepo, schoolId, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	return s.repo.GetBySchool(ctx, schoolId, query)
 }
 
-func (s *StudentsService) createSession(ctx context.Context, studentId primitive.ObjectID) (Tokens, error) {
-	var (
-		res Tokens
-		err error
-	)
-
-	res.AccessToken, err = s.tokenManager.NewJWT(studentId.Hex(), s.accessTokenTTL)
+// isLessonAvailable returns module of the lesson, when it's available for the student.
+func (s *StudentsService) isLessonAvailable(ctx context.Context, studentId, lessonId primitive.ObjectID) (domain.Module, error) {
+	module, err := s.modulesService.GetByLesson(ctx, lessonId)
 	if err != nil {
-		return res, err
+		return domain.Module{}, err
 	}
 
-	res.RefreshToken, err = s.tokenManager.NewRefreshToken()
+	student, err := s.GetById(ctx, module.SchoolID, studentId)
 	if err != nil {
-		return res, err
+		return domain.Module{}, err
 	}
 
-	session := domain.Session{
-		RefreshToken: res.RefreshToken,
-		ExpiresAt:    time.Now().Add(s.refreshTokenTTL),
+	if !student.IsModuleAvailable(module) {
+		return domain.Module{}, domain.ErrModuleIsNotAvailable
 	}
 
-	err = s.repo.SetSession(ctx, studentId, session)
+	accessStartedAt, err := s.accessStartedAt(ctx, student, module)
+	if err != nil {
+		return domain.Module{}, err
+	}
 
-	return res, err
-}
+	moduleUnlocksAt := module.UnlocksAt(accessStartedAt)
 
-func (s *StudentsService) isLessonAvailable(ctx context.Context, studentId, lessonId primitive.ObjectID) error {
-	module, err := s.modulesService.GetByLesson(ctx, lessonId)
-	if err != nil {
-		return err
+	for _, lesson := range module.Lessons {
+		if lesson.ID != lessonId {
+			continue
+		}
+
+		if unlocksAt := lesson.UnlocksAt(moduleUnlocksAt, accessStartedAt); unlocksAt.After(time.Now()) {
+			return domain.Module{}, domain.ContentLockedError{AvailableAt: unlocksAt}
+		}
 	}
 
-	student, err := s.GetById(ctx, module.SchoolID, studentId)
+	prerequisites, err := s.gatingService.GetPrerequisites(ctx, studentId, module)
 	if err != nil {
-		return err
+		return domain.Module{}, err
 	}
 
-	if !student.IsModuleAvailable(module) {
-		return domain.ErrModuleIsNotAvailable
+	if prerequisite, ok := prerequisites[lessonId]; ok {
+		return domain.Module{}, domain.PrerequisiteError{Prerequisite: prerequisite}
 	}
 
-	return nil
+	return module, nil
 }
 
 // TODO refactor.
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const emailChangeTokenLength = 32
+
+func (s *StudentsService) UpdateAccount(ctx context.Context, input UpdateStudentAccountInput) error {
+	return s.repo.Update(ctx, domain.UpdateStudentInput{
+		Name:      input.Name,
+		StudentID: input.StudentID,
+		SchoolID:  input.SchoolID,
+	})
+}
+
+// RequestConfirmationCode emails one-time code to the current address of the student without password,
+// the code confirms email change, password change or account deletion instead of the password.
+func (s *StudentsService) RequestConfirmationCode(ctx context.Context, input StudentRequestConfirmationCodeInput) error {
+	student, err := s.repo.GetById(ctx, input.SchoolID, input.StudentID)
+	if err != nil {
+		return err
+	}
+
+	if student.Password != "" {
+		return domain.ErrConfirmationCodeNotNeeded
+	}
+
+	code := s.otpGenerator.RandomSecret(s.verificationCodeLength)
+
+	if err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeReauthentication,
+		Hash:      auth.HashToken(code),
+		OwnerID:   student.ID,
+		Role:      domain.RoleStudent,
+		SchoolID:  input.SchoolID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(s.verificationCodeTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendConfirmationCodeEmail(ConfirmationCodeEmailInput{
+		Email: student.Email,
+		Name:  student.Name,
+		Code:  code,
+	})
+}
+
+// RequestEmailChange sends confirmation link to the new email, current email stays in use until it's confirmed.
+func (s *StudentsService) RequestEmailChange(ctx context.Context, input StudentChangeEmailInput) error {
+	student, err := s.repo.GetById(ctx, input.SchoolID, input.StudentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reauthenticate(ctx, student, input.Password, input.Code); err != nil {
+		return err
+	}
+
+	if err := s.checkEmailIsFree(ctx, input.SchoolID, input.Email); err != nil {
+		return err
+	}
+
+	token := s.otpGenerator.RandomSecret(emailChangeTokenLength)
+
+	if err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeEmailChange,
+		Hash:      auth.HashToken(token),
+		OwnerID:   student.ID,
+		Role:      domain.RoleStudent,
+		SchoolID:  input.SchoolID,
+		Email:     input.Email,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(s.verificationCodeTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendEmailChangeEmail(EmailChangeEmailInput{
+		Email:  input.Email,
+		Name:   student.Name,
+		Token:  token,
+		Domain: input.SchoolDomain,
+	})
+}
+
+// ConfirmEmailChange sets the new email, it's verified because confirmation link was received there.
+func (s *StudentsService) ConfirmEmailChange(ctx context.Context, input StudentConfirmEmailChangeInput) error {
+	token, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(input.Token),
+		Purpose:  domain.TokenPurposeEmailChange,
+		Role:     domain.RoleStudent,
+		SchoolID: input.SchoolID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// email could be taken while the link was waiting in the inbox
+	if err := s.checkEmailIsFree(ctx, input.SchoolID, token.Email); err != nil {
+		return err
+	}
+
+	verified := true
+
+	return s.repo.Update(ctx, domain.UpdateStudentInput{
+		Email:     token.Email,
+		Verified:  &verified,
+		StudentID: token.OwnerID,
+		SchoolID:  input.SchoolID,
+	})
+}
+
+// ChangePassword sets new password and revokes all sessions, new session is created for the current device.
+func (s *StudentsService) ChangePassword(ctx context.Context, input StudentChangePasswordInput) (Tokens, error) {
+	student, err := s.repo.GetById(ctx, input.SchoolID, input.StudentID)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if err := s.reauthenticate(ctx, student, input.CurrentPassword, input.Code); err != nil {
+		return Tokens{}, err
+	}
+
+	passwordHash, err := s.hasher.Hash(input.NewPassword)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if err := s.repo.SetPassword(ctx, student.ID, passwordHash); err != nil {
+		return Tokens{}, err
+	}
+
+	if err := s.sessionsService.RevokeAll(ctx, student.ID); err != nil {
+		return Tokens{}, err
+	}
+
+	return s.createSession(ctx, student, input.SchoolDomain, input.Device)
+}
+
+// DeleteAccount removes student with the data exports and erases personal data from orders and survey results,
+// which are kept for the school's reports.
+func (s *StudentsService) DeleteAccount(ctx context.Context, input StudentDeleteAccountInput) error {
+	student, err := s.repo.GetById(ctx, input.SchoolID, input.StudentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reauthenticate(ctx, student, input.Password, input.Code); err != nil {
+		return err
+	}
+
+	if err := s.ordersRepo.AnonymizeStudent(ctx, student.ID); err != nil {
+		return err
+	}
+
+	if err := s.surveyResultsRepo.AnonymizeStudent(ctx, student.ID); err != nil {
+		return err
+	}
+
+	if err := s.activitiesService.DeleteByStudent(ctx, student.ID); err != nil {
+		return err
+	}
+
+	if err := s.exportsService.DeleteByStudent(ctx, input.SchoolID, student.ID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, input.SchoolID, student.ID); err != nil {
+		return err
+	}
+
+	return s.sessionsService.RevokeAll(ctx, student.ID)
+}
+
+// reauthenticate confirms sensitive changes with the current password.
+// Students signed up with OIDC or magic link may have no password, they confirm changes with emailed code.
+// Failures are counted together with sign in failures, so a stolen session can't be used to guess the password.
+func (s *StudentsService) reauthenticate(ctx context.Context, student domain.Student, password, code string) error {
+	invalidErr := domain.ErrPasswordInvalid
+	if student.Password == "" {
+		if code == "" {
+			return domain.ErrConfirmationCodeInvalid
+		}
+
+		invalidErr = domain.ErrConfirmationCodeInvalid
+	}
+
+	attemptsKey := SignInAttemptsKey{Role: domain.RoleStudent, SchoolID: student.SchoolID, Email: student.Email}
+
+	err := withSignInAttempts(s.signInAttemptsService, attemptsKey, func() error {
+		if student.Password == "" {
+			err := s.consumeConfirmationCode(ctx, student, code)
+			if errors.Is(err, domain.ErrConfirmationCodeInvalid) {
+				return domain.ErrUserNotFound
+			}
+
+			return err
+		}
+
+		return verifyPassword(s.hasher, password, student.Password, func(passwordHash string) error {
+			return s.repo.SetPassword(ctx, student.ID, passwordHash)
+		})
+	}, nil)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return invalidErr
+	}
+
+	return err
+}
+
+func (s *StudentsService) consumeConfirmationCode(ctx context.Context, student domain.Student, code string) error {
+	_, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(code),
+		Purpose:  domain.TokenPurposeReauthentication,
+		Role:     domain.RoleStudent,
+		SchoolID: student.SchoolID,
+		OwnerID:  student.ID,
+	})
+	if errors.Is(err, domain.ErrOneTimeTokenInvalid) {
+		return domain.ErrConfirmationCodeInvalid
+	}
+
+	return err
+}
+
+func (s *StudentsService) checkEmailIsFree(ctx context.Context, schoolId primitive.ObjectID, email string) error {
+	_, err := s.repo.GetByEmail(ctx, schoolId, email)
+	if err == nil {
+		return domain.ErrUserAlreadyExists
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testStudentWithPassword(t *testing.T, password string) domain.Student {
+	t.Helper()
+
+	passwordHash, err := testHasher.Hash(password)
+	require.NoError(t, err)
+
+	return domain.Student{
+		ID:       primitive.NewObjectID(),
+		Name:     "Student",
+		Email:    "student@test.com",
+		Password: passwordHash,
+		SchoolID: primitive.NewObjectID(),
+	}
+}
+
+func TestStudentsService_DeleteAccount(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := testStudentWithPassword(t, "password")
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+	mocks.orders.EXPECT().AnonymizeStudent(ctx, student.ID)
+	mocks.surveyResults.EXPECT().AnonymizeStudent(ctx, student.ID)
+	mocks.activities.EXPECT().DeleteByStudent(ctx, student.ID)
+	mocks.exports.EXPECT().DeleteByStudent(ctx, student.SchoolID, student.ID)
+	mocks.students.EXPECT().Delete(ctx, student.SchoolID, student.ID)
+	mocks.sessions.EXPECT().DeleteByOwner(ctx, student.ID)
+
+	err := studentService.DeleteAccount(ctx, service.StudentDeleteAccountInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+		Password:  "password",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_DeleteAccountWrongPassword(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := testStudentWithPassword(t, "password")
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+
+	err := studentService.DeleteAccount(ctx, service.StudentDeleteAccountInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+		Password:  "wrong",
+	})
+
+	require.ErrorIs(t, err, domain.ErrPasswordInvalid)
+}
+
+func TestStudentsService_DeleteAccountLocksAfterTooManyAttempts(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := testStudentWithPassword(t, "password")
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil).
+		Times(testSignInAttemptsConfig.MaxAttempts + 1)
+
+	for i := 1; i <= testSignInAttemptsConfig.MaxAttempts; i++ {
+		// wait for backoff to pass, only lockout should reject correct password
+		time.Sleep(10 * time.Millisecond)
+
+		err := studentService.DeleteAccount(ctx, service.StudentDeleteAccountInput{
+			StudentID: student.ID,
+			SchoolID:  student.SchoolID,
+			Password:  "wrong",
+		})
+
+		if i < testSignInAttemptsConfig.MaxAttempts {
+			require.ErrorIs(t, err, domain.ErrPasswordInvalid)
+		} else {
+			require.ErrorIs(t, err, domain.ErrTooManyAttempts)
+		}
+	}
+
+	err := studentService.DeleteAccount(ctx, service.StudentDeleteAccountInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+		Password:  "password",
+	})
+
+	require.ErrorIs(t, err, domain.ErrTooManyAttempts)
+}
+
+func TestStudentsService_RequestEmailChangeEmailTaken(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := testStudentWithPassword(t, "password")
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, "taken@test.com").Return(domain.Student{}, nil)
+
+	err := studentService.RequestEmailChange(ctx, service.StudentChangeEmailInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+		Email:     "taken@test.com",
+		Password:  "password",
+	})
+
+	require.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+}
+
+func TestStudentsService_ConfirmEmailChange(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	schoolId, studentId := primitive.NewObjectID(), primitive.NewObjectID()
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{OwnerID: studentId, Email: "new@test.com"}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, schoolId, "new@test.com").Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, inp domain.UpdateStudentInput) error {
+		require.Equal(t, studentId, inp.StudentID)
+		require.Equal(t, "new@test.com", inp.Email)
+		require.True(t, *inp.Verified)
+
+		return nil
+	})
+
+	err := studentService.ConfirmEmailChange(ctx, service.StudentConfirmEmailChangeInput{
+		Token:    "token",
+		SchoolID: schoolId,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_RequestConfirmationCode(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), Name: "Student", Email: "student@test.com", SchoolID: primitive.NewObjectID()}
+
+	var code string
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+	mocks.emails.EXPECT().SendConfirmationCodeEmail(gomock.Any()).DoAndReturn(func(inp service.ConfirmationCodeEmailInput) error {
+		require.Equal(t, student.Email, inp.Email)
+
+		code = inp.Code
+
+		return nil
+	})
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token domain.OneTimeToken) error {
+		require.Equal(t, domain.TokenPurposeReauthentication, token.Purpose)
+		require.Equal(t, student.ID, token.OwnerID)
+
+		return nil
+	})
+
+	err := studentService.RequestConfirmationCode(ctx, service.StudentRequestConfirmationCodeInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+}
+
+func TestStudentsService_DeleteAccountWithoutPasswordRequiresCode(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+
+	err := studentService.DeleteAccount(ctx, service.StudentDeleteAccountInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+	})
+
+	require.ErrorIs(t, err, domain.ErrConfirmationCodeInvalid)
+}
+
+func TestStudentsService_RequestEmailChangeWithoutPasswordWrongCode(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	mocks.students.EXPECT().GetById(ctx, student.SchoolID, student.ID).Return(student, nil)
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken("wrong"),
+		Purpose:  domain.TokenPurposeReauthentication,
+		Role:     domain.RoleStudent,
+		SchoolID: student.SchoolID,
+		OwnerID:  student.ID,
+	}).Return(domain.OneTimeToken{}, domain.ErrOneTimeTokenInvalid)
+
+	err := studentService.RequestEmailChange(ctx, service.StudentChangeEmailInput{
+		StudentID: student.ID,
+		SchoolID:  student.SchoolID,
+		Email:     "new@test.com",
+		Code:      "wrong",
+	})
+
+	require.ErrorIs(t, err, domain.ErrConfirmationCodeInvalid)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+)
+
+const magicLinkTokenLength = 32
+
+// RequestMagicLink emails single-use login link. Like password reset, it doesn't reveal whether
+// the student exists, so nothing is sent to blocked students or unknown emails when registration is disabled.
+func (s *StudentsService) RequestMagicLink(ctx context.Context, input StudentRequestMagicLinkInput) error {
+	student, err := s.repo.GetByEmail(ctx, input.SchoolID, input.Email)
+
+	switch {
+	case err == nil:
+		if student.Blocked {
+			return nil
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		if input.DisableRegistration {
+			return nil
+		}
+	default:
+		return err
+	}
+
+	token := s.otpGenerator.RandomSecret(magicLinkTokenLength)
+
+	if err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeMagicLink,
+		Hash:      auth.HashToken(token),
+		OwnerID:   student.ID,
+		Role:      domain.RoleStudent,
+		SchoolID:  input.SchoolID,
+		Email:     input.Email,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(s.magicLinkTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendMagicLinkEmail(MagicLinkEmailInput{
+		Email:  input.Email,
+		Token:  token,
+		Domain: input.SchoolDomain,
+	})
+}
+
+// SignInMagicLink exchanges login link token for the same tokens as SignIn does.
+// Student is created on the first login, if registration is enabled.
+func (s *StudentsService) SignInMagicLink(ctx context.Context, input StudentMagicLinkSignInInput) (Tokens, error) {
+	token, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     auth.HashToken(input.Token),
+		Purpose:  domain.TokenPurposeMagicLink,
+		Role:     domain.RoleStudent,
+		SchoolID: input.SchoolID,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	student, err := s.getOrCreateMagicLinkStudent(ctx, token.Email, input)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if student.Blocked {
+		return Tokens{}, domain.ErrStudentBlocked
+	}
+
+	return s.signIn(ctx, student, domain.SignInMethodMagicLink, input.SchoolDomain, input.Device)
+}
+
+func (s *StudentsService) getOrCreateMagicLinkStudent(ctx context.Context, email string,
+	input StudentMagicLinkSignInInput) (domain.Student, error) {
+	student, err := s.repo.GetByEmail(ctx, input.SchoolID, email)
+	if err == nil {
+		// link was delivered to the email, so it's verified now
+		if !student.Verification.Verified {
+			if err := s.claimUnverified(ctx, &student); err != nil {
+				return domain.Student{}, err
+			}
+
+			return student, s.repo.MarkVerified(ctx, student.ID)
+		}
+
+		return student, nil
+	}
+
+	if !errors.Is(err, domain.ErrUserNotFound) || input.DisableRegistration {
+		return domain.Student{}, err
+	}
+
+	student = domain.Student{
+		Name:         email,
+		Email:        email,
+		RegisteredAt: time.Now(),
+		LastVisitAt:  time.Now(),
+		SchoolID:     input.SchoolID,
+		Verification: domain.Verification{Verified: true},
+	}
+
+	if err := s.repo.Create(ctx, &student); err != nil {
+		return domain.Student{}, err
+	}
+
+	go s.addStudentToList(context.Background(), student)
+
+	return student, nil
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentsService_RequestMagicLink(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), Email: "student@test.com", SchoolID: primitive.NewObjectID()}
+
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, student.Email).Return(student, nil)
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token domain.OneTimeToken) error {
+		require.Equal(t, domain.TokenPurposeMagicLink, token.Purpose)
+		require.Equal(t, student.ID, token.OwnerID)
+		require.Equal(t, student.Email, token.Email)
+
+		return nil
+	})
+	mocks.emails.EXPECT().SendMagicLinkEmail(gomock.Any())
+
+	err := studentService.RequestMagicLink(ctx, service.StudentRequestMagicLinkInput{
+		Email:    student.Email,
+		SchoolID: student.SchoolID,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_RequestMagicLinkSilentlyIgnored(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+
+	mocks.students.EXPECT().GetByEmail(ctx, schoolId, "blocked@test.com").Return(domain.Student{Blocked: true}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, schoolId, "unknown@test.com").Return(domain.Student{}, domain.ErrUserNotFound)
+
+	err := studentService.RequestMagicLink(ctx, service.StudentRequestMagicLinkInput{
+		Email:    "blocked@test.com",
+		SchoolID: schoolId,
+	})
+	require.NoError(t, err)
+
+	err = studentService.RequestMagicLink(ctx, service.StudentRequestMagicLinkInput{
+		Email:               "unknown@test.com",
+		SchoolID:            schoolId,
+		DisableRegistration: true,
+	})
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInMagicLinkVerifiesStudent(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), Email: "student@test.com", SchoolID: primitive.NewObjectID()}
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{Email: student.Email}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, student.Email).Return(student, nil)
+	mocks.students.EXPECT().MarkVerified(ctx, student.ID)
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+	mocks.students.EXPECT().SetLastVisit(ctx, student.ID)
+
+	_, err := studentService.SignInMagicLink(ctx, service.StudentMagicLinkSignInInput{
+		Token:    "token",
+		SchoolID: student.SchoolID,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInMagicLinkClearsPasswordOfUnverified(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), Email: "student@test.com", SchoolID: primitive.NewObjectID(), Password: "hash"}
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{Email: student.Email}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, student.Email).Return(student, nil)
+	mocks.students.EXPECT().SetPassword(ctx, student.ID, "")
+	mocks.sessions.EXPECT().DeleteByOwner(ctx, student.ID)
+	mocks.students.EXPECT().MarkVerified(ctx, student.ID)
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+	mocks.students.EXPECT().SetLastVisit(ctx, student.ID)
+
+	_, err := studentService.SignInMagicLink(ctx, service.StudentMagicLinkSignInInput{
+		Token:    "token",
+		SchoolID: student.SchoolID,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInMagicLinkBlocked(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{
+		ID:           primitive.NewObjectID(),
+		Email:        "student@test.com",
+		SchoolID:     primitive.NewObjectID(),
+		Verification: domain.Verification{Verified: true},
+		Blocked:      true,
+	}
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{Email: student.Email}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, student.Email).Return(student, nil)
+
+	_, err := studentService.SignInMagicLink(ctx, service.StudentMagicLinkSignInInput{
+		Token:    "token",
+		SchoolID: student.SchoolID,
+	})
+
+	require.True(t, errors.Is(err, domain.ErrStudentBlocked))
+}
+
+func TestStudentsService_SignInMagicLinkRegistrationDisabled(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	schoolId := primitive.NewObjectID()
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{Email: "new@test.com"}, nil)
+	mocks.students.EXPECT().GetByEmail(ctx, schoolId, "new@test.com").Return(domain.Student{}, domain.ErrUserNotFound)
+
+	_, err := studentService.SignInMagicLink(ctx, service.StudentMagicLinkSignInInput{
+		Token:               "token",
+		SchoolID:            schoolId,
+		DisableRegistration: true,
+	})
+
+	require.True(t, errors.Is(err, domain.ErrUserNotFound))
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
+)
+
+const (
+	oidcStateLength = 32
+	oidcNonceLength = 32
+	oidcStateTTL    = 10 * time.Minute
+
+	oidcRedirectURITmpl = "https://%s/oidc/%s/callback" // https://<school host>/oidc/<provider>/callback
+)
+
+// GetOIDCAuthURL returns provider's consent page URL. State is stored as one-time token together with the nonce,
+// so the callback can't be forged, replayed or finished in the other browser.
+func (s *StudentsService) GetOIDCAuthURL(ctx context.Context, input StudentOIDCAuthURLInput) (OIDCAuthURL, error) {
+	endpoints, err := s.oidcEndpoints(ctx, input.Provider)
+	if err != nil {
+		return OIDCAuthURL{}, err
+	}
+
+	state := s.otpGenerator.RandomSecret(oidcStateLength)
+	nonce := s.otpGenerator.RandomSecret(oidcNonceLength)
+
+	if err := s.oneTimeTokensRepo.Create(ctx, domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeOIDCState,
+		Hash:      oidcStateHash(state, nonce),
+		Role:      domain.RoleStudent,
+		SchoolID:  input.SchoolID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(oidcStateTTL),
+	}); err != nil {
+		return OIDCAuthURL{}, err
+	}
+
+	redirectURI := fmt.Sprintf(oidcRedirectURITmpl, input.SchoolDomain, input.Provider.Name)
+
+	return OIDCAuthURL{
+		URL:   oidc.AuthCodeURL(endpoints.AuthURL, input.Provider.ClientID, redirectURI, state),
+		Nonce: nonce,
+	}, nil
+}
+
+// SignInOIDC finishes authorization code flow. Student is found by provider's account, then by email,
+// otherwise a new verified student is created.
+func (s *StudentsService) SignInOIDC(ctx context.Context, input StudentOIDCSignInInput) (Tokens, error) {
+	if _, err := s.oneTimeTokensRepo.Consume(ctx, repository.ConsumeOneTimeTokenInput{
+		Hash:     oidcStateHash(input.State, input.Nonce),
+		Purpose:  domain.TokenPurposeOIDCState,
+		Role:     domain.RoleStudent,
+		SchoolID: input.SchoolID,
+	}); err != nil {
+		return Tokens{}, err
+	}
+
+	endpoints, err := s.oidcEndpoints(ctx, input.Provider)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	accessToken, err := s.oidcProvider.Exchange(ctx, oidc.ExchangeInput{
+		TokenURL:     endpoints.TokenURL,
+		ClientID:     input.Provider.ClientID,
+		ClientSecret: input.Provider.ClientSecret,
+		Code:         input.Code,
+		RedirectURI:  fmt.Sprintf(oidcRedirectURITmpl, input.SchoolDomain, input.Provider.Name),
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	info, err := s.oidcProvider.UserInfo(ctx, endpoints.UserInfoURL, accessToken)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	student, err := s.getOrCreateOIDCStudent(ctx, input, info)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if student.Blocked {
+		return Tokens{}, domain.ErrStudentBlocked
+	}
+
+	return s.signIn(ctx, student, domain.SignInMethodOIDC, input.SchoolDomain, input.Device)
+}
+
+func (s *StudentsService) getOrCreateOIDCStudent(ctx context.Context, input StudentOIDCSignInInput,
+	info oidc.UserInfo) (domain.Student, error) {
+	identity := domain.StudentIdentity{Provider: input.Provider.Name, Subject: info.Subject}
+
+	student, err := s.repo.GetByIdentity(ctx, input.SchoolID, identity)
+	if err == nil || !errors.Is(err, domain.ErrUserNotFound) {
+		return student, err
+	}
+
+	// email is used to link accounts, so it must be confirmed by provider
+	if info.Email == "" || !info.EmailVerified {
+		return domain.Student{}, domain.ErrOIDCEmailNotVerified
+	}
+
+	student, err = s.repo.GetByEmail(ctx, input.SchoolID, info.Email)
+	if err == nil {
+		if err := s.claimUnverified(ctx, &student); err != nil {
+			return domain.Student{}, err
+		}
+
+		return student, s.repo.LinkIdentity(ctx, student.ID, identity)
+	}
+
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return domain.Student{}, err
+	}
+
+	if input.DisableRegistration {
+		return domain.Student{}, domain.ErrRegistrationDisabled
+	}
+
+	name := info.Name
+	if name == "" {
+		name = info.Email
+	}
+
+	student = domain.Student{
+		Name:         name,
+		Email:        info.Email,
+		RegisteredAt: time.Now(),
+		LastVisitAt:  time.Now(),
+		SchoolID:     input.SchoolID,
+		Verification: domain.Verification{Verified: true},
+		Identities:   []domain.StudentIdentity{identity},
+	}
+
+	if err := s.repo.Create(ctx, &student); err != nil {
+		return domain.Student{}, err
+	}
+
+	go s.addStudentToList(context.Background(), student)
+
+	return student, nil
+}
+
+// claimUnverified is called when email owner has proven it before verifying the account.
+// Unverified account could be registered by someone else with the owner's email,
+// so its password is cleared and sessions are revoked.
+func (s *StudentsService) claimUnverified(ctx context.Context, student *domain.Student) error {
+	if student.Verification.Verified || student.Password == "" {
+		return nil
+	}
+
+	if err := s.repo.SetPassword(ctx, student.ID, ""); err != nil {
+		return err
+	}
+
+	student.Password = ""
+
+	return s.sessionsService.RevokeAll(ctx, student.ID)
+}
+
+// oidcEndpoints discovers provider's endpoints, explicitly configured ones take precedence.
+func (s *StudentsService) oidcEndpoints(ctx context.Context, provider domain.OIDCProvider) (oidc.Endpoints, error) {
+	endpoints := oidc.Endpoints{
+		AuthURL:     provider.AuthURL,
+		TokenURL:    provider.TokenURL,
+		UserInfoURL: provider.UserInfoURL,
+	}
+
+	if endpoints.AuthURL != "" && endpoints.TokenURL != "" && endpoints.UserInfoURL != "" {
+		return endpoints, nil
+	}
+
+	discovered, err := s.oidcProvider.Discover(ctx, provider.Issuer)
+	if err != nil {
+		return oidc.Endpoints{}, err
+	}
+
+	if endpoints.AuthURL == "" {
+		endpoints.AuthURL = discovered.AuthURL
+	}
+
+	if endpoints.TokenURL == "" {
+		endpoints.TokenURL = discovered.TokenURL
+	}
+
+	if endpoints.UserInfoURL == "" {
+		endpoints.UserInfoURL = discovered.UserInfoURL
+	}
+
+	return endpoints, nil
+}
+
+// oidcStateHash binds state to the nonce, which is known only to the browser started sign in.
+func oidcStateHash(state, nonce string) string {
+	return auth.HashToken(state + "." + nonce)
+}
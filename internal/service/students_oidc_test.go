@@ -0,0 +1,287 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type studentServiceMocks struct {
+	students      *mock_repository.MockStudents
+	sessions      *mock_repository.MockSessions
+	oneTimeTokens *mock_repository.MockOneTimeTokens
+	orders        *mock_repository.MockOrders
+	surveyResults *mock_repository.MockSurveyResults
+	segments      *mock_repository.MockStudentSegments
+	modules       *mock_service.MockModules
+	offers        *mock_service.MockOffers
+	emails        *mock_service.MockEmails
+	activities    *mock_service.MockStudentActivities
+	exports       *mock_service.MockStudentExports
+	gating        *mock_service.MockLessonGating
+	oidcProvider  *oidc.MockProvider
+}
+
+func mockStudentService(t *testing.T) (*service.StudentsService, studentServiceMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := studentServiceMocks{
+		students:      mock_repository.NewMockStudents(mockCtl),
+		sessions:      mock_repository.NewMockSessions(mockCtl),
+		oneTimeTokens: mock_repository.NewMockOneTimeTokens(mockCtl),
+		orders:        mock_repository.NewMockOrders(mockCtl),
+		surveyResults: mock_repository.NewMockSurveyResults(mockCtl),
+		segments:      mock_repository.NewMockStudentSegments(mockCtl),
+		modules:       mock_service.NewMockModules(mockCtl),
+		offers:        mock_service.NewMockOffers(mockCtl),
+		emails:        mock_service.NewMockEmails(mockCtl),
+		activities:    mock_service.NewMockStudentActivities(mockCtl),
+		exports:       mock_service.NewMockStudentExports(mockCtl),
+		gating:        mock_service.NewMockLessonGating(mockCtl),
+		oidcProvider:  new(oidc.MockProvider),
+	}
+
+	// sign-ins are logged on every successful authentication, access changes are checked by tests
+	mocks.activities.EXPECT().Log(gomock.Any(), gomock.Not(activityOfType{
+		domain.StudentActivityAccessGranted, domain.StudentActivityAccessRevoked,
+	})).AnyTimes()
+
+	otpGenerator := otp.NewGOTPGenerator()
+
+	studentService := service.NewStudentsService(
+		mocks.students,
+		mocks.oneTimeTokens,
+		mocks.orders,
+		mocks.surveyResults,
+		mocks.segments,
+		mocks.modules,
+		mocks.offers,
+		mock_service.NewMockLessons(mockCtl),
+		testHasher,
+		service.NewSessionsService(mocks.sessions, &auth.Manager{}, 1*time.Minute, 1*time.Minute),
+		service.NewPasswordResetsService(mocks.oneTimeTokens, otpGenerator, 1*time.Minute),
+		service.NewSignInAttemptsService(cache.NewMemoryCache(), testSignInAttemptsConfig),
+		mocks.emails,
+		mock_service.NewMockStudentLessons(mockCtl),
+		mocks.activities,
+		mocks.exports,
+		mocks.gating,
+		otpGenerator,
+		mocks.oidcProvider,
+		8,
+		time.Hour,
+		time.Minute,
+	)
+
+	return studentService, mocks
+}
+
+func oidcSignInInput() service.StudentOIDCSignInInput {
+	return service.StudentOIDCSignInInput{
+		Provider: domain.OIDCProvider{
+			Name:         "test",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			AuthURL:      "https://oidc.test/authorize",
+			TokenURL:     "https://oidc.test/token",
+			UserInfoURL:  "https://oidc.test/userinfo",
+		},
+		Code:         "code",
+		State:        "state",
+		Nonce:        "nonce",
+		SchoolID:     primitive.NewObjectID(),
+		SchoolDomain: "school.creatly.me",
+	}
+}
+
+func mockOIDCCodeFlow(ctx context.Context, mocks studentServiceMocks, info oidc.UserInfo) {
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{}, nil)
+	mocks.oidcProvider.On("Exchange", ctx, oidc.ExchangeInput{
+		TokenURL:     "https://oidc.test/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Code:         "code",
+		RedirectURI:  "https://school.creatly.me/oidc/test/callback",
+	}).Return("access-token", nil)
+	mocks.oidcProvider.On("UserInfo", ctx, "https://oidc.test/userinfo", "access-token").Return(info, nil)
+}
+
+func TestStudentsService_SignInOIDCCreatesStudent(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+	info := oidc.UserInfo{Subject: "42", Email: "student@test.com", EmailVerified: true, Name: "Student"}
+
+	mockOIDCCodeFlow(ctx, mocks, info)
+	mocks.students.EXPECT().GetByIdentity(ctx, input.SchoolID, gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().GetByEmail(ctx, input.SchoolID, info.Email).Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, student *domain.Student) error {
+		require.True(t, student.Verification.Verified)
+		require.Equal(t, []domain.StudentIdentity{{Provider: "test", Subject: "42"}}, student.Identities)
+
+		student.ID = primitive.NewObjectID()
+
+		return nil
+	})
+	mocks.emails.EXPECT().AddStudentToList(gomock.Any(), info.Email, info.Name, input.SchoolID).AnyTimes()
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+	mocks.students.EXPECT().SetLastVisit(ctx, gomock.Any())
+
+	_, err := studentService.SignInOIDC(ctx, input)
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInOIDCLinksByEmail(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+	info := oidc.UserInfo{Subject: "42", Email: "student@test.com", EmailVerified: true}
+	student := domain.Student{ID: primitive.NewObjectID(), Email: info.Email, SchoolID: input.SchoolID}
+
+	mockOIDCCodeFlow(ctx, mocks, info)
+	mocks.students.EXPECT().GetByIdentity(ctx, input.SchoolID, gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().GetByEmail(ctx, input.SchoolID, info.Email).Return(student, nil)
+	mocks.students.EXPECT().LinkIdentity(ctx, student.ID, domain.StudentIdentity{Provider: "test", Subject: "42"})
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+	mocks.students.EXPECT().SetLastVisit(ctx, student.ID)
+
+	_, err := studentService.SignInOIDC(ctx, input)
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInOIDCLinksUnverifiedWithPassword(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+	info := oidc.UserInfo{Subject: "42", Email: "student@test.com", EmailVerified: true}
+	student := domain.Student{ID: primitive.NewObjectID(), Email: info.Email, SchoolID: input.SchoolID, Password: "hash"}
+
+	mockOIDCCodeFlow(ctx, mocks, info)
+	mocks.students.EXPECT().GetByIdentity(ctx, input.SchoolID, gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().GetByEmail(ctx, input.SchoolID, info.Email).Return(student, nil)
+	mocks.students.EXPECT().SetPassword(ctx, student.ID, "")
+	mocks.sessions.EXPECT().DeleteByOwner(ctx, student.ID)
+	mocks.students.EXPECT().LinkIdentity(ctx, student.ID, domain.StudentIdentity{Provider: "test", Subject: "42"})
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+	mocks.students.EXPECT().SetLastVisit(ctx, student.ID)
+
+	_, err := studentService.SignInOIDC(ctx, input)
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_SignInOIDCUnverifiedEmail(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+
+	mockOIDCCodeFlow(ctx, mocks, oidc.UserInfo{Subject: "42", Email: "student@test.com"})
+	mocks.students.EXPECT().GetByIdentity(ctx, input.SchoolID, gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+
+	_, err := studentService.SignInOIDC(ctx, input)
+
+	require.True(t, errors.Is(err, domain.ErrOIDCEmailNotVerified))
+}
+
+func TestStudentsService_SignInOIDCRegistrationDisabled(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+	input.DisableRegistration = true
+	info := oidc.UserInfo{Subject: "42", Email: "student@test.com", EmailVerified: true}
+
+	mockOIDCCodeFlow(ctx, mocks, info)
+	mocks.students.EXPECT().GetByIdentity(ctx, input.SchoolID, gomock.Any()).Return(domain.Student{}, domain.ErrUserNotFound)
+	mocks.students.EXPECT().GetByEmail(ctx, input.SchoolID, info.Email).Return(domain.Student{}, domain.ErrUserNotFound)
+
+	_, err := studentService.SignInOIDC(ctx, input)
+
+	require.ErrorIs(t, err, domain.ErrRegistrationDisabled)
+}
+
+func TestStudentsService_OIDCStateBoundToNonce(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	input := oidcSignInInput()
+
+	var stateHash string
+
+	mocks.oneTimeTokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token domain.OneTimeToken) error {
+		require.True(t, token.OwnerID.IsZero())
+
+		stateHash = token.Hash
+
+		return nil
+	})
+
+	res, err := studentService.GetOIDCAuthURL(ctx, service.StudentOIDCAuthURLInput{
+		Provider:     input.Provider,
+		SchoolID:     input.SchoolID,
+		SchoolDomain: input.SchoolDomain,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Nonce)
+
+	state := oidcURLState(t, res.URL)
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, inp repository.ConsumeOneTimeTokenInput) (domain.OneTimeToken, error) {
+			require.NotEqual(t, stateHash, inp.Hash)
+
+			return domain.OneTimeToken{}, domain.ErrOneTimeTokenInvalid
+		})
+
+	input.State = state
+	input.Nonce = "other browser"
+
+	_, err = studentService.SignInOIDC(ctx, input)
+	require.ErrorIs(t, err, domain.ErrOneTimeTokenInvalid)
+}
+
+func oidcURLState(t *testing.T, rawURL string) string {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+
+	return u.Query().Get("state")
+}
+
+func TestStudentsService_SignInOIDCInvalidState(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+
+	mocks.oneTimeTokens.EXPECT().Consume(ctx, gomock.Any()).Return(domain.OneTimeToken{}, domain.ErrOneTimeTokenInvalid)
+
+	_, err := studentService.SignInOIDC(ctx, oidcSignInInput())
+
+	require.True(t, errors.Is(err, domain.ErrOneTimeTokenInvalid))
+	mocks.oidcProvider.AssertNotCalled(t, "Exchange")
+}
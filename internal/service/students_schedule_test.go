@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentsService_GetModuleContentDrip(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	schoolId := primitive.NewObjectID()
+	offerId := primitive.NewObjectID()
+	grantedAt := time.Now().Add(-36 * time.Hour)
+	publishAt := time.Now().Add(time.Hour)
+
+	module := domain.Module{
+		ID:       primitive.NewObjectID(),
+		SchoolID: schoolId,
+		DripDays: 1,
+		Survey:   domain.Survey{Title: "survey"},
+		Lessons: []domain.Lesson{
+			{ID: primitive.NewObjectID(), Published: true, Blocks: []domain.LessonBlock{{Type: domain.LessonBlockText}}},
+			{ID: primitive.NewObjectID(), Published: true, DripDays: 3, Blocks: []domain.LessonBlock{{Type: domain.LessonBlockText}}},
+			{ID: primitive.NewObjectID(), PublishAt: &publishAt, Blocks: []domain.LessonBlock{{Type: domain.LessonBlockText}}},
+		},
+	}
+	student := domain.Student{
+		ID:               primitive.NewObjectID(),
+		RegisteredAt:     grantedAt.Add(-30 * 24 * time.Hour),
+		AvailableModules: []primitive.ObjectID{module.ID},
+		OffersAccess:     []domain.OfferAccess{{OfferID: offerId, GrantedAt: grantedAt}},
+	}
+
+	mocks.modules.EXPECT().GetWithContent(gomock.Any(), module.ID).Return(module, nil)
+	mocks.students.EXPECT().GetById(gomock.Any(), schoolId, student.ID).Return(student, nil)
+	mocks.offers.EXPECT().GetByModule(gomock.Any(), schoolId, module.ID).Return([]domain.Offer{{ID: offerId}}, nil)
+	mocks.gating.EXPECT().GetPrerequisites(gomock.Any(), student.ID, module).Return(nil, nil)
+
+	content, err := studentService.GetModuleContent(context.Background(), schoolId, student.ID, module.ID)
+	require.NoError(t, err)
+
+	require.Nil(t, content.AvailableAt)
+	require.Equal(t, "survey", content.Survey.Title)
+
+	require.Nil(t, content.Lessons[0].AvailableAt)
+	require.NotNil(t, content.Lessons[0].Blocks)
+
+	require.Equal(t, grantedAt.AddDate(0, 0, 3), *content.Lessons[1].AvailableAt)
+	require.Nil(t, content.Lessons[1].Blocks)
+
+	require.Equal(t, publishAt, *content.Lessons[2].AvailableAt)
+	require.Nil(t, content.Lessons[2].Blocks)
+}
+
+func TestStudentsService_GetModuleContentLockedModule(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	schoolId := primitive.NewObjectID()
+	publishAt := time.Now().Add(24 * time.Hour)
+
+	module := domain.Module{
+		ID:        primitive.NewObjectID(),
+		SchoolID:  schoolId,
+		PublishAt: &publishAt,
+		Survey:    domain.Survey{Title: "survey"},
+		Lessons:   []domain.Lesson{{ID: primitive.NewObjectID(), Published: true, Blocks: []domain.LessonBlock{{Type: domain.LessonBlockText}}}},
+	}
+	student := domain.Student{ID: primitive.NewObjectID(), AvailableModules: []primitive.ObjectID{module.ID}}
+
+	mocks.modules.EXPECT().GetWithContent(gomock.Any(), module.ID).Return(module, nil)
+	mocks.students.EXPECT().GetById(gomock.Any(), schoolId, student.ID).Return(student, nil)
+	mocks.gating.EXPECT().GetPrerequisites(gomock.Any(), student.ID, module).Return(nil, nil)
+
+	content, err := studentService.GetModuleContent(context.Background(), schoolId, student.ID, module.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, publishAt, *content.AvailableAt)
+	require.Empty(t, content.Survey.Title)
+	require.Equal(t, publishAt, *content.Lessons[0].AvailableAt)
+	require.Nil(t, content.Lessons[0].Blocks)
+}
+
+func TestStudentsService_SetLessonFinishedLocked(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	schoolId := primitive.NewObjectID()
+	lessonId := primitive.NewObjectID()
+	registeredAt := time.Now().Add(-time.Hour)
+
+	module := domain.Module{
+		ID:       primitive.NewObjectID(),
+		SchoolID: schoolId,
+		Lessons:  []domain.Lesson{{ID: lessonId, Published: true, DripDays: 2}},
+	}
+	student := domain.Student{ID: primitive.NewObjectID(), RegisteredAt: registeredAt, AvailableModules: []primitive.ObjectID{module.ID}}
+
+	mocks.modules.EXPECT().GetByLesson(gomock.Any(), lessonId).Return(module, nil)
+	mocks.students.EXPECT().GetById(gomock.Any(), schoolId, student.ID).Return(student, nil)
+	mocks.offers.EXPECT().GetByModule(gomock.Any(), schoolId, module.ID).Return(nil, nil)
+
+	err := studentService.SetLessonFinished(context.Background(), student.ID, lessonId)
+	require.ErrorIs(t, err, domain.ErrContentIsLocked)
+
+	var locked domain.ContentLockedError
+	require.ErrorAs(t, err, &locked)
+	require.Equal(t, registeredAt.AddDate(0, 0, 2), locked.AvailableAt)
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStudentsService_ResendVerificationSentRecently(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	student := domain.Student{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID(), Email: "student@test.com"}
+
+	mocks.students.EXPECT().GetByEmail(ctx, student.SchoolID, student.Email).Return(student, nil)
+	mocks.students.EXPECT().SetVerificationCode(ctx, student.ID, gomock.Any()).Return(domain.ErrVerificationSentRecently)
+
+	err := studentService.ResendVerification(ctx, service.ResendVerificationInput{
+		Email:    student.Email,
+		SchoolID: student.SchoolID,
+	})
+
+	require.NoError(t, err)
+}
+
+// activityOfType matches student activities of the given types.
+type activityOfType []string
+
+func (m activityOfType) Matches(x interface{}) bool {
+	activity, ok := x.(domain.StudentActivity)
+	if !ok {
+		return false
+	}
+
+	for _, activityType := range m {
+		if activity.Type == activityType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m activityOfType) String() string {
+	return fmt.Sprintf("is activity of types %v", []string(m))
+}
+
+func TestStudentsService_GiveAccessToOffer(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	studentId, adminId := primitive.NewObjectID(), primitive.NewObjectID()
+	offer := domain.Offer{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID(), PackageIDs: []primitive.ObjectID{primitive.NewObjectID()}}
+	module := domain.Module{ID: primitive.NewObjectID()}
+
+	mocks.modules.EXPECT().GetByPackages(ctx, offer.PackageIDs).Return([]domain.Module{module}, nil)
+	mocks.students.EXPECT().AttachOffer(ctx, studentId, offer.ID, []primitive.ObjectID{module.ID})
+	mocks.activities.EXPECT().Log(ctx, domain.StudentActivity{
+		SchoolID:  offer.SchoolID,
+		StudentID: studentId,
+		Type:      domain.StudentActivityAccessGranted,
+		OfferID:   offer.ID,
+		AdminID:   adminId,
+	})
+
+	err := studentService.GiveAccessToOffer(ctx, studentId, offer, adminId)
+
+	require.NoError(t, err)
+}
+
+func TestStudentsService_RemoveAccessToOfferStudentNotFound(t *testing.T) {
+	studentService, mocks := mockStudentService(t)
+
+	ctx := context.Background()
+	studentId := primitive.NewObjectID()
+	offer := domain.Offer{ID: primitive.NewObjectID(), SchoolID: primitive.NewObjectID()}
+
+	mocks.modules.EXPECT().GetByPackages(ctx, offer.PackageIDs).Return(nil, nil)
+	mocks.students.EXPECT().DetachOffer(ctx, studentId, offer.ID, []primitive.ObjectID{}).Return(domain.ErrUserNotFound)
+
+	err := studentService.RemoveAccessToOffer(ctx, studentId, offer, primitive.NewObjectID())
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
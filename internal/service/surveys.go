@@ -13,10 +13,17 @@ type SurveysService struct {
 	modulesRepo       repository.Modules
 	surveyResultsRepo repository.SurveyResults
 	studentsRepo      repository.Students
+	activitiesService StudentActivities
 }
 
-func NewSurveysService(modulesRepo repository.Modules, surveyResultsRepo repository.SurveyResults, studentsRepo repository.Students) *SurveysService {
-	return &SurveysService{modulesRepo: modulesRepo, surveyResultsRepo: surveyResultsRepo, studentsRepo: studentsRepo}
+func NewSurveysService(modulesRepo repository.Modules, surveyResultsRepo repository.SurveyResults, studentsRepo repository.Students,
+	activitiesService StudentActivities) *SurveysService {
+	return &SurveysService{
+		modulesRepo:       modulesRepo,
+		surveyResultsRepo: surveyResultsRepo,
+		studentsRepo:      studentsRepo,
+		activitiesService: activitiesService,
+	}
 }
 
 func (s *SurveysService) Create(ctx context.Context, inp CreateSurveyInput) error {
@@ -37,7 +44,7 @@ func (s *SurveysService) SaveStudentAnswers(ctx context.Context, inp SaveStudent
 		return err
 	}
 
-	return s.surveyResultsRepo.Save(ctx, domain.SurveyResult{
+	if err := s.surveyResultsRepo.Save(ctx, domain.SurveyResult{
 		Student: domain.StudentInfoShort{
 			ID:    student.ID,
 			Name:  student.Name,
@@ -46,7 +53,18 @@ func (s *SurveysService) SaveStudentAnswers(ctx context.Context, inp SaveStudent
 		ModuleID:    inp.ModuleID,
 		SubmittedAt: time.Now(),
 		Answers:     inp.Answers,
+	}); err != nil {
+		return err
+	}
+
+	s.activitiesService.Log(ctx, domain.StudentActivity{
+		SchoolID:  student.SchoolID,
+		StudentID: student.ID,
+		Type:      domain.StudentActivitySurveySubmitted,
+		ModuleID:  inp.ModuleID,
 	})
+
+	return nil
 }
 
 func (s *SurveysService) GetResultsByModule(ctx context.Context, moduleId primitive.ObjectID,
@@ -9,7 +9,6 @@ import (
 
 	"github.com/zhashkevych/creatly-backend/internal/domain"
 	"github.com/zhashkevych/creatly-backend/internal/repository"
-	"github.com/zhashkevych/creatly-backend/pkg/auth"
 	"github.com/zhashkevych/creatly-backend/pkg/dns"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
 	"github.com/zhashkevych/creatly-backend/pkg/otp"
@@ -18,34 +17,41 @@ import (
 
 type UsersService struct {
 	repo         repository.Users
+	adminsRepo   repository.Admins
 	hasher       hash.PasswordHasher
-	tokenManager auth.TokenManager
 	otpGenerator otp.Generator
 	dnsService   dns.DomainManager
 
-	emailService  Emails
-	schoolService Schools
+	emailService          Emails
+	schoolService         Schools
+	sessionsService       Sessions
+	passwordResetsService PasswordResets
+	signInAttemptsService SignInAttempts
+
+	dummyPassword *dummyPassword
 
-	accessTokenTTL         time.Duration
-	refreshTokenTTL        time.Duration
 	verificationCodeLength int
+	verificationCodeTTL    time.Duration
 
 	domain string
 }
 
-func NewUsersService(repo repository.Users, hasher hash.PasswordHasher, tokenManager auth.TokenManager,
-	emailService Emails, schoolsService Schools, dnsService dns.DomainManager, accessTTL, refreshTTL time.Duration, otpGenerator otp.Generator,
-	verificationCodeLength int, domain string) *UsersService {
+func NewUsersService(repo repository.Users, adminsRepo repository.Admins, hasher hash.PasswordHasher, sessionsService Sessions, passwordResetsService PasswordResets,
+	signInAttemptsService SignInAttempts, emailService Emails, schoolsService Schools, dnsService dns.DomainManager, otpGenerator otp.Generator,
+	verificationCodeLength int, verificationCodeTTL time.Duration, domain string) *UsersService {
 	return &UsersService{
 		repo:                   repo,
+		adminsRepo:             adminsRepo,
 		hasher:                 hasher,
+		dummyPassword:          newDummyPassword(hasher),
 		emailService:           emailService,
 		schoolService:          schoolsService,
-		tokenManager:           tokenManager,
-		accessTokenTTL:         accessTTL,
-		refreshTokenTTL:        refreshTTL,
+		sessionsService:        sessionsService,
+		passwordResetsService:  passwordResetsService,
+		signInAttemptsService:  signInAttemptsService,
 		otpGenerator:           otpGenerator,
 		verificationCodeLength: verificationCodeLength,
+		verificationCodeTTL:    verificationCodeTTL,
 		dnsService:             dnsService,
 		domain:                 domain,
 	}
@@ -57,8 +63,6 @@ func (s *UsersService) SignUp(ctx context.Context, input UserSignUpInput) error
 		return err
 	}
 
-	verificationCode := s.otpGenerator.RandomSecret(s.verificationCodeLength)
-
 	user := domain.User{
 		Name:         input.Name,
 		Password:     passwordHash,
@@ -66,9 +70,7 @@ func (s *UsersService) SignUp(ctx context.Context, input UserSignUpInput) error
 		Email:        input.Email,
 		RegisteredAt: time.Now(),
 		LastVisitAt:  time.Now(),
-		Verification: domain.Verification{
-			Code: verificationCode,
-		},
+		Verification: newVerification(s.otpGenerator, s.verificationCodeLength, s.verificationCodeTTL),
 	}
 
 	if err := s.repo.Create(ctx, user); err != nil {
@@ -81,51 +83,110 @@ func (s *UsersService) SignUp(ctx context.Context, input UserSignUpInput) error
 
 	// todo. DECIDE ON EMAIL MARKETING STRATEGY
 
-	return s.emailService.SendUserVerificationEmail(VerificationEmailInput{
-		Email:            user.Email,
-		Name:             user.Name,
-		VerificationCode: verificationCode,
-	})
+	return s.sendVerificationEmail(user)
 }
 
 func (s *UsersService) SignIn(ctx context.Context, input UserSignInInput) (Tokens, error) {
-	passwordHash, err := s.hasher.Hash(input.Password)
-	if err != nil {
-		return Tokens{}, err
-	}
+	var user domain.User
 
-	user, err := s.repo.GetByCredentials(ctx, input.Email, passwordHash)
-	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return Tokens{}, err
+	attemptsKey := SignInAttemptsKey{Role: domain.RoleUser, Email: input.Email}
+
+	if err := withSignInAttempts(s.signInAttemptsService, attemptsKey, func() error {
+		var err error
+
+		user, err = s.repo.GetByEmail(ctx, input.Email)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				s.dummyPassword.Verify(input.Password)
+			}
+
+			return err
 		}
 
+		return verifyPassword(s.hasher, input.Password, user.Password, func(passwordHash string) error {
+			return s.repo.SetPassword(ctx, user.ID, passwordHash)
+		})
+	}, nil); err != nil {
 		return Tokens{}, err
 	}
 
-	return s.createSession(ctx, user.ID)
+	return s.createSession(ctx, user.ID, input.Device)
 }
 
-func (s *UsersService) RefreshTokens(ctx context.Context, refreshToken string) (Tokens, error) {
-	student, err := s.repo.GetByRefreshToken(ctx, refreshToken)
+func (s *UsersService) RefreshTokens(ctx context.Context, input UserRefreshTokensInput) (Tokens, error) {
+	session, tokens, err := s.sessionsService.Refresh(ctx, RefreshSessionInput{
+		RefreshToken: input.RefreshToken,
+		Role:         domain.RoleUser,
+		Audience:     s.domain,
+		Device:       input.Device,
+	})
 	if err != nil {
 		return Tokens{}, err
 	}
 
-	return s.createSession(ctx, student.ID)
+	return tokens, s.repo.SetLastVisit(ctx, session.OwnerID)
 }
 
 func (s *UsersService) Verify(ctx context.Context, userID primitive.ObjectID, hash string) error {
-	err := s.repo.Verify(ctx, userID, hash)
+	return s.repo.Verify(ctx, userID, hash)
+}
+
+func (s *UsersService) ResendVerification(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.repo.GetById(ctx, userID)
 	if err != nil {
-		if errors.Is(err, domain.ErrVerificationCodeInvalid) {
-			return err
+		return err
+	}
+
+	if user.Verification.Verified {
+		return domain.ErrAlreadyVerified
+	}
+
+	user.Verification = newVerification(s.otpGenerator, s.verificationCodeLength, s.verificationCodeTTL)
+
+	if err := s.repo.SetVerificationCode(ctx, user.ID, toSetVerificationCodeInput(user.Verification)); err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(user)
+}
+
+func (s *UsersService) sendVerificationEmail(user domain.User) error {
+	return s.emailService.SendUserVerificationEmail(VerificationEmailInput{
+		Email:            user.Email,
+		Name:             user.Name,
+		VerificationCode: user.Verification.Code,
+	})
+}
+
+func (s *UsersService) RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error {
+	user, err := s.repo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		// don't reveal whether account with such email exists
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
 		}
 
 		return err
 	}
 
-	return nil
+	token, err := s.passwordResetsService.CreateToken(ctx, user.ID, domain.RoleUser, primitive.ObjectID{})
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendPasswordResetEmail(PasswordResetEmailInput{
+		Email:  user.Email,
+		Name:   user.Name,
+		Token:  token,
+		Domain: s.domain,
+	})
+}
+
+func (s *UsersService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	return resetPassword(ctx, s.hasher, s.passwordResetsService, s.sessionsService, domain.RoleUser, input,
+		func(userId primitive.ObjectID, passwordHash string) error {
+			return s.repo.SetPassword(ctx, userId, passwordHash)
+		})
 }
 
 func (s *UsersService) CreateSchool(ctx context.Context, userId primitive.ObjectID, schoolName string) (domain.School, error) {
@@ -159,30 +220,131 @@ func (s *UsersService) CreateSchool(ctx context.Context, userId primitive.Object
 	return domain.School{ID: schoolId, Settings: domain.Settings{Domains: []string{schoolDomain}}}, nil
 }
 
-func (s *UsersService) createSession(ctx context.Context, userId primitive.ObjectID) (Tokens, error) {
-	var (
-		res Tokens
-		err error
-	)
+func (s *UsersService) GetSchools(ctx context.Context, userId primitive.ObjectID) ([]domain.School, error) {
+	user, err := s.repo.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.schoolService.GetByIds(ctx, user.Schools)
+}
+
+func (s *UsersService) GetSchoolById(ctx context.Context, userId, schoolId primitive.ObjectID) (domain.School, error) {
+	if _, err := s.getSchoolOwner(ctx, userId, schoolId); err != nil {
+		return domain.School{}, err
+	}
+
+	return s.schoolService.GetById(ctx, schoolId)
+}
+
+func (s *UsersService) UpdateSchool(ctx context.Context, userId, schoolId primitive.ObjectID, input domain.UpdateSchoolSettingsInput) error {
+	if _, err := s.getSchoolOwner(ctx, userId, schoolId); err != nil {
+		return err
+	}
+
+	return s.schoolService.UpdateSettings(ctx, schoolId, input)
+}
 
-	res.AccessToken, err = s.tokenManager.NewJWT(userId.Hex(), s.accessTokenTTL)
+// ImpersonateAdmin exchanges user's token for admin Tokens of the owned school. User is signed in as an admin
+// linked to their account, which is created on first use and has no password of its own.
+func (s *UsersService) ImpersonateAdmin(ctx context.Context, input UserImpersonateAdminInput) (Tokens, error) {
+	user, err := s.getSchoolOwner(ctx, input.UserID, input.SchoolID)
 	if err != nil {
-		return res, err
+		return Tokens{}, err
 	}
 
-	res.RefreshToken, err = s.tokenManager.NewRefreshToken()
+	school, err := s.schoolService.GetById(ctx, input.SchoolID)
 	if err != nil {
-		return res, err
+		return Tokens{}, err
 	}
 
-	session := domain.Session{
-		RefreshToken: res.RefreshToken,
-		ExpiresAt:    time.Now().Add(s.refreshTokenTTL),
+	if len(school.Settings.Domains) == 0 {
+		return Tokens{}, domain.ErrSchoolHasNoDomains
+	}
+
+	admin, err := s.getOrCreateLinkedAdmin(ctx, user, school.ID)
+	if err != nil {
+		return Tokens{}, err
 	}
 
-	err = s.repo.SetSession(ctx, userId, session)
+	return s.sessionsService.Create(ctx, CreateSessionInput{
+		OwnerID:  admin.ID,
+		Role:     domain.RoleAdmin,
+		SchoolID: school.ID,
+		Audience: school.Settings.Domains[0],
+		Device:   input.Device,
+	})
+}
+
+func (s *UsersService) getSchoolOwner(ctx context.Context, userId, schoolId primitive.ObjectID) (domain.User, error) {
+	user, err := s.repo.GetById(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	for _, id := range user.Schools {
+		if id == schoolId {
+			return user, nil
+		}
+	}
+
+	return domain.User{}, domain.ErrSchoolNotFound
+}
+
+// getOrCreateLinkedAdmin returns active owner admin linked to the user. Admin, which isn't an owner anymore,
+// is unlinked and a dedicated owner admin is created instead.
+func (s *UsersService) getOrCreateLinkedAdmin(ctx context.Context, user domain.User, schoolId primitive.ObjectID) (domain.Admin, error) {
+	admin, err := s.adminsRepo.GetByUser(ctx, schoolId, user.ID)
+
+	switch {
+	case err == nil && admin.IsActiveOwner():
+		return admin, nil
+	case err == nil:
+		if err := s.adminsRepo.LinkUser(ctx, admin.ID, primitive.NilObjectID); err != nil {
+			return domain.Admin{}, err
+		}
+	case !errors.Is(err, domain.ErrUserNotFound):
+		return domain.Admin{}, err
+	}
+
+	// school may already have an owner account with owner's email
+	admin, err = s.adminsRepo.GetByEmail(ctx, schoolId, user.Email)
+	if err == nil && admin.IsActiveOwner() {
+		return admin, s.adminsRepo.LinkUser(ctx, admin.ID, user.ID)
+	}
+
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return domain.Admin{}, err
+	}
+
+	linked := domain.Admin{
+		Name:     user.Name,
+		Email:    user.Email,
+		SchoolID: schoolId,
+		UserID:   user.ID,
+		Role:     domain.AdminRoleOwner,
+	}
+
+	// email stays with the existing admin, so it could still sign in with it
+	if err == nil {
+		linked.Email = ""
+	}
+
+	return linked, s.adminsRepo.Create(ctx, &linked)
+}
+
+func (s *UsersService) createSession(ctx context.Context, userId primitive.ObjectID, device Device) (Tokens, error) {
+	tokens, err := s.sessionsService.Create(ctx, CreateSessionInput{
+		OwnerID:  userId,
+		Role:     domain.RoleUser,
+		Audience: s.domain,
+		Device:   device,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
 
-	return res, err
+	return tokens, s.repo.SetLastVisit(ctx, userId)
 }
 
 func (s *UsersService) generateSchoolDomain(subdomain string) string {
@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	mock_repository "github.com/zhashkevych/creatly-backend/internal/repository/mocks"
+	"github.com/zhashkevych/creatly-backend/internal/service"
+	mock_service "github.com/zhashkevych/creatly-backend/internal/service/mocks"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
+	"github.com/zhashkevych/creatly-backend/pkg/cache"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type usersServiceMocks struct {
+	users    *mock_repository.MockUsers
+	admins   *mock_repository.MockAdmins
+	sessions *mock_repository.MockSessions
+	schools  *mock_service.MockSchools
+}
+
+func mockUsersService(t *testing.T) (*service.UsersService, usersServiceMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := usersServiceMocks{
+		users:    mock_repository.NewMockUsers(mockCtl),
+		admins:   mock_repository.NewMockAdmins(mockCtl),
+		sessions: mock_repository.NewMockSessions(mockCtl),
+		schools:  mock_service.NewMockSchools(mockCtl),
+	}
+
+	otpGenerator := otp.NewGOTPGenerator()
+
+	usersService := service.NewUsersService(
+		mocks.users,
+		mocks.admins,
+		testHasher,
+		service.NewSessionsService(mocks.sessions, &auth.Manager{}, time.Minute, time.Minute),
+		service.NewPasswordResetsService(mock_repository.NewMockOneTimeTokens(mockCtl), otpGenerator, time.Minute),
+		service.NewSignInAttemptsService(cache.NewMemoryCache(), testSignInAttemptsConfig),
+		mock_service.NewMockEmails(mockCtl),
+		mocks.schools,
+		nil,
+		otpGenerator,
+		8,
+		time.Hour,
+		"creatly.me",
+	)
+
+	return usersService, mocks
+}
+
+func mockImpersonation(ctx context.Context, mocks usersServiceMocks) (domain.User, domain.School) {
+	school := domain.School{ID: primitive.NewObjectID(), Settings: domain.Settings{Domains: []string{"school.creatly.me"}}}
+	user := domain.User{ID: primitive.NewObjectID(), Name: "Owner", Email: "owner@test.com", Schools: []primitive.ObjectID{school.ID}}
+
+	mocks.users.EXPECT().GetById(ctx, user.ID).Return(user, nil)
+	mocks.schools.EXPECT().GetById(ctx, school.ID).Return(school, nil)
+	mocks.sessions.EXPECT().Create(ctx, gomock.Any())
+
+	return user, school
+}
+
+func TestUsersService_ImpersonateAdminLinkedOwner(t *testing.T) {
+	usersService, mocks := mockUsersService(t)
+
+	ctx := context.Background()
+	user, school := mockImpersonation(ctx, mocks)
+
+	mocks.admins.EXPECT().GetByUser(ctx, school.ID, user.ID).Return(domain.Admin{ID: primitive.NewObjectID()}, nil)
+
+	_, err := usersService.ImpersonateAdmin(ctx, service.UserImpersonateAdminInput{UserID: user.ID, SchoolID: school.ID})
+	require.NoError(t, err)
+}
+
+func TestUsersService_ImpersonateAdminSkipsNotOwner(t *testing.T) {
+	usersService, mocks := mockUsersService(t)
+
+	ctx := context.Background()
+	user, school := mockImpersonation(ctx, mocks)
+	support := domain.Admin{ID: primitive.NewObjectID(), Email: user.Email, Role: domain.AdminRoleSupport}
+
+	mocks.admins.EXPECT().GetByUser(ctx, school.ID, user.ID).Return(domain.Admin{}, domain.ErrUserNotFound)
+	mocks.admins.EXPECT().GetByEmail(ctx, school.ID, user.Email).Return(support, nil)
+	mocks.admins.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, admin *domain.Admin) error {
+		require.Equal(t, domain.AdminRoleOwner, admin.Role)
+		require.Equal(t, user.ID, admin.UserID)
+		require.Empty(t, admin.Email)
+
+		admin.ID = primitive.NewObjectID()
+
+		return nil
+	})
+
+	_, err := usersService.ImpersonateAdmin(ctx, service.UserImpersonateAdminInput{UserID: user.ID, SchoolID: school.ID})
+	require.NoError(t, err)
+}
+
+func TestUsersService_ImpersonateAdminRelinksPending(t *testing.T) {
+	usersService, mocks := mockUsersService(t)
+
+	ctx := context.Background()
+	user, school := mockImpersonation(ctx, mocks)
+	pending := domain.Admin{ID: primitive.NewObjectID(), Email: user.Email, Role: domain.AdminRoleOwner, Pending: true}
+
+	mocks.admins.EXPECT().GetByUser(ctx, school.ID, user.ID).Return(pending, nil)
+	mocks.admins.EXPECT().LinkUser(ctx, pending.ID, primitive.NilObjectID)
+	mocks.admins.EXPECT().GetByEmail(ctx, school.ID, user.Email).Return(pending, nil)
+	mocks.admins.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, admin *domain.Admin) error {
+		require.Equal(t, domain.AdminRoleOwner, admin.Role)
+		require.False(t, admin.Pending)
+
+		admin.ID = primitive.NewObjectID()
+
+		return nil
+	})
+
+	_, err := usersService.ImpersonateAdmin(ctx, service.UserImpersonateAdminInput{UserID: user.ID, SchoolID: school.ID})
+	require.NoError(t, err)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"time"
+
+	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/internal/repository"
+	"github.com/zhashkevych/creatly-backend/pkg/otp"
+)
+
+// verificationResendInterval limits how often a new verification code can be sent to the same account.
+const verificationResendInterval = time.Minute
+
+func newVerification(otpGenerator otp.Generator, codeLength int, ttl time.Duration) domain.Verification {
+	now := time.Now()
+
+	return domain.Verification{
+		Code:      otpGenerator.RandomSecret(codeLength),
+		ExpiresAt: now.Add(ttl),
+		SentAt:    now,
+	}
+}
+
+func toSetVerificationCodeInput(verification domain.Verification) repository.SetVerificationCodeInput {
+	return repository.SetVerificationCodeInput{
+		Code:       verification.Code,
+		ExpiresAt:  verification.ExpiresAt,
+		SentAt:     verification.SentAt,
+		SentBefore: verification.SentAt.Add(-verificationResendInterval),
+	}
+}
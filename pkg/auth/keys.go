@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyFileExt = ".pem"
+
+// Key is an asymmetric key pair used to sign and verify access tokens, ID is put into "kid" header.
+// Retired key (RetireAt is set) is used only to verify tokens issued before rotation until RetireAt,
+// it's a grace period, which should be not shorter than access token TTL.
+type Key struct {
+	ID         string
+	PrivateKey crypto.Signer
+	RetireAt   time.Time
+}
+
+func (k Key) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+func (k Key) signingMethod() (jwt.SigningMethod, error) {
+	switch k.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PrivateKey:
+		return SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T", k.ID, k.PrivateKey)
+	}
+}
+
+// ParsePrivateKey parses PEM encoded RSA (PKCS #1 or PKCS #8) or Ed25519 (PKCS #8) private key.
+func ParsePrivateKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("key %s: no PEM data found", id)
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return Key{}, fmt.Errorf("key %s: %w", id, err)
+		}
+
+		return Key{ID: id, PrivateKey: key}, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return Key{}, fmt.Errorf("key %s: unsupported key type %T", id, key)
+	}
+
+	k := Key{ID: id, PrivateKey: signer}
+	if _, err := k.signingMethod(); err != nil {
+		return Key{}, err
+	}
+
+	return k, nil
+}
+
+// LoadKeys reads private keys from "<kid>.pem" files of the directory.
+func LoadKeys(dir string) ([]Key, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(files))
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParsePrivateKey(strings.TrimSuffix(filepath.Base(file), keyFileExt), data)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517), so third parties could verify our tokens.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k Key) jwk() JWK {
+	switch public := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType:   "RSA",
+			KeyID:     k.ID,
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			Use:       "sig",
+			N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType:   "OKP",
+			KeyID:     k.ID,
+			Algorithm: SigningMethodEdDSA.Alg(),
+			Use:       "sig",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(public),
+		}
+	default:
+		return JWK{}
+	}
+}
+
+// SigningMethodEdDSA implements EdDSA (Ed25519) signing method (RFC 8037), which is missing in jwt-go v3.
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+var errEdDSAVerification = errors.New("eddsa: verification error")
+
+type signingMethodEdDSA struct{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return errEdDSAVerification
+	}
+
+	return nil
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	sig, err := privateKey.Sign(rand.Reader, []byte(signingString), crypto.Hash(0))
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.EncodeSegment(sig), nil
+}
@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -11,13 +14,33 @@ import (
 
 // TokenManager provides logic for JWT & Refresh tokens generation and parsing.
 type TokenManager interface {
-	NewJWT(userId string, ttl time.Duration) (string, error)
-	Parse(accessToken string) (string, error)
+	NewJWT(claims Claims, ttl time.Duration) (string, error)
+	Parse(accessToken string) (Claims, error)
 	NewRefreshToken() (string, error)
+	JWKS() JWKSet
 }
 
+// Claims describes who the access token was issued to and where it can be used.
+type Claims struct {
+	UserID   string
+	Role     string
+	SchoolID string
+	Audience string
+}
+
+type tokenClaims struct {
+	jwt.StandardClaims
+	Role     string `json:"role"`
+	SchoolID string `json:"schoolId,omitempty"`
+}
+
+// Manager signs access tokens with one of the asymmetric keys (RS256 or EdDSA) and verifies them
+// with any key, which isn't retired yet, so keys could be rotated without invalidating issued tokens.
+// Without asymmetric keys Manager falls back to HS256 with a single shared signing key.
 type Manager struct {
-	signingKey string
+	signingKey   string
+	signingKeyID string
+	keys         map[string]Key
 }
 
 func NewManager(signingKey string) (*Manager, error) {
@@ -28,44 +51,158 @@ func NewManager(signingKey string) (*Manager, error) {
 	return &Manager{signingKey: signingKey}, nil
 }
 
-func (m *Manager) NewJWT(userId string, ttl time.Duration) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(ttl).Unix(),
-		Subject:   userId,
+// NewKeySetManager creates Manager, which signs new tokens with the key signingKeyID.
+func NewKeySetManager(signingKeyID string, keys []Key) (*Manager, error) {
+	m := &Manager{signingKeyID: signingKeyID, keys: make(map[string]Key, len(keys))}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key id is empty")
+		}
+
+		if _, ex := m.keys[key.ID]; ex {
+			return nil, fmt.Errorf("duplicate key id: %s", key.ID)
+		}
+
+		if _, err := key.signingMethod(); err != nil {
+			return nil, err
+		}
+
+		m.keys[key.ID] = key
+	}
+
+	signer, ex := m.keys[signingKeyID]
+	if !ex {
+		return nil, fmt.Errorf("signing key %s not found", signingKeyID)
+	}
+
+	if !signer.RetireAt.IsZero() {
+		return nil, fmt.Errorf("signing key %s is retired", signingKeyID)
+	}
+
+	return m, nil
+}
+
+func (m *Manager) NewJWT(claims Claims, ttl time.Duration) (string, error) {
+	method, key, err := m.signer()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(ttl).Unix(),
+			Subject:   claims.UserID,
+			Audience:  claims.Audience,
+		},
+		Role:     claims.Role,
+		SchoolID: claims.SchoolID,
+	})
+
+	if m.signingKeyID != "" {
+		token.Header["kid"] = m.signingKeyID
+	}
+
+	return token.SignedString(key)
+}
+
+func (m *Manager) Parse(accessToken string) (Claims, error) {
+	token, err := jwt.ParseWithClaims(accessToken, &tokenClaims{}, m.verificationKey)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	claims, ok := token.Claims.(*tokenClaims)
+	if !ok {
+		return Claims{}, fmt.Errorf("error get user claims from token")
+	}
+
+	if claims.Subject == "" || claims.Role == "" {
+		return Claims{}, errors.New("token is missing required claims")
+	}
+
+	return Claims{
+		UserID:   claims.Subject,
+		Role:     claims.Role,
+		SchoolID: claims.SchoolID,
+		Audience: claims.Audience,
+	}, nil
+}
+
+// JWKS returns public keys, which are currently accepted for verification.
+func (m *Manager) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+
+	now := time.Now()
+
+	for _, key := range m.keys {
+		if !key.retired(now) {
+			set.Keys = append(set.Keys, key.jwk())
+		}
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
 	})
 
-	return token.SignedString([]byte(m.signingKey))
+	return set
 }
 
-func (m *Manager) Parse(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
+func (m *Manager) signer() (jwt.SigningMethod, interface{}, error) {
+	if m.signingKeyID == "" {
+		return jwt.SigningMethodHS256, []byte(m.signingKey), nil
+	}
+
+	key := m.keys[m.signingKeyID]
+
+	method, err := key.signingMethod()
+
+	return method, key.PrivateKey, err
+}
+
+func (m *Manager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if m.signingKeyID == "" {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
 		return []byte(m.signingKey), nil
-	})
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	key, ex := m.keys[kid]
+	if !ex || key.retired(time.Now()) {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+
+	// algorithm is bound to the key, so token can't choose how it is verified
+	method, err := key.signingMethod()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", fmt.Errorf("error get user claims from token")
+	if token.Method.Alg() != method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
 
-	return claims["sub"].(string), nil
+	return key.PrivateKey.Public(), nil
 }
 
 func (m *Manager) NewRefreshToken() (string, error) {
 	b := make([]byte, 32)
 
-	s := rand.NewSource(time.Now().Unix())
-	r := rand.New(s)
-
-	if _, err := r.Read(b); err != nil {
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%x", b), nil
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken returns SHA256 hash of an opaque token (e.g. refresh token), so it could be stored instead of the token itself.
+// Tokens are random and long enough, so there is no need in salt or slow hash functions.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
 }
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestManager_NewRefreshTokenUnique(t *testing.T) {
+	manager, err := NewManager("signing_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers, tokensPerWorker = 16, 500
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		tokens = make(map[string]struct{}, workers*tokensPerWorker)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < tokensPerWorker; j++ {
+				token, err := manager.NewRefreshToken()
+				if err != nil {
+					t.Error(err)
+
+					return
+				}
+
+				mu.Lock()
+				tokens[token] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(tokens) != workers*tokensPerWorker {
+		t.Errorf("expected %d unique tokens, got %d", workers*tokensPerWorker, len(tokens))
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	if HashToken("token") != HashToken("token") {
+		t.Error("hash of the same token differs")
+	}
+
+	if HashToken("token") == HashToken("token2") {
+		t.Error("hashes of different tokens are equal")
+	}
+}
+
+func newTestKeys(t *testing.T) (Key, Key) {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Key{ID: "rsa", PrivateKey: rsaKey}, Key{ID: "ed", PrivateKey: edKey}
+}
+
+func TestKeySetManager_Rotation(t *testing.T) {
+	rsaKey, edKey := newTestKeys(t)
+	claims := Claims{UserID: "user", Role: "student", SchoolID: "school", Audience: "school.creatly.me"}
+
+	oldManager, err := NewKeySetManager(rsaKey.ID, []Key{rsaKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldToken, err := oldManager.NewJWT(claims, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// new key is used for signing, old one is accepted during grace period
+	rsaKey.RetireAt = time.Now().Add(time.Minute)
+
+	manager, err := NewKeySetManager(edKey.ID, []Key{rsaKey, edKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newToken, err := manager.NewJWT(claims, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []string{oldToken, newToken} {
+		parsed, err := manager.Parse(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if parsed != claims {
+			t.Errorf("expected %v, got %v", claims, parsed)
+		}
+	}
+
+	if _, err := oldManager.Parse(newToken); err == nil {
+		t.Error("token signed with unknown key is accepted")
+	}
+
+	if keys := manager.JWKS().Keys; len(keys) != 2 || keys[0].KeyID != "ed" || keys[0].Algorithm != "EdDSA" || keys[1].KeyType != "RSA" {
+		t.Errorf("unexpected jwks: %v", keys)
+	}
+
+	// grace period is over
+	rsaKey.RetireAt = time.Now()
+
+	manager, err = NewKeySetManager(edKey.ID, []Key{rsaKey, edKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := manager.Parse(oldToken); err == nil {
+		t.Error("token signed with retired key is accepted")
+	}
+
+	if keys := manager.JWKS().Keys; len(keys) != 1 {
+		t.Errorf("retired key is published: %v", keys)
+	}
+}
+
+func TestKeySetManager_RejectsHMAC(t *testing.T) {
+	rsaKey, _ := newTestKeys(t)
+
+	hmacManager, err := NewManager("signing_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := hmacManager.NewJWT(Claims{UserID: "user", Role: "student"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := NewKeySetManager(rsaKey.ID, []Key{rsaKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := manager.Parse(token); err == nil {
+		t.Error("HS256 token is accepted")
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	_, edKey := newTestKeys(t)
+	dir := t.TempDir()
+
+	der, err := x509.MarshalPKCS8PrivateKey(edKey.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "2026-10.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 1 || keys[0].ID != "2026-10" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
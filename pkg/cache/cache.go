@@ -3,4 +3,8 @@ package cache
 type Cache interface {
 	Set(key, value interface{}, ttl int64) error
 	Get(key interface{}) (interface{}, error)
+	Delete(key interface{}) error
+	// Increment atomically adds one to the integer value and returns the result, missing value starts from zero.
+	// TTL is set on every increment, so the value expires ttl seconds after the last one.
+	Increment(key interface{}, ttl int64) (int64, error)
 }
@@ -6,7 +6,10 @@ import (
 	"time"
 )
 
-var ErrItemNotFound = errors.New("cache: item not found")
+var (
+	ErrItemNotFound     = errors.New("cache: item not found")
+	ErrItemIsNotInteger = errors.New("cache: item is not an integer")
+)
 
 type item struct {
 	value     interface{}
@@ -31,7 +34,7 @@ func (c *MemoryCache) setTtlTimer() {
 	for {
 		c.Lock()
 		for k, v := range c.cache {
-			if time.Now().Unix()-v.createdAt > v.ttl {
+			if v.expired() {
 				delete(c.cache, k)
 			}
 		}
@@ -58,9 +61,47 @@ func (c *MemoryCache) Get(key interface{}) (interface{}, error) {
 	item, ex := c.cache[key]
 	c.RUnlock()
 
-	if !ex {
+	if !ex || item.expired() {
 		return nil, ErrItemNotFound
 	}
 
 	return item.value, nil
 }
+
+func (c *MemoryCache) Delete(key interface{}) error {
+	c.Lock()
+	delete(c.cache, key)
+	c.Unlock()
+
+	return nil
+}
+
+func (c *MemoryCache) Increment(key interface{}, ttl int64) (int64, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	var value int64
+
+	if item, ex := c.cache[key]; ex && !item.expired() {
+		current, ok := item.value.(int64)
+		if !ok {
+			return 0, ErrItemIsNotInteger
+		}
+
+		value = current
+	}
+
+	value++
+
+	c.cache[key] = &item{
+		value:     value,
+		createdAt: time.Now().Unix(),
+		ttl:       ttl,
+	}
+
+	return value, nil
+}
+
+func (i *item) expired() bool {
+	return time.Now().Unix()-i.createdAt > i.ttl
+}
@@ -0,0 +1,120 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var errInvalidArgon2Hash = errors.New("invalid argon2id hash")
+
+// Argon2Params are argon2id cost parameters, see RFC 9106 for recommendations.
+type Argon2Params struct {
+	Time       uint32
+	Memory     uint32 // KiB
+	Threads    uint8
+	KeyLength  uint32
+	SaltLength uint32
+}
+
+var DefaultArgon2Params = Argon2Params{
+	Time:       1,
+	Memory:     64 * 1024,
+	Threads:    4,
+	KeyLength:  32,
+	SaltLength: 16,
+}
+
+// Argon2Hasher uses argon2id with a random salt per password.
+// Hashes are encoded in PHC string format: $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>.
+type Argon2Hasher struct {
+	params Argon2Params
+}
+
+func NewArgon2Hasher(params Argon2Params) *Argon2Hasher {
+	return &Argon2Hasher{params: params}
+}
+
+func (h *Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Time, h.params.Memory, h.params.Threads, h.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.params.Memory, h.params.Time, h.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2Hasher) Verify(password, encodedHash string) (bool, error) {
+	params, salt, key, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
+
+func (h *Argon2Hasher) NeedsRehash(encodedHash string) bool {
+	params, salt, _, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	params.SaltLength = uint32(len(salt))
+
+	return params != h.params
+}
+
+func (h *Argon2Hasher) Supports(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, argon2idPrefix)
+}
+
+func decodeArgon2Hash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+	var (
+		params  Argon2Params
+		version int
+	)
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
+	}
+
+	if version != argon2.Version {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
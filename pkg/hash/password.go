@@ -2,15 +2,66 @@ package hash
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"strings"
 )
 
+var ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+
 // PasswordHasher provides hashing logic to securely store passwords.
 type PasswordHasher interface {
 	Hash(password string) (string, error)
+	Verify(password, encodedHash string) (bool, error)
+	NeedsRehash(encodedHash string) bool
+}
+
+// Algorithm is a PasswordHasher that is able to recognize hashes produced by itself.
+type Algorithm interface {
+	PasswordHasher
+	Supports(encodedHash string) bool
+}
+
+// MultiHasher hashes new passwords with the primary algorithm
+// and verifies hashes produced by any of the registered algorithms.
+type MultiHasher struct {
+	primary    Algorithm
+	algorithms []Algorithm
+}
+
+func NewMultiHasher(primary Algorithm, legacy ...Algorithm) *MultiHasher {
+	return &MultiHasher{
+		primary:    primary,
+		algorithms: append([]Algorithm{primary}, legacy...),
+	}
+}
+
+func (h *MultiHasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+func (h *MultiHasher) Verify(password, encodedHash string) (bool, error) {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Supports(encodedHash) {
+			return algorithm.Verify(password, encodedHash)
+		}
+	}
+
+	return false, ErrUnknownAlgorithm
+}
+
+// NeedsRehash reports whether hash was produced by another algorithm or with outdated parameters.
+func (h *MultiHasher) NeedsRehash(encodedHash string) bool {
+	if !h.primary.Supports(encodedHash) {
+		return true
+	}
+
+	return h.primary.NeedsRehash(encodedHash)
 }
 
 // SHA1Hasher uses SHA1 to hash passwords with provided salt.
+// It's kept only to verify legacy hashes, which are upgraded on the next successful sign in.
 type SHA1Hasher struct {
 	salt string
 }
@@ -29,3 +80,47 @@ func (h *SHA1Hasher) Hash(password string) (string, error) {
 
 	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
 }
+
+func (h *SHA1Hasher) Verify(password, encodedHash string) (bool, error) {
+	hash, err := h.Hash(password)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(encodedHash)) == 1, nil
+}
+
+func (h *SHA1Hasher) NeedsRehash(encodedHash string) bool {
+	return false
+}
+
+// Supports reports whether hash is a legacy one, legacy hashes don't have an algorithm prefix.
+func (h *SHA1Hasher) Supports(encodedHash string) bool {
+	return encodedHash != "" && !strings.HasPrefix(encodedHash, "$")
+}
+
+// PlaintextHasher verifies passwords, which were stored without hashing.
+// It's kept only to verify legacy admin passwords, which are upgraded on the next successful sign in.
+type PlaintextHasher struct{}
+
+func NewPlaintextHasher() *PlaintextHasher {
+	return &PlaintextHasher{}
+}
+
+// Hash is never used for new passwords, because PlaintextHasher is registered only as a legacy algorithm.
+func (h *PlaintextHasher) Hash(password string) (string, error) {
+	return password, nil
+}
+
+func (h *PlaintextHasher) Verify(password, encodedHash string) (bool, error) {
+	return subtle.ConstantTimeCompare([]byte(password), []byte(encodedHash)) == 1, nil
+}
+
+func (h *PlaintextHasher) NeedsRehash(encodedHash string) bool {
+	return true
+}
+
+// Supports reports whether password is a legacy one, legacy passwords don't have an algorithm prefix.
+func (h *PlaintextHasher) Supports(encodedHash string) bool {
+	return encodedHash != "" && !strings.HasPrefix(encodedHash, "$")
+}
@@ -0,0 +1,191 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testArgon2Params = Argon2Params{
+	Time:       1,
+	Memory:     1024,
+	Threads:    1,
+	KeyLength:  32,
+	SaltLength: 16,
+}
+
+func TestAlgorithms_HashVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+		prefix    string
+	}{
+		{
+			name:      "bcrypt",
+			algorithm: NewBcryptHasher(bcrypt.MinCost),
+			prefix:    "$2a$",
+		},
+		{
+			name:      "argon2id",
+			algorithm: NewArgon2Hasher(testArgon2Params),
+			prefix:    "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		{
+			name:      "sha1",
+			algorithm: NewSHA1Hasher("salt"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passwordHash, err := tt.algorithm.Hash("qwerty123")
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(passwordHash, tt.prefix))
+			require.True(t, tt.algorithm.Supports(passwordHash))
+
+			ok, err := tt.algorithm.Verify("qwerty123", passwordHash)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			ok, err = tt.algorithm.Verify("wrong", passwordHash)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestArgon2Hasher_HashUsesRandomSalt(t *testing.T) {
+	hasher := NewArgon2Hasher(testArgon2Params)
+
+	first, err := hasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	second, err := hasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+}
+
+func TestArgon2Hasher_VerifyInvalidHash(t *testing.T) {
+	hasher := NewArgon2Hasher(testArgon2Params)
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "not enough parts", hash: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA"},
+		{name: "unsupported version", hash: "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5"},
+		{name: "invalid params", hash: "$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5"},
+		{name: "invalid salt", hash: "$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5"},
+		{name: "invalid key", hash: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := hasher.Verify("qwerty123", tt.hash)
+			require.Error(t, err)
+			require.False(t, ok)
+			require.True(t, hasher.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestAlgorithms_NeedsRehash(t *testing.T) {
+	bcryptHash, err := NewBcryptHasher(bcrypt.MinCost).Hash("qwerty123")
+	require.NoError(t, err)
+
+	argon2Hash, err := NewArgon2Hasher(testArgon2Params).Hash("qwerty123")
+	require.NoError(t, err)
+
+	outdatedParams := testArgon2Params
+	outdatedParams.Memory = 512
+
+	tests := []struct {
+		name      string
+		algorithm PasswordHasher
+		hash      string
+		want      bool
+	}{
+		{
+			name:      "bcrypt same cost",
+			algorithm: NewBcryptHasher(bcrypt.MinCost),
+			hash:      bcryptHash,
+			want:      false,
+		},
+		{
+			name:      "bcrypt other cost",
+			algorithm: NewBcryptHasher(bcrypt.MinCost + 1),
+			hash:      bcryptHash,
+			want:      true,
+		},
+		{
+			name:      "argon2id same params",
+			algorithm: NewArgon2Hasher(testArgon2Params),
+			hash:      argon2Hash,
+			want:      false,
+		},
+		{
+			name:      "argon2id other params",
+			algorithm: NewArgon2Hasher(outdatedParams),
+			hash:      argon2Hash,
+			want:      true,
+		},
+		{
+			name:      "plaintext",
+			algorithm: NewPlaintextHasher(),
+			hash:      "qwerty123",
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.algorithm.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestMultiHasher(t *testing.T) {
+	sha1Hasher := NewSHA1Hasher("salt")
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	hasher := NewMultiHasher(NewArgon2Hasher(testArgon2Params), bcryptHasher, sha1Hasher)
+
+	argon2Hash, err := hasher.Hash("qwerty123")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(argon2Hash, argon2idPrefix))
+
+	bcryptHash, err := bcryptHasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	sha1Hash, err := sha1Hasher.Hash("qwerty123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		hash        string
+		password    string
+		ok          bool
+		err         error
+		needsRehash bool
+	}{
+		{name: "primary", hash: argon2Hash, password: "qwerty123", ok: true},
+		{name: "primary wrong password", hash: argon2Hash, password: "wrong"},
+		{name: "legacy bcrypt", hash: bcryptHash, password: "qwerty123", ok: true, needsRehash: true},
+		{name: "legacy sha1", hash: sha1Hash, password: "qwerty123", ok: true, needsRehash: true},
+		{name: "legacy wrong password", hash: sha1Hash, password: "wrong", needsRehash: true},
+		{name: "unknown algorithm", hash: "$md5$hash", password: "qwerty123", err: ErrUnknownAlgorithm, needsRehash: true},
+		{name: "empty hash", hash: "", password: "", err: ErrUnknownAlgorithm, needsRehash: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := hasher.Verify(tt.password, tt.hash)
+			require.True(t, errors.Is(err, tt.err))
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.needsRehash, hasher.NeedsRehash(tt.hash))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProvider struct {
+	mock.Mock
+}
+
+func (m *MockProvider) Discover(ctx context.Context, issuer string) (Endpoints, error) {
+	args := m.Called(ctx, issuer)
+
+	return args.Get(0).(Endpoints), args.Error(1)
+}
+
+func (m *MockProvider) Exchange(ctx context.Context, input ExchangeInput) (string, error) {
+	args := m.Called(ctx, input)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProvider) UserInfo(ctx context.Context, userInfoURL, accessToken string) (UserInfo, error) {
+	args := m.Called(ctx, userInfoURL, accessToken)
+
+	return args.Get(0).(UserInfo), args.Error(1)
+}
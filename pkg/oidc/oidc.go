@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Documentation
+// https://openid.net/specs/openid-connect-core-1_0.html#CodeFlowAuth
+// https://openid.net/specs/openid-connect-discovery-1_0.html
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// Endpoints of the OpenID provider. Usually they are discovered by issuer URL,
+// but can be set explicitly for providers without discovery document.
+type Endpoints struct {
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+}
+
+type ExchangeInput struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+}
+
+// UserInfo contains standard claims returned by userinfo endpoint.
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+type Provider interface {
+	Discover(ctx context.Context, issuer string) (Endpoints, error)
+	Exchange(ctx context.Context, input ExchangeInput) (string, error)
+	UserInfo(ctx context.Context, userInfoURL, accessToken string) (UserInfo, error)
+}
+
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{httpClient: httpClient}
+}
+
+// AuthCodeURL builds URL of the provider's consent page, user is redirected back to redirectURI with code and state.
+func AuthCodeURL(authURL, clientID, redirectURI, state string) string {
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {clientID},
+		"redirect_uri":  {redirectURI},
+		"scope":         {strings.Join(defaultScopes, " ")},
+		"state":         {state},
+	}
+
+	separator := "?"
+	if strings.Contains(authURL, "?") {
+		separator = "&"
+	}
+
+	return authURL + separator + params.Encode()
+}
+
+func (c *Client) Discover(ctx context.Context, issuer string) (Endpoints, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return Endpoints{}, err
+	}
+
+	var endpoints Endpoints
+	if err := c.do(req, &endpoints); err != nil {
+		return Endpoints{}, err
+	}
+
+	if endpoints.AuthURL == "" || endpoints.TokenURL == "" || endpoints.UserInfoURL == "" {
+		return Endpoints{}, errors.New("discovery document doesn't contain required endpoints")
+	}
+
+	return endpoints, nil
+}
+
+// Exchange trades authorization code for access token.
+func (c *Client) Exchange(ctx context.Context, input ExchangeInput) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {input.Code},
+		"redirect_uri":  {input.RedirectURI},
+		"client_id":     {input.ClientID},
+		"client_secret": {input.ClientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, input.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := c.do(req, &token); err != nil {
+		return "", err
+	}
+
+	if token.AccessToken == "" {
+		return "", errors.New("token response doesn't contain access token")
+	}
+
+	return token.AccessToken, nil
+}
+
+func (c *Client) UserInfo(ctx context.Context, userInfoURL, accessToken string) (UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userInfoURL, nil)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var info UserInfo
+	if err := c.do(req, &info); err != nil {
+		return UserInfo{}, err
+	}
+
+	if info.Subject == "" {
+		return UserInfo{}, errors.New("userinfo response doesn't contain subject")
+	}
+
+	return info, nil
+}
+
+func (c *Client) do(req *http.Request, out interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc provider responded with status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProvider starts local stand-in for OpenID provider, which accepts only the given code.
+func newTestProvider(t *testing.T, code string, info UserInfo) *httptest.Server {
+	t.Helper()
+
+	const accessToken = "access-token"
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Endpoints{ //nolint:errcheck
+			AuthURL:     server.URL + "/authorize",
+			TokenURL:    server.URL + "/token",
+			UserInfoURL: server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != code || r.PostFormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"access_token": accessToken}) //nolint:errcheck
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(info) //nolint:errcheck
+	})
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_CodeFlow(t *testing.T) {
+	info := UserInfo{Subject: "123", Email: "student@test.com", EmailVerified: true, Name: "Student"}
+	server := newTestProvider(t, "code", info)
+	client := NewClient(server.Client())
+	ctx := context.Background()
+
+	endpoints, err := client.Discover(ctx, server.URL+"/")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/token", endpoints.TokenURL)
+
+	accessToken, err := client.Exchange(ctx, ExchangeInput{
+		TokenURL:     endpoints.TokenURL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Code:         "code",
+		RedirectURI:  "https://school.creatly.me/oidc/test/callback",
+	})
+	require.NoError(t, err)
+
+	res, err := client.UserInfo(ctx, endpoints.UserInfoURL, accessToken)
+	require.NoError(t, err)
+	require.Equal(t, info, res)
+}
+
+func TestClient_ExchangeInvalidCode(t *testing.T) {
+	server := newTestProvider(t, "code", UserInfo{Subject: "123"})
+	client := NewClient(server.Client())
+
+	_, err := client.Exchange(context.Background(), ExchangeInput{
+		TokenURL:     server.URL + "/token",
+		ClientSecret: "secret",
+		Code:         "wrong",
+	})
+	require.Error(t, err)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	authURL, err := url.Parse(AuthCodeURL("https://accounts.test/authorize?prompt=login", "client", "https://school/callback", "state"))
+	require.NoError(t, err)
+
+	query := authURL.Query()
+	require.Equal(t, "login", query.Get("prompt"))
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "client", query.Get("client_id"))
+	require.Equal(t, "https://school/callback", query.Get("redirect_uri"))
+	require.Equal(t, "state", query.Get("state"))
+	require.Equal(t, "openid email profile", query.Get("scope"))
+}
@@ -1,6 +1,10 @@
 package otp
 
-import "github.com/stretchr/testify/mock"
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockGenerator struct {
 	mock.Mock
@@ -11,3 +15,15 @@ func (m *MockGenerator) RandomSecret(length int) string {
 
 	return args.Get(0).(string)
 }
+
+func (m *MockGenerator) ProvisioningURI(secret, accountName, issuer string) string {
+	args := m.Called(secret, accountName, issuer)
+
+	return args.Get(0).(string)
+}
+
+func (m *MockGenerator) ValidateTOTP(secret, code string, at time.Time) (int64, bool) {
+	args := m.Called(secret, code, at)
+
+	return args.Get(0).(int64), args.Bool(1)
+}
@@ -1,6 +1,11 @@
 package otp
 
-import "github.com/xlzd/gotp"
+import (
+	"crypto/rand"
+)
+
+// base32Alphabet is used by gotp.RandomSecret as well, so generated secrets are compatible with OTP apps.
+const base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
 
 type Generator interface {
 	RandomSecret(length int) string
@@ -12,6 +17,18 @@ func NewGOTPGenerator() *GOTPGenerator {
 	return &GOTPGenerator{}
 }
 
+// RandomSecret generates secret using crypto/rand, gotp.RandomSecret relies on math/rand which is predictable.
 func (g *GOTPGenerator) RandomSecret(length int) string {
-	return gotp.RandomSecret(length)
+	b := make([]byte, length)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+
+	// len(base32Alphabet) is a divisor of 256, so each symbol is equally likely
+	for i := range b {
+		b[i] = base32Alphabet[int(b[i])%len(base32Alphabet)]
+	}
+
+	return string(b)
 }
@@ -0,0 +1,83 @@
+package otp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xlzd/gotp"
+)
+
+func TestGOTPGenerator_RandomSecretUnique(t *testing.T) {
+	generator := NewGOTPGenerator()
+
+	const workers, secretsPerWorker = 16, 500
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		secrets = make(map[string]struct{}, workers*secretsPerWorker)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < secretsPerWorker; j++ {
+				secret := generator.RandomSecret(16)
+
+				mu.Lock()
+				secrets[secret] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(secrets) != workers*secretsPerWorker {
+		t.Errorf("expected %d unique secrets, got %d", workers*secretsPerWorker, len(secrets))
+	}
+}
+
+func TestGOTPGenerator_RandomSecretAlphabet(t *testing.T) {
+	secret := NewGOTPGenerator().RandomSecret(64)
+
+	if len(secret) != 64 {
+		t.Fatalf("expected secret of length 64, got %d", len(secret))
+	}
+
+	for _, r := range secret {
+		if !strings.ContainsRune(base32Alphabet, r) {
+			t.Errorf("unexpected symbol %q in secret", r)
+		}
+	}
+}
+
+func TestGOTPGenerator_ValidateTOTP(t *testing.T) {
+	generator := NewGOTPGenerator()
+	secret := generator.RandomSecret(32)
+	now := time.Now()
+
+	code := gotp.NewTOTP(secret, totpDigits, totpInterval, nil).At(int(now.Unix()))
+
+	step, ok := generator.ValidateTOTP(secret, code, now)
+	if !ok {
+		t.Fatal("expected current code to be valid")
+	}
+
+	if step != now.Unix()/totpInterval {
+		t.Errorf("expected step %d, got %d", now.Unix()/totpInterval, step)
+	}
+
+	if _, ok := generator.ValidateTOTP(secret, code, now.Add(totpInterval*time.Second)); !ok {
+		t.Error("expected code from previous interval to be valid")
+	}
+
+	if _, ok := generator.ValidateTOTP(secret, code, now.Add(5*totpInterval*time.Second)); ok {
+		t.Error("expected outdated code to be invalid")
+	}
+}
@@ -0,0 +1,40 @@
+package otp
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/xlzd/gotp"
+)
+
+const (
+	totpDigits   = 6
+	totpInterval = 30
+	// totpSkew is a number of intervals before and after the current one, codes from which are still accepted.
+	totpSkew = 1
+)
+
+// TOTP provides time-based one-time passwords (RFC 6238), compatible with Google Authenticator, Authy, etc.
+type TOTP interface {
+	ProvisioningURI(secret, accountName, issuer string) string
+	// ValidateTOTP returns time step the code belongs to, so callers can reject codes which were already used.
+	ValidateTOTP(secret, code string, at time.Time) (int64, bool)
+}
+
+func (g *GOTPGenerator) ProvisioningURI(secret, accountName, issuer string) string {
+	return gotp.NewTOTP(secret, totpDigits, totpInterval, nil).ProvisioningUri(accountName, issuer)
+}
+
+func (g *GOTPGenerator) ValidateTOTP(secret, code string, at time.Time) (int64, bool) {
+	totp := gotp.NewTOTP(secret, totpDigits, totpInterval, nil)
+	step := at.Unix() / totpInterval
+
+	for i := step - totpSkew; i <= step+totpSkew; i++ {
+		expected := totp.At(int(i * totpInterval))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
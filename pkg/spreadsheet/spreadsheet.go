@@ -0,0 +1,42 @@
+package spreadsheet
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown spreadsheet format")
+
+// Writer writes table row by row, so large tables can be streamed without keeping them in memory.
+// Close must be called to flush buffered data, it doesn't close the underlying io.Writer.
+type Writer interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
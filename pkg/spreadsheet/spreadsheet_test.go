@@ -0,0 +1,70 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewCSVWriter(&buf)
+	require.NoError(t, w.WriteRow([]string{"name", "email"}))
+	require.NoError(t, w.WriteRow([]string{"=HYPERLINK(\"x\")", "a,b@test.com"}))
+	require.NoError(t, w.Close())
+
+	require.Equal(t, "name,email\n\"'=HYPERLINK(\"\"x\"\")\",\"a,b@test.com\"\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewXLSXWriter(&buf)
+	require.NoError(t, w.WriteRow([]string{"name", "email"}))
+	require.NoError(t, w.WriteRow([]string{"<Student & Co>", "student@test.com\x00"}))
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+	}
+
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "xl/workbook.xml")
+
+	var sheet struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R    string `xml:"r,attr"`
+				Text string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+
+	require.NoError(t, xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 2)
+	require.Equal(t, "2", sheet.Rows[1].R)
+	require.Equal(t, "B2", sheet.Rows[1].Cells[1].R)
+	require.Equal(t, "<Student & Co>", sheet.Rows[1].Cells[0].Text)
+	require.Equal(t, "student@test.com", sheet.Rows[1].Cells[1].Text)
+}
+
+func TestColumnName(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		require.Equal(t, name, columnName(i))
+	}
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 )
@@ -23,8 +24,11 @@ func NewFileStorage(client *minio.Client, bucket, endpoint string) *FileStorage
 
 func (fs *FileStorage) Upload(ctx context.Context, input UploadInput) (string, error) {
 	opts := minio.PutObjectOptions{
-		ContentType:  input.ContentType,
-		UserMetadata: map[string]string{"x-amz-acl": "public-read"},
+		ContentType: input.ContentType,
+	}
+
+	if !input.Private {
+		opts.UserMetadata = map[string]string{"x-amz-acl": "public-read"}
 	}
 
 	_, err := fs.client.PutObject(ctx, fs.bucket, input.Name, input.File, input.Size, opts)
@@ -35,6 +39,68 @@ func (fs *FileStorage) Upload(ctx context.Context, input UploadInput) (string, e
 	return fs.generateFileURL(input.Name), nil
 }
 
+func (fs *FileStorage) GetSignedURL(ctx context.Context, name string, expires time.Duration) (string, error) {
+	url, err := fs.client.PresignedGetObject(ctx, fs.bucket, name, expires, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return url.String(), nil
+}
+
+// Remove lists matched objects and removes them in batches.
+func (fs *FileStorage) Remove(ctx context.Context, input RemoveInput) error {
+	// listing is cancelled when removal stops early, otherwise it would block on sending forever
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make(chan minio.ObjectInfo)
+	listDone := make(chan struct{})
+
+	var listErr error
+
+	go func() {
+		defer close(listDone)
+		defer close(objects)
+
+		for object := range fs.client.ListObjects(listCtx, fs.bucket, minio.ListObjectsOptions{Prefix: input.Prefix, Recursive: true}) {
+			if object.Err != nil {
+				listErr = object.Err
+
+				return
+			}
+
+			if !input.ModifiedBefore.IsZero() && !object.LastModified.Before(input.ModifiedBefore) {
+				continue
+			}
+
+			select {
+			case objects <- object:
+			case <-listCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var err error
+
+	// errors channel is drained, so listing and removal aren't blocked
+	for removeErr := range fs.client.RemoveObjects(ctx, fs.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if err == nil {
+			err = removeErr.Err
+		}
+	}
+
+	cancel()
+	<-listDone
+
+	if err != nil {
+		return err
+	}
+
+	return listErr
+}
+
 // DigitalOcean Spaces URL format.
 func (fs *FileStorage) generateFileURL(filename string) string {
 	return fmt.Sprintf("https://%s.%s/%s", fs.bucket, fs.endpoint, filename)
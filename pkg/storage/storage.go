@@ -3,15 +3,27 @@ package storage
 import (
 	"context"
 	"io"
+	"time"
 )
 
+// UploadInput uploads public object, unless Private is set.
 type UploadInput struct {
 	File        io.Reader
 	Name        string
 	Size        int64
 	ContentType string
+	Private     bool
+}
+
+// RemoveInput matches objects by name prefix, and by last modification time if ModifiedBefore is set.
+type RemoveInput struct {
+	Prefix         string
+	ModifiedBefore time.Time
 }
 
 type Provider interface {
 	Upload(ctx context.Context, input UploadInput) (string, error)
+	// GetSignedURL returns URL of the private object, which is valid for the given duration.
+	GetSignedURL(ctx context.Context, name string, expires time.Duration) (string, error)
+	Remove(ctx context.Context, input RemoveInput) error
 }
@@ -19,7 +19,6 @@ func (s *APITestSuite) TestAdminCreateCourse() {
 
 	// populate DB data
 	id := primitive.NewObjectID()
-	schoolID := primitive.NewObjectID()
 	adminEmail, password := "testAdmin@test.com", "qwerty123"
 	passwordHash, err := s.hasher.Hash(password)
 	s.NoError(err)
@@ -28,11 +27,11 @@ func (s *APITestSuite) TestAdminCreateCourse() {
 		ID:       id,
 		Email:    adminEmail,
 		Password: passwordHash,
-		SchoolID: schoolID,
+		SchoolID: school.ID,
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleAdmin)
 	s.NoError(err)
 
 	adminCourseName := "admin course test name"
@@ -55,7 +54,6 @@ func (s *APITestSuite) TestAdminGetAllCourses() {
 	r := s.Require()
 
 	id := primitive.NewObjectID()
-	schoolID := primitive.NewObjectID()
 	adminEmail, password := "testAdmin@test.com", "qwerty123"
 	passwordHash, err := s.hasher.Hash(password)
 	s.NoError(err)
@@ -64,11 +62,11 @@ func (s *APITestSuite) TestAdminGetAllCourses() {
 		ID:       id,
 		Email:    adminEmail,
 		Password: passwordHash,
-		SchoolID: schoolID,
+		SchoolID: school.ID,
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleAdmin)
 	s.NoError(err)
 
 	req, _ := http.NewRequest("GET", "/api/v1/admins/courses", nil)
@@ -87,7 +85,6 @@ func (s *APITestSuite) TestAdminGetCourseByID() {
 	r := s.Require()
 
 	id := primitive.NewObjectID()
-	schoolID := primitive.NewObjectID()
 	adminEmail, password := "testAdmin@test.com", "qwerty123"
 	passwordHash, err := s.hasher.Hash(password)
 	s.NoError(err)
@@ -96,11 +93,11 @@ func (s *APITestSuite) TestAdminGetCourseByID() {
 		ID:       id,
 		Email:    adminEmail,
 		Password: passwordHash,
-		SchoolID: schoolID,
+		SchoolID: school.ID,
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleAdmin)
 	s.NoError(err)
 
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/admins/courses/%s", school.Courses[0].ID.Hex()), nil)
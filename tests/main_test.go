@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -16,8 +17,10 @@ import (
 	"github.com/zhashkevych/creatly-backend/pkg/database/mongodb"
 	emailmock "github.com/zhashkevych/creatly-backend/pkg/email/mock"
 	"github.com/zhashkevych/creatly-backend/pkg/hash"
+	"github.com/zhashkevych/creatly-backend/pkg/oidc"
 	"github.com/zhashkevych/creatly-backend/pkg/otp"
 	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var dbURI, dbName string
@@ -76,7 +79,7 @@ func (s *APITestSuite) initDeps() {
 	// Init domain deps
 	repos := repository.NewRepositories(s.db)
 	memCache := cache.NewMemoryCache()
-	hasher := hash.NewSHA1Hasher("salt")
+	hasher := hash.NewMultiHasher(hash.NewBcryptHasher(bcrypt.MinCost), hash.NewSHA1Hasher("salt"))
 
 	tokenManager, err := auth.NewManager("signing_key")
 	if err != nil {
@@ -94,17 +97,42 @@ func (s *APITestSuite) initDeps() {
 			Templates: config.EmailTemplates{
 				Verification:       "../templates/verification_email.html",
 				PurchaseSuccessful: "../templates/purchase_successful.html",
+				PasswordReset:      "../templates/password_reset.html",
+				AccountLocked:      "../templates/account_locked.html",
+				AdminInvitation:    "../templates/admin_invitation.html",
+				MagicLink:          "../templates/magic_link.html",
+				EmailChange:        "../templates/email_change.html",
+				DataExport:         "../templates/data_export.html",
+				StudentWelcome:     "../templates/student_welcome.html",
 			},
 			Subjects: config.EmailSubjects{
 				Verification:       "Спасибо за регистрацию, %s!",
 				PurchaseSuccessful: "Покупка прошла успешно!",
+				PasswordReset:      "Восстановление пароля, %s",
+				AccountLocked:      "Вход в аккаунт временно заблокирован",
+				AdminInvitation:    "Приглашение в команду школы %s",
+				MagicLink:          "Ссылка для входа",
+				EmailChange:        "Подтверждение нового email",
+				DataExport:         "Твои данные готовы к скачиванию",
+				StudentWelcome:     "Добро пожаловать в школу, %s!",
 			},
 		},
 		AccessTokenTTL:         time.Minute * 15,
 		RefreshTokenTTL:        time.Minute * 15,
 		CacheTTL:               int64(time.Minute.Seconds()),
 		OtpGenerator:           s.mocks.otpGenerator,
+		TOTP:                   s.mocks.otpGenerator,
+		OIDCProvider:           oidc.NewClient(http.DefaultClient),
 		VerificationCodeLength: 8,
+		VerificationCodeTTL:    time.Hour,
+		SignInAttempts: config.SignInAttemptsConfig{
+			FreeAttempts:    3,
+			MaxAttempts:     10,
+			BackoffBase:     time.Second,
+			LockoutDuration: time.Minute,
+		},
+		PasswordResetTokenTTL: time.Minute * 15,
+		MagicLinkTTL:          time.Minute * 15,
 	})
 
 	s.repos = repos
@@ -73,7 +73,7 @@ func (s *APITestSuite) TestFondyCallbackApproved() {
 	// Get Paid Lessons After Callback
 	r = s.Require()
 
-	jwt, err := s.getJwt(studentId)
+	jwt, err := s.getJwt(studentId, domain.RoleStudent)
 	s.NoError(err)
 
 	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/students/modules/%s/content", modules[1].(domain.Module).ID.Hex()), nil)
@@ -125,7 +125,7 @@ func (s *APITestSuite) TestFondyCallbackDeclined() {
 	// Get Paid Lessons After Callback
 	r = s.Require()
 
-	jwt, err := s.getJwt(studentId)
+	jwt, err := s.getJwt(studentId, domain.RoleStudent)
 	s.NoError(err)
 
 	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/students/modules/%s/content", modules[1].(domain.Module).ID.Hex()), nil)
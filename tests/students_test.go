@@ -12,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/creatly-backend/internal/domain"
+	"github.com/zhashkevych/creatly-backend/pkg/auth"
 	"github.com/zhashkevych/creatly-backend/pkg/email"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -52,11 +53,11 @@ func (s *APITestSuite) TestStudentSignUp() {
 	err := s.db.Collection("students").FindOne(context.Background(), bson.M{"email": studentEmail}).Decode(&student)
 	s.NoError(err)
 
-	passwordHash, err := s.hasher.Hash(password)
+	passwordMatches, err := s.hasher.Verify(password, student.Password)
 	s.NoError(err)
 
 	r.Equal(name, student.Name)
-	r.Equal(passwordHash, student.Password)
+	r.True(passwordMatches)
 	r.Equal(false, student.Verification.Verified)
 	r.Equal(verificationCode, student.Verification.Code)
 }
@@ -169,7 +170,7 @@ func (s *APITestSuite) TestStudentGetPaidLessonsWithoutPurchase() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/students/modules/%s/content", modules[1].(domain.Module).ID.Hex()), nil)
@@ -202,7 +203,7 @@ func (s *APITestSuite) TestStudentGetModuleOffers() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/students/modules/%s/offers", modules[1].(domain.Module).ID.Hex()), nil)
@@ -251,7 +252,7 @@ func (s *APITestSuite) TestStudentCreateOrderWithoutPromocode() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	orderData := fmt.Sprintf(`{"offerId":"%s"}`, offers[0].(domain.Offer).ID.Hex())
@@ -295,7 +296,7 @@ func (s *APITestSuite) TestStudentCreateOrderWrongOffer() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	orderData := fmt.Sprintf(`{"offerId":"%s"}`, id.Hex())
@@ -330,7 +331,7 @@ func (s *APITestSuite) TestStudentCreateOrderWithPromocode() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	orderData := fmt.Sprintf(`{"offerId":"%s", "promoId": "%s"}`,
@@ -379,7 +380,7 @@ func (s *APITestSuite) TestStudentCreateOrderWrongPromo() {
 	})
 	s.NoError(err)
 
-	jwt, err := s.getJwt(id)
+	jwt, err := s.getJwt(id, domain.RoleStudent)
 	s.NoError(err)
 
 	orderData := fmt.Sprintf(`{"offerId":"%s", "promoId": "%s"}`,
@@ -395,6 +396,11 @@ func (s *APITestSuite) TestStudentCreateOrderWrongPromo() {
 	r.Equal(http.StatusBadRequest, resp.Result().StatusCode)
 }
 
-func (s *APITestSuite) getJwt(userId primitive.ObjectID) (string, error) {
-	return s.tokenManager.NewJWT(userId.Hex(), time.Hour)
+// getJwt issues a token for the test school, requests in tests are sent without Referer, so the audience is empty.
+func (s *APITestSuite) getJwt(userId primitive.ObjectID, role string) (string, error) {
+	return s.tokenManager.NewJWT(auth.Claims{
+		UserID:   userId.Hex(),
+		Role:     role,
+		SchoolID: school.ID.Hex(),
+	}, time.Hour)
 }